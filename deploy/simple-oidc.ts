@@ -11,6 +11,8 @@ import * as certificatemanager from 'aws-cdk-lib/aws-certificatemanager'
 import * as route53 from 'aws-cdk-lib/aws-route53'
 import { matchingHostedZone } from './lib/domain-tools'
 import * as dynamodb from 'aws-cdk-lib/aws-dynamodb'
+import * as events from 'aws-cdk-lib/aws-events'
+import * as targets from 'aws-cdk-lib/aws-events-targets'
 
 
 import { lookupAccountId } from './lib/account-tools'
@@ -72,12 +74,20 @@ async function init() {
         // no dot . or dash - allowed
 
         'host_name': lambdaHostname,
+        'CLIENT_REGISTRATION': process.env.CLIENT_REGISTRATION || 'closed',
+        'CLIENT_REGISTRATION_TOKEN': process.env.CLIENT_REGISTRATION_TOKEN || '',
 
         'git_hash': process.env.GITHUB_SHA || 'unknown',
         'deploytime': `${new Date()}`,
       },
     })
 
+    // pending back-channel logouts are retried outside of any request
+    new events.Rule(appStack, `${name}-pending-logouts`, {
+      schedule: events.Schedule.rate(cdk.Duration.minutes(1)),
+      targets: [new targets.LambdaFunction(fn)],
+    })
+
     const restApi = new apigateway.LambdaRestApi(appStack, `${name}-restapi`, {
       restApiName: 'Simple OIDC',
       description: 'Kncept Simple OIDC and Oauth2 Server',
@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// security relevant events, for administrators to review
+const (
+	// a rotated (stale) refresh token was presented
+	EventTypeRefreshTokenReuse = "refresh_token_reuse"
+	// an already redeemed authorization code was presented
+	EventTypeAuthorizationCodeReuse = "authorization_code_reuse"
+	// a token was exchanged for another audience, possibly on behalf of an actor
+	EventTypeTokenExchange = "token_exchange"
+	// a client asserted a subject, and a token was issued to an actor impersonating them
+	EventTypeImpersonation = "impersonation"
+	// a client could not be notified that a user session ended (after retrying)
+	EventTypeBackChannelLogoutFailed = "backchannel_logout_failed"
+)
+
+type Event struct {
+	EventId   string    `dynamodbav:"id"` // ksuid, so ordered by creation time
+	EventType string    `dynamodbav:"eventType"`
+	Created   time.Time `dynamodbav:"created"`
+
+	ClientId  string `dynamodbav:"clientId"`
+	UserId    string `dynamodbav:"userId"`
+	SessionId string `dynamodbav:"sessionId"`
+	Details   string `dynamodbav:"details"`
+}
+
+type EventStore interface {
+	SaveEvent(ctx context.Context, event *Event) error
+	ListEvents(ctx context.Context) ([]*Event, error)
+}
+
+func NewEvent(eventType string) (*Event, error) {
+	now := time.Now().UTC()
+	k, err := ksuid.NewRandomWithTime(now)
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		EventId:   k.String(),
+		EventType: eventType,
+		Created:   now,
+	}, nil
+}
+
+// events are also logged, as they may indicate an ongoing attack
+func RecordEvent(ctx context.Context, store EventStore, event *Event) error {
+	log.Printf("security event %v: client=%v user=%v session=%v %v\n", event.EventType, event.ClientId, event.UserId, event.SessionId, event.Details)
+	return store.SaveEvent(ctx, event)
+}
@@ -2,21 +2,95 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/segmentio/ksuid"
 )
 
+// see https://datatracker.ietf.org/doc/html/rfc6749#section-4.1.2
+// "A maximum authorization code lifetime of 10 minutes is RECOMMENDED"
+const AuthorizationCodeLifetime = 5 * time.Minute
+
 type AuthorizationCodeStore interface {
 	SaveAuthorizationCode(ctx context.Context, code *AuthorizationCode) error
 	GetAuthorizationCode(ctx context.Context, code string) (*AuthorizationCode, error)
+	DeleteAuthorizationCode(ctx context.Context, code string) error
+	// atomically marks the code as redeemed into the session.
+	// returns false if the code doesn't exist, or has already been redeemed
+	RedeemAuthorizationCode(ctx context.Context, code string, sessionId string) (bool, error)
 }
 
 type AuthorizationCode struct {
-	Code       string     `dynamodbav:"code"` // partition key
-	UserId     string     `dynamodbav:"userId"`
-	Expiry     *time.Time `dynamodbav:"expiry"`
-	OidcParams string     `dynamodbav:"params"`
+	Code        string     `dynamodbav:"code"` // partition key
+	UserId      string     `dynamodbav:"userId"`
+	ClientId    string     `dynamodbav:"clientId"`
+	RedirectUri string     `dynamodbav:"redirectUri"`
+	Expiry      *time.Time `dynamodbav:"expiry"`
+	OidcParams  string     `dynamodbav:"params"`
+
+	// carried through to the issued tokens
+	Nonce    string    `dynamodbav:"nonce"`
+	AuthTime time.Time `dynamodbav:"authTime"`
+	Scope    string    `dynamodbav:"scope"`
+
+	// codes are single use. A redeemed code is kept (until expiry) so that
+	// a replay can revoke the session that was issued for it
+	Redeemed  *time.Time `dynamodbav:"redeemed"`
+	SessionId string     `dynamodbav:"sessionId"`
+
+	// PKCE (RFC 7636)
+	CodeChallenge       string `dynamodbav:"codeChallenge"`
+	CodeChallengeMethod string `dynamodbav:"codeChallengeMethod"`
+}
+
+func (ac *AuthorizationCode) IsRedeemed() bool {
+	return ac.Redeemed != nil
+}
+
+func (ac *AuthorizationCode) IsExpired(asof ...time.Time) bool {
+	if len(asof) > 1 {
+		panic("must only provide one asof arg")
+	}
+	if len(asof) != 1 {
+		asof = []time.Time{
+			time.Now().UTC(),
+		}
+	}
+	return ac.Expiry == nil || !asof[0].Before(*ac.Expiry)
+}
+
+// the code may only be redeemed by the client it was issued to, with the same redirect uri
+// see https://datatracker.ietf.org/doc/html/rfc6749#section-4.1.3
+func (ac *AuthorizationCode) VerifyRedemption(clientId string, redirectUri string) error {
+	if ac.ClientId == "" || ac.ClientId != clientId {
+		return fmt.Errorf("authorization code was not issued to client %v", clientId)
+	}
+	if ac.RedirectUri != redirectUri {
+		return fmt.Errorf("redirect_uri mismatch")
+	}
+	return nil
+}
+
+// marks the code as used, recording the session issued for it
+func (ac *AuthorizationCode) Redeem(sessionId string) {
+	now := time.Now().UTC()
+	ac.Redeemed = &now
+	ac.SessionId = sessionId
+}
+
+// if a code challenge was issued with the authorization request, the code verifier must match it
+func (ac *AuthorizationCode) VerifyCodeVerifier(codeVerifier string) error {
+	if ac.CodeChallenge == "" {
+		if codeVerifier != "" {
+			return fmt.Errorf("code verifier supplied without code challenge")
+		}
+		return nil
+	}
+	if codeVerifier == "" {
+		return fmt.Errorf("code verifier required")
+	}
+	return VerifyCodeChallenge(codeVerifier, ac.CodeChallenge, ac.CodeChallengeMethod)
 }
 
 func (ac *AuthorizationCode) Created() (time.Time, error) {
@@ -27,14 +101,19 @@ func (ac *AuthorizationCode) Created() (time.Time, error) {
 	return k.Time(), nil
 }
 
-func NewAuthorizationCode(userId string, oidcParams string) (*AuthorizationCode, error) {
-	k, err := ksuid.NewRandom()
+func NewAuthorizationCode(userId string, clientId string, redirectUri string, oidcParams string) (*AuthorizationCode, error) {
+	now := time.Now().UTC()
+	k, err := ksuid.NewRandomWithTime(now)
 	if err != nil {
 		return nil, err
 	}
+	expiry := now.Add(AuthorizationCodeLifetime)
 	return &AuthorizationCode{
-		Code:       k.String(),
-		UserId:     userId,
-		OidcParams: oidcParams,
+		Code:        k.String(),
+		UserId:      userId,
+		ClientId:    clientId,
+		RedirectUri: redirectUri,
+		Expiry:      &expiry,
+		OidcParams:  oidcParams,
 	}, nil
 }
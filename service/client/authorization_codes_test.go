@@ -11,7 +11,7 @@ func TestAuthcodeCreated(t *testing.T) {
 
 	before := time.Now().Truncate(time.Second)
 
-	authCode, err := NewAuthorizationCode(uuid.NewString(), "oidc params")
+	authCode, err := NewAuthorizationCode(uuid.NewString(), "client", "https://client/callback", "oidc params")
 	if err != nil {
 		t.Fatalf("%v\n", err)
 	}
@@ -29,3 +29,65 @@ func TestAuthcodeCreated(t *testing.T) {
 	}
 
 }
+
+func TestAuthcodeCodeVerifier(t *testing.T) {
+	authCode, err := NewAuthorizationCode(uuid.NewString(), "client", "https://client/callback", "oidc params")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if err = authCode.VerifyCodeVerifier(""); err != nil {
+		t.Fatalf("no code verifier required without a challenge: %v", err)
+	}
+	if err = authCode.VerifyCodeVerifier(testCodeVerifier); err == nil {
+		t.Fatalf("code verifier must not be accepted without a challenge")
+	}
+
+	authCode.CodeChallenge = testCodeChallenge
+	authCode.CodeChallengeMethod = CodeChallengeMethodS256
+	if err = authCode.VerifyCodeVerifier(""); err == nil {
+		t.Fatalf("code verifier should be required")
+	}
+	if err = authCode.VerifyCodeVerifier(testCodeVerifier); err != nil {
+		t.Fatalf("code verifier should have matched: %v", err)
+	}
+}
+
+func TestAuthcodeExpiry(t *testing.T) {
+	authCode, err := NewAuthorizationCode(uuid.NewString(), "client", "https://client/callback", "oidc params")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if authCode.IsExpired() {
+		t.Fatalf("new authorization code should not be expired")
+	}
+	if !authCode.IsExpired(time.Now().Add(AuthorizationCodeLifetime + time.Second)) {
+		t.Fatalf("authorization code should have expired")
+	}
+	authCode.Expiry = nil
+	if !authCode.IsExpired() {
+		t.Fatalf("authorization code without an expiry must be treated as expired")
+	}
+}
+
+func TestAuthcodeRedemption(t *testing.T) {
+	authCode, err := NewAuthorizationCode(uuid.NewString(), "client", "https://client/callback", "oidc params")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if err = authCode.VerifyRedemption("other-client", "https://client/callback"); err == nil {
+		t.Fatalf("authorization code must be bound to the client")
+	}
+	if err = authCode.VerifyRedemption("client", "https://client/other"); err == nil {
+		t.Fatalf("authorization code must be bound to the redirect uri")
+	}
+	if err = authCode.VerifyRedemption("client", "https://client/callback"); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if authCode.IsRedeemed() {
+		t.Fatalf("new authorization code should not be redeemed")
+	}
+	authCode.Redeem("session-id")
+	if !authCode.IsRedeemed() || authCode.SessionId != "session-id" {
+		t.Fatalf("authorization code should be redeemed")
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/params"
+)
+
+func (obj *Client) IsValidRedirectUri(redirectUri string) bool {
+	if obj.AllowRegexForRedirectUri {
+		// TOOD: Consider caching?
+		for _, allowedRedirectUri := range obj.AllowedRedirectUris {
+			// regex match https://pkg.go.dev/regexp
+			r, err := regexp.Compile(allowedRedirectUri)
+			if err != nil {
+				log.Printf("%v\n", err)
+				continue
+			}
+			if r.Match([]byte(redirectUri)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if obj.ExactRedirectUris {
+		return slices.Contains(obj.AllowedRedirectUris, redirectUri)
+	}
+
+	// else this is just a 'starts with'... MUCH simpler
+	for _, allowedRedirectUri := range obj.AllowedRedirectUris {
+		if strings.HasPrefix(redirectUri, allowedRedirectUri) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checked by every endpoint that handles the request (/authorize, /par, and again by /accept,
+// as the parameters may have been round tripped via the browser).
+// the redirect uri must have already been validated.
+// normalizes the response type, and reduces the scope to what was granted
+func (obj *Client) ValidateAuthorizeRequest(authRequest *params.OidcAuthCodeFlowParams) error {
+	requestedResponseType := authRequest.ResponseType
+	responseType := NormalizeResponseType(requestedResponseType)
+	authRequest.ResponseType = responseType
+	if !slices.Contains(SupportedResponseTypes, responseType) {
+		return oautherror.New(oautherror.UnsupportedResponseType, "unsupported response_type: %v", requestedResponseType)
+	}
+	if !obj.IsAllowedResponseType(responseType) {
+		return oautherror.New(oautherror.UnauthorizedClient, "response_type not allowed for client: %v", requestedResponseType)
+	}
+	err := ValidateResponseMode(responseType, authRequest.ResponseMode)
+	if err != nil {
+		return oautherror.Wrap(oautherror.InvalidRequest, err)
+	}
+	// see https://openid.net/specs/openid-connect-core-1_0.html#ImplicitAuthRequest
+	if ResponseTypeIncludes(responseType, ResponseTypeIdToken) && IsFrontChannelResponseType(responseType) && authRequest.Nonce == "" {
+		return oautherror.New(oautherror.InvalidRequest, "nonce required for response_type: %v", responseType)
+	}
+
+	// PKCE
+	if authRequest.CodeChallengeMethod != "" && authRequest.CodeChallenge == "" {
+		return oautherror.New(oautherror.InvalidRequest, "code_challenge_method requires a code_challenge")
+	}
+	if !IsSupportedCodeChallengeMethod(authRequest.CodeChallengeMethod) {
+		return oautherror.New(oautherror.InvalidRequest, "unsupported code_challenge_method: %v", authRequest.CodeChallengeMethod)
+	}
+	if obj.RequirePkce && authRequest.CodeChallenge == "" {
+		return oautherror.New(oautherror.InvalidRequest, "code_challenge required for client: %v", obj.ClientId)
+	}
+
+	grantedScope, err := obj.GrantScopes(authRequest.Scope)
+	if err != nil {
+		return err
+	}
+	authRequest.Scope = grantedScope
+	if !authRequest.IsValid() {
+		return oautherror.New(oautherror.InvalidRequest, "missing required parameters")
+	}
+	return nil
+}
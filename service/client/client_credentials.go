@@ -0,0 +1,6 @@
+package client
+
+// see https://datatracker.ietf.org/doc/html/rfc6749#section-4.4
+func (obj *Client) AllowsClientCredentials() bool {
+	return obj.IsConfidential() && len(obj.MachineAudiences) != 0
+}
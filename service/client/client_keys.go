@@ -0,0 +1,23 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kncept-oauth/simple-oidc/service/keys"
+)
+
+func (obj *Client) HasPublicKeys() bool {
+	return obj.JwksUri != "" || (obj.Jwks != nil && len(obj.Jwks.Keys) != 0)
+}
+
+// the clients registered public keys (inline keys take precedence)
+func (obj *Client) PublicKeys(ctx context.Context) (*keys.JwkSet, error) {
+	if obj.Jwks != nil && len(obj.Jwks.Keys) != 0 {
+		return obj.Jwks, nil
+	}
+	if obj.JwksUri != "" {
+		return keys.FetchJwks(ctx, obj.JwksUri)
+	}
+	return nil, fmt.Errorf("no public keys registered for client %v", obj.ClientId)
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/kncept-oauth/simple-oidc/service/users"
+)
+
+const (
+	// public clients (SPA's, mobile apps) can not keep a secret
+	ClientTypePublic = "public"
+	// confidential clients must authenticate at the token endpoint
+	ClientTypeConfidential = "confidential"
+)
+
+// see https://datatracker.ietf.org/doc/html/rfc6749#section-2.3.1
+const (
+	TokenEndpointAuthMethodNone              = "none"
+	TokenEndpointAuthMethodClientSecretBasic = "client_secret_basic"
+	TokenEndpointAuthMethodClientSecretPost  = "client_secret_post"
+	// see https://datatracker.ietf.org/doc/html/rfc7523#section-2.2
+	TokenEndpointAuthMethodPrivateKeyJwt = "private_key_jwt"
+	// the HMAC key is a separate (unhashed) secret, see Client.ClientSecretJwtKey
+	TokenEndpointAuthMethodClientSecretJwt = "client_secret_jwt"
+)
+
+var SupportedTokenEndpointAuthMethods = []string{
+	TokenEndpointAuthMethodClientSecretBasic,
+	TokenEndpointAuthMethodClientSecretPost,
+	TokenEndpointAuthMethodPrivateKeyJwt,
+	TokenEndpointAuthMethodClientSecretJwt,
+	TokenEndpointAuthMethodNone,
+}
+
+// ONLY the hash of the secret is stored.
+// uses the same salt/encoding scheme as user passwords.
+type ClientSecret struct {
+	Salt    string     `dynamodbav:"salt"`
+	Hash    string     `dynamodbav:"hash"`
+	Created time.Time  `dynamodbav:"created"`
+	Expiry  *time.Time `dynamodbav:"expiry"` // nil for the current secret
+}
+
+func (obj *ClientSecret) InDate(when time.Time) bool {
+	return obj.Expiry == nil || when.Before(*obj.Expiry)
+}
+
+func (obj *ClientSecret) Matches(rawSecret string) bool {
+	return users.ComparePassword(obj.Salt, rawSecret, obj.Hash)
+}
+
+// 32 random bytes, base64url encoded (43 characters)
+// n.b. bcrypt only uses the first 72 bytes of salt + secret
+func GenerateClientSecret() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func newClientSecret(rawSecret string, now time.Time) (*ClientSecret, error) {
+	salt := users.GenerateSalt()
+	hash, err := users.EncodePassword(salt, rawSecret)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientSecret{
+		Salt:    salt,
+		Hash:    hash,
+		Created: now,
+	}, nil
+}
+
+func (obj *Client) IsConfidential() bool {
+	return obj.ClientType == ClientTypeConfidential
+}
+
+// replaces ALL existing secrets
+func (obj *Client) SetClientSecret(rawSecret string) error {
+	secret, err := newClientSecret(rawSecret, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	obj.ClientSecrets = []*ClientSecret{secret}
+	return nil
+}
+
+// generates a new secret, and keeps the current one valid for the grace period
+// so that deployments can be rolled over without downtime.
+// already expired secrets are dropped.
+func (obj *Client) RotateClientSecret(gracePeriod time.Duration) (string, error) {
+	rawSecret, err := GenerateClientSecret()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now().UTC()
+	secret, err := newClientSecret(rawSecret, now)
+	if err != nil {
+		return "", err
+	}
+	graceExpiry := now.Add(gracePeriod)
+	secrets := []*ClientSecret{secret}
+	for _, existing := range obj.ClientSecrets {
+		if !existing.InDate(now) {
+			continue
+		}
+		if existing.Expiry == nil || existing.Expiry.After(graceExpiry) {
+			existing.Expiry = &graceExpiry
+		}
+		secrets = append(secrets, existing)
+	}
+	obj.ClientSecrets = secrets
+	return rawSecret, nil
+}
+
+// generates a new HMAC key for client_secret_jwt, replacing any existing key.
+// the raw key is returned, to be given to the client as its client secret
+func (obj *Client) GenerateClientSecretJwtKey() (string, error) {
+	rawKey, err := GenerateClientSecret()
+	if err != nil {
+		return "", err
+	}
+	obj.ClientSecretJwtKey = rawKey
+	return rawKey, nil
+}
+
+func (obj *Client) ClientSecretMatches(rawSecret string, asof ...time.Time) bool {
+	if len(asof) > 1 {
+		panic("must only provide one asof arg")
+	}
+	if len(asof) != 1 {
+		asof = []time.Time{
+			time.Now().UTC(),
+		}
+	}
+	if rawSecret == "" {
+		return false
+	}
+	for _, secret := range obj.ClientSecrets {
+		if secret.InDate(asof[0]) && secret.Matches(rawSecret) {
+			return true
+		}
+	}
+	return false
+}
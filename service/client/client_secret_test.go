@@ -0,0 +1,73 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClientSecretMatches(t *testing.T) {
+	c := &Client{}
+	if c.ClientSecretMatches("") {
+		t.Fatalf("empty secret must never match")
+	}
+	rawSecret, err := GenerateClientSecret()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err = c.SetClientSecret(rawSecret); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if c.ClientSecrets[0].Hash == rawSecret {
+		t.Fatalf("raw secret must not be stored")
+	}
+	if !c.ClientSecretMatches(rawSecret) {
+		t.Fatalf("secret should have matched")
+	}
+	if c.ClientSecretMatches(rawSecret + "x") {
+		t.Fatalf("secret should not have matched")
+	}
+}
+
+func TestClientSecretRotation(t *testing.T) {
+	c := &Client{}
+	oldSecret, err := GenerateClientSecret()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err = c.SetClientSecret(oldSecret); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	newSecret, err := c.RotateClientSecret(time.Hour)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(c.ClientSecrets) != 2 {
+		t.Fatalf("Expected 2 secrets, got %v", len(c.ClientSecrets))
+	}
+	if !c.ClientSecretMatches(newSecret) {
+		t.Fatalf("new secret should have matched")
+	}
+	if !c.ClientSecretMatches(oldSecret) {
+		t.Fatalf("old secret should match during the grace period")
+	}
+	afterGrace := time.Now().Add(2 * time.Hour)
+	if c.ClientSecretMatches(oldSecret, afterGrace) {
+		t.Fatalf("old secret should not match after the grace period")
+	}
+	if !c.ClientSecretMatches(newSecret, afterGrace) {
+		t.Fatalf("new secret should match after the grace period")
+	}
+
+	// rotating with no grace period drops everything but the new secret
+	latestSecret, err := c.RotateClientSecret(0)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if c.ClientSecretMatches(newSecret) || c.ClientSecretMatches(oldSecret) {
+		t.Fatalf("previous secrets should no longer match")
+	}
+	if !c.ClientSecretMatches(latestSecret) {
+		t.Fatalf("latest secret should have matched")
+	}
+}
@@ -1,6 +1,12 @@
 package client
 
-import "context"
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/kncept-oauth/simple-oidc/service/keys"
+)
 
 // hardcoded static client id
 const ClientId_SimpleOidc = "simple-oidc"
@@ -18,14 +24,110 @@ type Client struct {
 	// regex scripts for redirect uris
 	AllowedRedirectUris []string `dynamodbav:"allowedRedirectUris"`
 
+	// redirect uris must match exactly (eg: dynamically registered clients)
+	ExactRedirectUris bool `dynamodbav:"exactRedirectUris"`
+
+	// eg: "code", "id_token", "code id_token". Empty only allows "code"
+	AllowedResponseTypes []string `dynamodbav:"allowedResponseTypes"`
+
+	// the grant types a dynamically registered client registered for (RFC 7591).
+	// Empty allows every user grant. client_credentials and token exchange are only
+	// ever allowed by the machine access and token exchange settings
+	GrantTypes []string `dynamodbav:"grantTypes"`
+
+	// ClientTypePublic or ClientTypeConfidential (defaults to public)
+	ClientType string `dynamodbav:"clientType"`
+
+	// hashed secrets for confidential clients.
+	// more than one may be valid during a secret rotation
+	ClientSecrets []*ClientSecret `dynamodbav:"clientSecrets"`
+
+	// the shared HMAC key for client_secret_jwt client authentication.
+	// the key must be usable to verify a signature, so unlike ClientSecrets it can't be hashed
+	ClientSecretJwtKey string `dynamodbav:"clientSecretJwtKey"`
+
+	// restrict confidential clients to a single authentication method
+	// eg: client_secret_basic. Empty allows any supported method
+	TokenEndpointAuthMethod string `dynamodbav:"tokenEndpointAuthMethod"`
+
+	// PUBLIC keys for private_key_jwt client authentication.
+	// register either an inline key set, or a jwks uri (not both)
+	Jwks    *keys.JwkSet `dynamodbav:"jwks"`
+	JwksUri string       `dynamodbav:"jwksUri"`
+
+	// reject authorization requests that weren't pushed to /par first (RFC 9126),
+	// so that the request parameters are never exposed in the browser
+	RequirePushedAuthorizationRequests bool `dynamodbav:"requirePushedAuthorizationRequests"`
+
+	// reject authorization requests that aren't a request object (RFC 9101),
+	// signed with one of the client's public keys
+	RequireSignedRequestObject bool `dynamodbav:"requireSignedRequestObject"`
+	// where request objects may be fetched from (exact match)
+	RequestUris []string `dynamodbav:"requestUris"`
+
+	// reject token requests without a DPoP proof (RFC 9449),
+	// so that every token issued to the client is bound to its key
+	DPoPBoundAccessTokens bool `dynamodbav:"dpopBoundAccessTokens"`
+
+	// reject authorization requests without a PKCE code challenge
+	// RECOMMENDED TO BE TRUE for public clients (SPA's, mobile apps)
+	RequirePkce bool `dynamodbav:"requirePkce"`
+
+	// resource servers (APIs) that access tokens for this client are intended for.
+	// the issuer (for /userinfo) is always included
+	AccessTokenAudiences []string `dynamodbav:"accessTokenAudiences"`
+
+	// client_credentials (machine to machine) access. The client itself is the subject of
+	// these tokens, so they are only ever intended for the machine audiences.
+	// the client_credentials grant is only allowed with at least one machine audience
+	MachineScopes    []string `dynamodbav:"machineScopes"`
+	MachineAudiences []string `dynamodbav:"machineAudiences"`
+
+	// which audiences and subjects the client may exchange tokens for (RFC 8693).
+	// the token exchange grant is only allowed with a policy
+	TokenExchangePolicy *TokenExchangePolicy `dynamodbav:"tokenExchangePolicy"`
+
+	// what to do when a rotated (stale) refresh token is presented.
+	// RefreshTokenReusePolicyRevoke (default) or RefreshTokenReusePolicyReject
+	RefreshTokenReusePolicy string `dynamodbav:"refreshTokenReusePolicy"`
+
+	// where the user may be sent after logging out (exact match only)
+	PostLogoutRedirectUris []string `dynamodbav:"postLogoutRedirectUris"`
+
+	// a logout token is POSTed here when a user session with this client ends
+	BackchannelLogoutUri string `dynamodbav:"backchannelLogoutUri"`
+
+	// rendered in an iframe when the user logs out of simple-oidc
+	FrontchannelLogoutUri string `dynamodbav:"frontchannelLogoutUri"`
+	// the client needs the iss and sid query parameters to identify the session
+	FrontchannelLogoutSessionRequired bool `dynamodbav:"frontchannelLogoutSessionRequired"`
+
+	// set for dynamically registered clients, which manage their own registration (RFC 7592)
+	// with the (hashed) registration access token
+	Registered              *time.Time    `dynamodbav:"registered"`
+	RegistrationAccessToken *ClientSecret `dynamodbav:"registrationAccessToken"`
+
 	PublicName    string `dynamodbav:"publicName"`
 	PublicWebsite string `dynamodbav:"publicWebsite"`
 	Description   string `dynamodbav:"description"`
 }
 
+func (obj *Client) AccessTokenAudience(issuer string) []string {
+	audience := []string{issuer}
+	for _, aud := range obj.AccessTokenAudiences {
+		if !slices.Contains(audience, aud) {
+			audience = append(audience, aud)
+		}
+	}
+	return audience
+}
+
 type ClientStore interface {
 	GetClient(ctx context.Context, clientId string) (*Client, error)
+	// creates a new client, failing if the client id is already in use
 	SaveClient(ctx context.Context, client *Client) error
+	// replaces an existing client, failing if there is no such client
+	UpdateClient(ctx context.Context, client *Client) error
 	ListClients(ctx context.Context) ([]*Client, error)
 	RemoveClient(ctx context.Context, clientId string) error
 }
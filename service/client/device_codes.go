@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// see https://datatracker.ietf.org/doc/html/rfc8628
+const (
+	DeviceCodeLifetime = 10 * time.Minute
+	// the minimum time between token endpoint polls
+	DeviceCodeInterval = 5 * time.Second
+	// added to the interval every time the client polls too quickly
+	DeviceCodeSlowDownIncrement = 5 * time.Second
+)
+
+// base-20 consonants, so codes can't spell words and aren't ambiguous to read.
+// see https://datatracker.ietf.org/doc/html/rfc8628#section-6.1
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+const userCodeLength = 8
+
+type DeviceCodeStore interface {
+	SaveDeviceCode(ctx context.Context, deviceCode *DeviceCode) error
+	GetDeviceCode(ctx context.Context, userCode string) (*DeviceCode, error)
+	DeleteDeviceCode(ctx context.Context, userCode string) error
+}
+
+// a device authorization request, keyed by the (normalized) user code.
+// the device code is the user code with a random suffix, so that the token endpoint
+// can find the request without a second index.
+type DeviceCode struct {
+	UserCode   string `dynamodbav:"userCode"` // partition key
+	DeviceCode string `dynamodbav:"deviceCode"`
+	ClientId   string `dynamodbav:"clientId"`
+	Scope      string `dynamodbav:"scope"`
+
+	Expiry     *time.Time    `dynamodbav:"expiry"`
+	Interval   time.Duration `dynamodbav:"interval"`
+	LastPolled *time.Time    `dynamodbav:"lastPolled"`
+
+	// set when the user approves the request on the verification page
+	Approved *time.Time `dynamodbav:"approved"`
+	UserId   string     `dynamodbav:"userId"`
+	AuthTime time.Time  `dynamodbav:"authTime"`
+
+	// device codes are single use
+	Redeemed  *time.Time `dynamodbav:"redeemed"`
+	SessionId string     `dynamodbav:"sessionId"`
+}
+
+func (dc *DeviceCode) IsExpired(asof ...time.Time) bool {
+	if len(asof) > 1 {
+		panic("must only provide one asof arg")
+	}
+	if len(asof) != 1 {
+		asof = []time.Time{
+			time.Now().UTC(),
+		}
+	}
+	return dc.Expiry == nil || !asof[0].Before(*dc.Expiry)
+}
+
+func (dc *DeviceCode) IsApproved() bool {
+	return dc.Approved != nil
+}
+
+func (dc *DeviceCode) IsRedeemed() bool {
+	return dc.Redeemed != nil
+}
+
+// a request can only be approved once, and only before it expires
+func (dc *DeviceCode) Approve(userId string, authTime time.Time) error {
+	if dc.IsExpired() {
+		return fmt.Errorf("device code has expired")
+	}
+	if dc.IsApproved() {
+		return fmt.Errorf("device code has already been approved")
+	}
+	now := time.Now().UTC()
+	dc.Approved = &now
+	dc.UserId = userId
+	dc.AuthTime = authTime
+	return nil
+}
+
+// records a token endpoint poll.
+// returns false if the client is polling faster than the interval, and slows it down
+// see https://datatracker.ietf.org/doc/html/rfc8628#section-3.5
+func (dc *DeviceCode) Poll(asof time.Time) bool {
+	tooFast := dc.LastPolled != nil && asof.Sub(*dc.LastPolled) < dc.Interval
+	dc.LastPolled = &asof
+	if tooFast {
+		dc.Interval = dc.Interval + DeviceCodeSlowDownIncrement
+	}
+	return !tooFast
+}
+
+func (dc *DeviceCode) Redeem(sessionId string) {
+	now := time.Now().UTC()
+	dc.Redeemed = &now
+	dc.SessionId = sessionId
+}
+
+func (dc *DeviceCode) DeviceCodeMatches(deviceCode string) bool {
+	return subtle.ConstantTimeCompare([]byte(dc.DeviceCode), []byte(deviceCode)) == 1
+}
+
+// XXXX-XXXX, for display
+func (dc *DeviceCode) FormattedUserCode() string {
+	if len(dc.UserCode) != userCodeLength {
+		return dc.UserCode
+	}
+	return dc.UserCode[:userCodeLength/2] + "-" + dc.UserCode[userCodeLength/2:]
+}
+
+// users type the code in, so case and separators are ignored
+func NormalizeUserCode(userCode string) string {
+	sb := strings.Builder{}
+	for _, r := range strings.ToUpper(userCode) {
+		if strings.ContainsRune(userCodeAlphabet, r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// the user code that a device code was issued with
+func UserCodeFromDeviceCode(deviceCode string) string {
+	userCode, _, found := strings.Cut(deviceCode, ".")
+	if !found {
+		return ""
+	}
+	return userCode
+}
+
+func generateUserCode() (string, error) {
+	b := make([]byte, userCodeLength)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	// 256 % 20 != 0, but the bias is negligible for a short lived code
+	for i := range b {
+		b[i] = userCodeAlphabet[int(b[i])%len(userCodeAlphabet)]
+	}
+	return string(b), nil
+}
+
+func NewDeviceCode(clientId string, scope string) (*DeviceCode, error) {
+	userCode, err := generateUserCode()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := GenerateClientSecret()
+	if err != nil {
+		return nil, err
+	}
+	expiry := time.Now().UTC().Add(DeviceCodeLifetime)
+	return &DeviceCode{
+		UserCode:   userCode,
+		DeviceCode: userCode + "." + secret,
+		ClientId:   clientId,
+		Scope:      scope,
+		Expiry:     &expiry,
+		Interval:   DeviceCodeInterval,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNormalizeUserCode(t *testing.T) {
+	dc, err := NewDeviceCode("client", "openid")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	formatted := dc.FormattedUserCode()
+	if len(formatted) != 9 || formatted[4] != '-' {
+		t.Fatalf("unexpected formatted user code: %v", formatted)
+	}
+	for _, typed := range []string{formatted, dc.UserCode, " " + formatted + " ", formatted[:4] + " " + formatted[5:]} {
+		if NormalizeUserCode(typed) != dc.UserCode {
+			t.Fatalf("%q should normalize to %v", typed, dc.UserCode)
+		}
+	}
+	if UserCodeFromDeviceCode(dc.DeviceCode) != dc.UserCode {
+		t.Fatalf("device code %v was not issued with user code %v", dc.DeviceCode, dc.UserCode)
+	}
+	if !dc.DeviceCodeMatches(dc.DeviceCode) || dc.DeviceCodeMatches(dc.UserCode) {
+		t.Fatalf("device code should only match itself")
+	}
+}
+
+func TestDeviceCodePolling(t *testing.T) {
+	dc, err := NewDeviceCode("client", "openid")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	now := time.Now().UTC()
+	if !dc.Poll(now) {
+		t.Fatalf("the first poll is never too fast")
+	}
+	if dc.Poll(now.Add(time.Second)) {
+		t.Fatalf("polling within the interval should slow down")
+	}
+	if dc.Interval != DeviceCodeInterval+DeviceCodeSlowDownIncrement {
+		t.Fatalf("unexpected interval %v", dc.Interval)
+	}
+	if !dc.Poll(now.Add(time.Second + dc.Interval)) {
+		t.Fatalf("polling at the interval is allowed")
+	}
+
+	err = dc.Approve("user", now)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if dc.Approve("user", now) == nil {
+		t.Fatalf("a device code can only be approved once")
+	}
+	if !dc.IsExpired(dc.Expiry.Add(time.Second)) {
+		t.Fatalf("expected the device code to expire")
+	}
+}
@@ -0,0 +1,35 @@
+package client
+
+import "slices"
+
+// see https://datatracker.ietf.org/doc/html/rfc6749#section-4
+const (
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeClientCredentials = "client_credentials"
+	// see https://datatracker.ietf.org/doc/html/rfc8628#section-3.4
+	GrantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+	// see https://datatracker.ietf.org/doc/html/rfc8693#section-2.1
+	GrantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	// not a token endpoint grant, but the implicit response types are supported
+	GrantTypeImplicit = "implicit"
+)
+
+var SupportedGrantTypes = []string{
+	GrantTypeAuthorizationCode,
+	GrantTypeRefreshToken,
+	GrantTypeClientCredentials,
+	GrantTypeDeviceCode,
+	GrantTypeTokenExchange,
+	GrantTypeImplicit,
+}
+
+func (obj *Client) AllowsGrantType(grantType string) bool {
+	switch grantType {
+	case GrantTypeClientCredentials:
+		return obj.AllowsClientCredentials()
+	case GrantTypeTokenExchange:
+		return obj.AllowsTokenExchange()
+	}
+	return len(obj.GrantTypes) == 0 || slices.Contains(obj.GrantTypes, grantType)
+}
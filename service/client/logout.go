@@ -0,0 +1,29 @@
+package client
+
+import (
+	"net/url"
+	"slices"
+)
+
+// unlike the authorization redirect uris, these must match exactly
+// see https://openid.net/specs/openid-connect-rpinitiated-1_0.html#ClientMetadata
+func (obj *Client) IsAllowedPostLogoutRedirectUri(postLogoutRedirectUri string) bool {
+	return slices.Contains(obj.PostLogoutRedirectUris, postLogoutRedirectUri)
+}
+
+// the front channel logout uri, with the iss and sid query parameters (when the session is known)
+// see https://openid.net/specs/openid-connect-frontchannel-1_0.html#RPLogout
+func (obj *Client) FrontchannelLogoutUrl(issuer string, sessionId string) (string, error) {
+	u, err := url.Parse(obj.FrontchannelLogoutUri)
+	if err != nil {
+		return "", err
+	}
+	if sessionId == "" {
+		return u.String(), nil
+	}
+	q := u.Query()
+	q.Set("iss", issuer)
+	q.Set("sid", sessionId)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// undelivered logout tokens are abandoned after this, even if they are still being retried
+const PendingLogoutLifetime = 24 * time.Hour
+
+type PendingLogoutStore interface {
+	SavePendingLogout(ctx context.Context, pending *PendingLogout) error
+	ListPendingLogouts(ctx context.Context) ([]*PendingLogout, error)
+	DeletePendingLogout(ctx context.Context, id string) error
+}
+
+// a back-channel logout notification that has not (yet) been delivered to the client.
+// a fresh logout token is issued for every attempt
+// see https://openid.net/specs/openid-connect-backchannel-1_0.html#BCResponse
+type PendingLogout struct {
+	Id        string `dynamodbav:"id"` // ksuid, so ordered by creation time
+	ClientId  string `dynamodbav:"clientId"`
+	UserId    string `dynamodbav:"userId"`
+	SessionId string `dynamodbav:"sessionId"`
+
+	Attempts    int       `dynamodbav:"attempts"`
+	NextAttempt time.Time `dynamodbav:"nextAttempt"`
+	LastError   string    `dynamodbav:"lastError"`
+	Ttl         int64     `dynamodbav:"ttl"` // dynamodb time to live, in epoch seconds
+}
+
+// due straight away
+func NewPendingLogout(clientId string, userId string, sessionId string) (*PendingLogout, error) {
+	now := time.Now().UTC()
+	k, err := ksuid.NewRandomWithTime(now)
+	if err != nil {
+		return nil, err
+	}
+	return &PendingLogout{
+		Id:          k.String(),
+		ClientId:    clientId,
+		UserId:      userId,
+		SessionId:   sessionId,
+		NextAttempt: now,
+		Ttl:         now.Add(PendingLogoutLifetime).Unix(),
+	}, nil
+}
+
+func (obj *PendingLogout) IsDue(asof time.Time) bool {
+	return !asof.Before(obj.NextAttempt)
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+)
+
+// PKCE (RFC 7636) code challenge methods
+const (
+	CodeChallengeMethodPlain = "plain"
+	CodeChallengeMethodS256  = "S256"
+)
+
+// ordered by preference
+var SupportedCodeChallengeMethods = []string{
+	CodeChallengeMethodS256,
+	CodeChallengeMethodPlain,
+}
+
+// see https://datatracker.ietf.org/doc/html/rfc7636#section-4.1
+var codeVerifierRegex = regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`)
+
+func IsSupportedCodeChallengeMethod(method string) bool {
+	// defaults to plain when not specified
+	if method == "" {
+		return true
+	}
+	for _, supported := range SupportedCodeChallengeMethods {
+		if supported == method {
+			return true
+		}
+	}
+	return false
+}
+
+func CodeChallengeFromVerifier(codeVerifier string, method string) (string, error) {
+	switch method {
+	case "", CodeChallengeMethodPlain:
+		return codeVerifier, nil
+	case CodeChallengeMethodS256:
+		hash := sha256.Sum256([]byte(codeVerifier))
+		return base64.RawURLEncoding.EncodeToString(hash[:]), nil
+	}
+	return "", fmt.Errorf("unsupported code challenge method: %v", method)
+}
+
+func VerifyCodeChallenge(codeVerifier string, codeChallenge string, method string) error {
+	if !codeVerifierRegex.MatchString(codeVerifier) {
+		return fmt.Errorf("invalid code verifier")
+	}
+	expected, err := CodeChallengeFromVerifier(codeVerifier, method)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(codeChallenge)) != 1 {
+		return fmt.Errorf("code verifier mismatch")
+	}
+	return nil
+}
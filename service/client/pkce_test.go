@@ -0,0 +1,55 @@
+package client
+
+import (
+	"testing"
+)
+
+// BASE64URL(SHA256(ASCII(code_verifier)))
+const testCodeVerifier = "dBjftJeZ4CVP-mA4I9Lc8zHqWsvkGfq5YYHEsmyHlrY"
+const testCodeChallenge = "uLyBoxNOZdidTB1ZFqdiXSVtJ3sP2vJFWooYekyYsiM"
+
+func TestS256CodeChallenge(t *testing.T) {
+	challenge, err := CodeChallengeFromVerifier(testCodeVerifier, CodeChallengeMethodS256)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if challenge != testCodeChallenge {
+		t.Fatalf("Unexpected code challenge: %v", challenge)
+	}
+	err = VerifyCodeChallenge(testCodeVerifier, testCodeChallenge, CodeChallengeMethodS256)
+	if err != nil {
+		t.Fatalf("code verifier should have matched: %v", err)
+	}
+	err = VerifyCodeChallenge(testCodeVerifier, testCodeVerifier, CodeChallengeMethodS256)
+	if err == nil {
+		t.Fatalf("code verifier should not have matched")
+	}
+}
+
+func TestPlainCodeChallenge(t *testing.T) {
+	err := VerifyCodeChallenge(testCodeVerifier, testCodeVerifier, CodeChallengeMethodPlain)
+	if err != nil {
+		t.Fatalf("code verifier should have matched: %v", err)
+	}
+	// plain is the default method
+	err = VerifyCodeChallenge(testCodeVerifier, testCodeVerifier, "")
+	if err != nil {
+		t.Fatalf("code verifier should have matched: %v", err)
+	}
+	err = VerifyCodeChallenge(testCodeVerifier, testCodeChallenge, CodeChallengeMethodPlain)
+	if err == nil {
+		t.Fatalf("code verifier should not have matched")
+	}
+}
+
+func TestInvalidCodeVerifier(t *testing.T) {
+	shortVerifier := "too-short"
+	err := VerifyCodeChallenge(shortVerifier, shortVerifier, CodeChallengeMethodPlain)
+	if err == nil {
+		t.Fatalf("code verifier should have been rejected")
+	}
+	err = VerifyCodeChallenge(testCodeVerifier, testCodeChallenge, "S512")
+	if err == nil {
+		t.Fatalf("code challenge method should have been rejected")
+	}
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// see https://datatracker.ietf.org/doc/html/rfc9126#section-2.2
+const RequestUriPrefix = "urn:ietf:params:oauth:request_uri:"
+
+// how long the client has to send the user to /authorize with the request_uri
+const PushedAuthorizationRequestLifetime = 90 * time.Second
+
+// once started, the request is kept for as long as the user takes to log in and accept
+// (the same as the current operation cookie)
+const PushedAuthorizationFlowLifetime = 15 * time.Minute
+
+type PushedAuthorizationRequestStore interface {
+	SavePushedAuthorizationRequest(ctx context.Context, request *PushedAuthorizationRequest) error
+	GetPushedAuthorizationRequest(ctx context.Context, requestUri string) (*PushedAuthorizationRequest, error)
+	DeletePushedAuthorizationRequest(ctx context.Context, requestUri string) error
+}
+
+// a validated authorization request, pushed directly to simple-oidc by the client.
+// the browser only ever sees the request_uri
+type PushedAuthorizationRequest struct {
+	RequestUri string     `dynamodbav:"requestUri"` // partition key
+	ClientId   string     `dynamodbav:"clientId"`
+	OidcParams string     `dynamodbav:"params"`
+	Expiry     *time.Time `dynamodbav:"expiry"`
+
+	// the request_uri may only be used once at the authorization endpoint
+	Started *time.Time `dynamodbav:"started"`
+}
+
+func (obj *PushedAuthorizationRequest) IsExpired(asof ...time.Time) bool {
+	if len(asof) > 1 {
+		panic("must only provide one asof arg")
+	}
+	if len(asof) != 1 {
+		asof = []time.Time{
+			time.Now().UTC(),
+		}
+	}
+	return obj.Expiry == nil || !asof[0].Before(*obj.Expiry)
+}
+
+func (obj *PushedAuthorizationRequest) IsStarted() bool {
+	return obj.Started != nil
+}
+
+// called when the user arrives at the authorization endpoint with the request_uri
+func (obj *PushedAuthorizationRequest) Start() {
+	now := time.Now().UTC()
+	expiry := now.Add(PushedAuthorizationFlowLifetime)
+	obj.Started = &now
+	obj.Expiry = &expiry
+}
+
+func NewPushedAuthorizationRequest(clientId string, oidcParams string) (*PushedAuthorizationRequest, error) {
+	now := time.Now().UTC()
+	k, err := ksuid.NewRandomWithTime(now)
+	if err != nil {
+		return nil, err
+	}
+	expiry := now.Add(PushedAuthorizationRequestLifetime)
+	return &PushedAuthorizationRequest{
+		RequestUri: RequestUriPrefix + k.String(),
+		ClientId:   clientId,
+		OidcParams: oidcParams,
+		Expiry:     &expiry,
+	}, nil
+}
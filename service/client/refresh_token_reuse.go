@@ -0,0 +1,13 @@
+package client
+
+// see https://datatracker.ietf.org/doc/html/rfc9700#section-4.14.2
+const (
+	// revoke the whole session (the token family). This is the default
+	RefreshTokenReusePolicyRevoke = "revoke"
+	// only reject the request. The current refresh token remains valid
+	RefreshTokenReusePolicyReject = "reject"
+)
+
+func (obj *Client) RevokeOnRefreshTokenReuse() bool {
+	return obj.RefreshTokenReusePolicy != RefreshTokenReusePolicyReject
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kncept-oauth/simple-oidc/service/netutil"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+)
+
+// who may register clients with the dynamic client registration endpoint
+// see https://datatracker.ietf.org/doc/html/rfc7591#section-3
+const (
+	// clients are only created by an administrator (the default)
+	RegistrationPolicyClosed = "closed"
+	// anyone may register a client
+	RegistrationPolicyOpen = "open"
+	// registration requires the initial access token as a bearer token
+	RegistrationPolicyInitialAccessToken = "initial_access_token"
+)
+
+// deployment settings for dynamic client registration.
+// a nil *RegistrationSettings is closed
+type RegistrationSettings struct {
+	Policy             string
+	InitialAccessToken string
+}
+
+func (obj *RegistrationSettings) Validate() error {
+	if obj == nil {
+		return nil
+	}
+	switch obj.Policy {
+	case "", RegistrationPolicyClosed, RegistrationPolicyOpen:
+		return nil
+	case RegistrationPolicyInitialAccessToken:
+		if obj.InitialAccessToken == "" {
+			return fmt.Errorf("an initial access token is required for client registration policy %v", obj.Policy)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown client registration policy: %v", obj.Policy)
+}
+
+func (obj *RegistrationSettings) IsEnabled() bool {
+	return obj != nil && (obj.Policy == RegistrationPolicyOpen || obj.Policy == RegistrationPolicyInitialAccessToken)
+}
+
+// checks the bearer token (if any) presented to the registration endpoint
+// see https://datatracker.ietf.org/doc/html/rfc7591#section-3
+func (obj *RegistrationSettings) AuthorizeRegistration(bearerToken string) error {
+	if !obj.IsEnabled() {
+		return oautherror.New(oautherror.AccessDenied, "dynamic client registration is disabled")
+	}
+	if obj.Policy == RegistrationPolicyOpen {
+		return nil
+	}
+	if bearerToken == "" || subtle.ConstantTimeCompare([]byte(bearerToken), []byte(obj.InitialAccessToken)) != 1 {
+		return oautherror.New(oautherror.InvalidToken, "initial access token required")
+	}
+	return nil
+}
+
+// returns the raw registration access token. ONLY the hash is kept
+// see https://datatracker.ietf.org/doc/html/rfc7592#section-3
+func (obj *Client) IssueRegistrationAccessToken() (string, error) {
+	rawToken, err := GenerateClientSecret()
+	if err != nil {
+		return "", err
+	}
+	token, err := newClientSecret(rawToken, time.Now().UTC())
+	if err != nil {
+		return "", err
+	}
+	obj.RegistrationAccessToken = token
+	return rawToken, nil
+}
+
+func (obj *Client) RegistrationAccessTokenMatches(rawToken string) bool {
+	return rawToken != "" && obj.RegistrationAccessToken != nil && obj.RegistrationAccessToken.Matches(rawToken)
+}
+
+// uris that simple-oidc itself fetches (jwks_uri, request_uris, backchannel_logout_uri) for a
+// dynamically registered client. these must be https to a public host, otherwise anyone can
+// register a client that makes simple-oidc call loopback or internal (eg: cloud metadata) addresses.
+// hostnames are checked again when they are resolved, by the netutil.FetchClient
+func ValidateFetchedUri(fetchedUri string) error {
+	err := ValidateRegisteredUri(fetchedUri)
+	if err != nil {
+		return err
+	}
+	u, err := url.Parse(fetchedUri)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("uri must use https: %v", fetchedUri)
+	}
+	hostname := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if hostname == "localhost" || strings.HasSuffix(hostname, ".localhost") {
+		return fmt.Errorf("uri must not be a loopback uri: %v", fetchedUri)
+	}
+	// link local, unspecified and multicast addresses are not global unicast
+	if addr, err := netip.ParseAddr(hostname); err == nil && !netutil.IsPublicAddress(addr) {
+		return fmt.Errorf("uri must not be a loopback or internal address: %v", fetchedUri)
+	}
+	return nil
+}
+
+// registered uris must be absolute, without a fragment.
+// https is required, except for loopback (http) and native app (private-use scheme) redirects
+// see https://datatracker.ietf.org/doc/html/rfc8252#section-7
+func ValidateRegisteredUri(registeredUri string) error {
+	u, err := url.Parse(registeredUri)
+	if err != nil {
+		return err
+	}
+	if !u.IsAbs() {
+		return fmt.Errorf("not an absolute uri: %v", registeredUri)
+	}
+	if u.Fragment != "" || strings.Contains(registeredUri, "#") {
+		return fmt.Errorf("uri must not contain a fragment: %v", registeredUri)
+	}
+	switch u.Scheme {
+	case "https":
+		if u.Host == "" {
+			return fmt.Errorf("uri has no host: %v", registeredUri)
+		}
+		return nil
+	case "http":
+		if u.Hostname() == "localhost" {
+			return nil
+		}
+		if ip := net.ParseIP(u.Hostname()); ip != nil && ip.IsLoopback() {
+			return nil
+		}
+		return fmt.Errorf("http is only allowed for loopback uris: %v", registeredUri)
+	}
+	// private-use schemes are reverse domain names (eg: com.example.app)
+	if !strings.Contains(u.Scheme, ".") {
+		return fmt.Errorf("unsupported uri scheme: %v", u.Scheme)
+	}
+	return nil
+}
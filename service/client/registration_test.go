@@ -0,0 +1,75 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestRegistrationSettings(t *testing.T) {
+	var closed *RegistrationSettings
+	if closed.Validate() != nil || closed.IsEnabled() || closed.AuthorizeRegistration("") == nil {
+		t.Fatalf("nil settings should be closed")
+	}
+	if (&RegistrationSettings{Policy: RegistrationPolicyInitialAccessToken}).Validate() == nil {
+		t.Fatalf("an initial access token is required")
+	}
+	if (&RegistrationSettings{Policy: "sometimes"}).Validate() == nil {
+		t.Fatalf("unknown policies are invalid")
+	}
+	tokenRequired := &RegistrationSettings{
+		Policy:             RegistrationPolicyInitialAccessToken,
+		InitialAccessToken: "initial",
+	}
+	if tokenRequired.AuthorizeRegistration("") == nil || tokenRequired.AuthorizeRegistration("wrong") == nil {
+		t.Fatalf("the initial access token is required")
+	}
+	if err := tokenRequired.AuthorizeRegistration("initial"); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestValidateRegisteredUri(t *testing.T) {
+	valid := []string{
+		"https://app.example.com/callback",
+		"http://localhost:8080/callback",
+		"http://127.0.0.1/callback",
+		"com.example.app:/callback",
+	}
+	for _, uri := range valid {
+		if err := ValidateRegisteredUri(uri); err != nil {
+			t.Errorf("expected %v to be valid: %v", uri, err)
+		}
+	}
+	invalid := []string{
+		"/callback",
+		"http://app.example.com/callback",
+		"https://app.example.com/callback#fragment",
+		"javascript:alert(1)",
+	}
+	for _, uri := range invalid {
+		if err := ValidateRegisteredUri(uri); err == nil {
+			t.Errorf("expected %v to be invalid", uri)
+		}
+	}
+}
+
+func TestValidateFetchedUri(t *testing.T) {
+	if err := ValidateFetchedUri("https://app.example.com/jwks.json"); err != nil {
+		t.Errorf("expected a public https uri to be valid: %v", err)
+	}
+	invalid := []string{
+		"http://app.example.com/jwks.json",
+		"http://localhost:8080/jwks.json",
+		"https://localhost/jwks.json",
+		"https://127.0.0.1/jwks.json",
+		"https://[::1]/jwks.json",
+		"https://10.0.0.1/jwks.json",
+		"https://169.254.169.254/latest/meta-data",
+		"https://0.0.0.0/jwks.json",
+		"com.example.app:/jwks.json",
+	}
+	for _, uri := range invalid {
+		if err := ValidateFetchedUri(uri); err == nil {
+			t.Errorf("expected %v to be invalid", uri)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"fmt"
+	"slices"
+)
+
+// see https://openid.net/specs/oauth-v2-multiple-response-types-1_0.html#ResponseModes
+// and https://openid.net/specs/oauth-v2-form-post-response-mode-1_0.html
+const (
+	ResponseModeQuery    = "query"
+	ResponseModeFragment = "fragment"
+	ResponseModeFormPost = "form_post"
+)
+
+var SupportedResponseModes = []string{
+	ResponseModeQuery,
+	ResponseModeFragment,
+	ResponseModeFormPost,
+}
+
+// the default mode for the response type, if no response_mode was requested
+func DefaultResponseMode(responseType string) string {
+	if IsFrontChannelResponseType(responseType) {
+		return ResponseModeFragment
+	}
+	return ResponseModeQuery
+}
+
+// the response_mode to use, falling back to the default for the response type
+func EffectiveResponseMode(responseType string, responseMode string) string {
+	if responseMode == "" {
+		return DefaultResponseMode(responseType)
+	}
+	return responseMode
+}
+
+func ValidateResponseMode(responseType string, responseMode string) error {
+	if responseMode == "" {
+		return nil
+	}
+	if !slices.Contains(SupportedResponseModes, responseMode) {
+		return fmt.Errorf("unsupported response_mode: %v", responseMode)
+	}
+	// tokens must never be sent in the query, where they end up in logs and referer headers
+	if responseMode == ResponseModeQuery && IsFrontChannelResponseType(responseType) {
+		return fmt.Errorf("response_mode %v not allowed for response_type: %v", responseMode, responseType)
+	}
+	return nil
+}
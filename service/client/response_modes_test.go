@@ -0,0 +1,32 @@
+package client
+
+import "testing"
+
+func TestEffectiveResponseMode(t *testing.T) {
+	if mode := EffectiveResponseMode(ResponseTypeCode, ""); mode != ResponseModeQuery {
+		t.Fatalf("expected query for code but got %v", mode)
+	}
+	if mode := EffectiveResponseMode(ResponseTypeCodeIdToken, ""); mode != ResponseModeFragment {
+		t.Fatalf("expected fragment for hybrid but got %v", mode)
+	}
+	if mode := EffectiveResponseMode(ResponseTypeCode, ResponseModeFormPost); mode != ResponseModeFormPost {
+		t.Fatalf("expected requested form_post but got %v", mode)
+	}
+}
+
+func TestValidateResponseMode(t *testing.T) {
+	for _, responseMode := range []string{"", ResponseModeQuery, ResponseModeFragment, ResponseModeFormPost} {
+		if err := ValidateResponseMode(ResponseTypeCode, responseMode); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+	if err := ValidateResponseMode(ResponseTypeCode, "web_message"); err == nil {
+		t.Fatalf("unsupported response_mode must be rejected")
+	}
+	if err := ValidateResponseMode(ResponseTypeIdTokenToken, ResponseModeQuery); err == nil {
+		t.Fatalf("tokens must not be returned in the query")
+	}
+	if err := ValidateResponseMode(ResponseTypeIdTokenToken, ResponseModeFormPost); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"slices"
+	"strings"
+)
+
+// see https://openid.net/specs/oauth-v2-multiple-response-types-1_0.html
+const (
+	ResponseTypeCode         = "code"
+	ResponseTypeIdToken      = "id_token"
+	ResponseTypeIdTokenToken = "id_token token"
+	ResponseTypeCodeIdToken  = "code id_token"
+)
+
+var SupportedResponseTypes = []string{
+	ResponseTypeCode,
+	ResponseTypeIdToken,
+	ResponseTypeIdTokenToken,
+	ResponseTypeCodeIdToken,
+}
+
+// response type values are a space separated, unordered, set
+// eg: "token id_token" is the same as "id_token token"
+func NormalizeResponseType(responseType string) string {
+	values := strings.Fields(responseType)
+	// sort so that 'code' is always first, followed by 'id_token' then 'token'
+	slices.SortFunc(values, func(a, b string) int {
+		return slices.Index(responseTypeOrder, a) - slices.Index(responseTypeOrder, b)
+	})
+	return strings.Join(slices.Compact(values), " ")
+}
+
+var responseTypeOrder = []string{"code", "id_token", "token"}
+
+func ResponseTypeIncludes(responseType string, value string) bool {
+	return slices.Contains(strings.Fields(responseType), value)
+}
+
+// implicit and hybrid responses are returned in the fragment by default
+func IsFrontChannelResponseType(responseType string) bool {
+	return NormalizeResponseType(responseType) != ResponseTypeCode
+}
+
+// an empty AllowedResponseTypes only allows the code flow
+func (obj *Client) IsAllowedResponseType(responseType string) bool {
+	responseType = NormalizeResponseType(responseType)
+	if !slices.Contains(SupportedResponseTypes, responseType) {
+		return false
+	}
+	if len(obj.AllowedResponseTypes) == 0 {
+		return responseType == ResponseTypeCode
+	}
+	return slices.ContainsFunc(obj.AllowedResponseTypes, func(allowed string) bool {
+		return NormalizeResponseType(allowed) == responseType
+	})
+}
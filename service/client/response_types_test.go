@@ -0,0 +1,40 @@
+package client
+
+import "testing"
+
+func TestNormalizeResponseType(t *testing.T) {
+	expected := map[string]string{
+		"code":            ResponseTypeCode,
+		"token id_token":  ResponseTypeIdTokenToken,
+		" id_token  code": ResponseTypeCodeIdToken,
+		"id_token":        ResponseTypeIdToken,
+	}
+	for responseType, normalized := range expected {
+		if actual := NormalizeResponseType(responseType); actual != normalized {
+			t.Errorf("expected %q to normalize to %q but got %q", responseType, normalized, actual)
+		}
+	}
+}
+
+func TestIsAllowedResponseType(t *testing.T) {
+	defaultClient := &Client{}
+	if !defaultClient.IsAllowedResponseType(ResponseTypeCode) {
+		t.Fatalf("code flow should be allowed by default")
+	}
+	if defaultClient.IsAllowedResponseType(ResponseTypeIdToken) {
+		t.Fatalf("implicit flow must not be allowed by default")
+	}
+
+	hybridClient := &Client{
+		AllowedResponseTypes: []string{ResponseTypeCodeIdToken},
+	}
+	if !hybridClient.IsAllowedResponseType("id_token code") {
+		t.Fatalf("hybrid flow should be allowed")
+	}
+	if hybridClient.IsAllowedResponseType(ResponseTypeCode) {
+		t.Fatalf("code flow is not in the allowed response types")
+	}
+	if hybridClient.IsAllowedResponseType("code token") {
+		t.Fatalf("unsupported response types must not be allowed")
+	}
+}
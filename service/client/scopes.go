@@ -0,0 +1,43 @@
+package client
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/scopes"
+)
+
+// validates the requested scope against the AllowedScopes, returning the granted scope.
+// an empty AllowedScopes allows any scope, and openid is always allowed
+func (obj *Client) GrantScopes(requested string) (string, error) {
+	requestedScopes := scopes.Parse(requested)
+	if len(requestedScopes) == 0 {
+		return "", oautherror.New(oautherror.InvalidScope, "scope required")
+	}
+	for _, scope := range requestedScopes {
+		if scope == scopes.OpenId || len(obj.AllowedScopes) == 0 {
+			continue
+		}
+		if !slices.Contains(obj.AllowedScopes, scope) {
+			return "", oautherror.New(oautherror.InvalidScope, "scope not allowed for client: %v", scope)
+		}
+	}
+	return strings.Join(requestedScopes, " "), nil
+}
+
+// validates the requested scope against the MachineScopes, returning the granted scope.
+// unlike user scopes, an empty request is granted every machine scope
+// see https://datatracker.ietf.org/doc/html/rfc6749#section-4.4.2
+func (obj *Client) GrantMachineScopes(requested string) (string, error) {
+	requestedScopes := scopes.Parse(requested)
+	if len(requestedScopes) == 0 {
+		return strings.Join(obj.MachineScopes, " "), nil
+	}
+	for _, scope := range requestedScopes {
+		if !slices.Contains(obj.MachineScopes, scope) {
+			return "", oautherror.New(oautherror.InvalidScope, "machine scope not allowed for client: %v", scope)
+		}
+	}
+	return strings.Join(requestedScopes, " "), nil
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+)
+
+func TestGrantScopes(t *testing.T) {
+	anyScopeClient := &Client{}
+	granted, err := anyScopeClient.GrantScopes("openid  profile custom")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if granted != "openid profile custom" {
+		t.Fatalf("unexpected granted scope: %v", granted)
+	}
+
+	restrictedClient := &Client{
+		AllowedScopes: []string{"email"},
+	}
+	if granted, err = restrictedClient.GrantScopes("openid email"); err != nil || granted != "openid email" {
+		t.Fatalf("expected openid email to be granted: %v %v", granted, err)
+	}
+	_, err = restrictedClient.GrantScopes("openid profile")
+	if oauthErr, ok := oautherror.As(err); !ok || oauthErr.Code != oautherror.InvalidScope {
+		t.Fatalf("expected invalid_scope but got %v", err)
+	}
+}
+
+func TestGrantMachineScopes(t *testing.T) {
+	machineClient := &Client{
+		MachineScopes: []string{"reports:read", "reports:write"},
+	}
+	if granted, err := machineClient.GrantMachineScopes(""); err != nil || granted != "reports:read reports:write" {
+		t.Fatalf("expected every machine scope to be granted: %v %v", granted, err)
+	}
+	if granted, err := machineClient.GrantMachineScopes("reports:write"); err != nil || granted != "reports:write" {
+		t.Fatalf("expected reports:write to be granted: %v %v", granted, err)
+	}
+	_, err := machineClient.GrantMachineScopes("reports:read profile")
+	if oauthErr, ok := oautherror.As(err); !ok || oauthErr.Code != oautherror.InvalidScope {
+		t.Fatalf("expected invalid_scope but got %v", err)
+	}
+}
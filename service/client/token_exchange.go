@@ -0,0 +1,37 @@
+package client
+
+import "slices"
+
+// allows a TokenExchangePolicy to exchange tokens for any subject (or actor)
+const TokenExchangeAnySubject = "*"
+
+// see https://datatracker.ietf.org/doc/html/rfc8693#section-5
+type TokenExchangePolicy struct {
+	// the resource servers (APIs) exchanged tokens may be issued for
+	Audiences []string `dynamodbav:"audiences"`
+
+	// the users whose tokens may be exchanged, or TokenExchangeAnySubject.
+	// if the client has public keys, it may also impersonate these users with a signed
+	// assertion (on behalf of an actor, eg: a support user)
+	Subjects []string `dynamodbav:"subjects"`
+
+	// the users who may act on behalf of a subject (delegation and impersonation),
+	// or TokenExchangeAnySubject. empty allows no actor tokens
+	Actors []string `dynamodbav:"actors"`
+}
+
+func (obj *Client) AllowsTokenExchange() bool {
+	return obj.IsConfidential() && obj.TokenExchangePolicy != nil && len(obj.TokenExchangePolicy.Audiences) != 0
+}
+
+func (obj *TokenExchangePolicy) AllowsAudience(audience string) bool {
+	return slices.Contains(obj.Audiences, audience)
+}
+
+func (obj *TokenExchangePolicy) AllowsSubject(subject string) bool {
+	return slices.Contains(obj.Subjects, TokenExchangeAnySubject) || slices.Contains(obj.Subjects, subject)
+}
+
+func (obj *TokenExchangePolicy) AllowsActor(actor string) bool {
+	return slices.Contains(obj.Actors, TokenExchangeAnySubject) || slices.Contains(obj.Actors, actor)
+}
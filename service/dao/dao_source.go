@@ -3,7 +3,9 @@ package dao
 import (
 	"context"
 
+	"github.com/kncept-oauth/simple-oidc/service/audit"
 	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
 	"github.com/kncept-oauth/simple-oidc/service/keys"
 	"github.com/kncept-oauth/simple-oidc/service/session"
 	"github.com/kncept-oauth/simple-oidc/service/users"
@@ -24,6 +26,12 @@ type DaoSource interface {
 	// OIDC Authorization Codes for user-client authorizations
 	GetAuthorizationCodeStore(ctx context.Context) client.AuthorizationCodeStore
 
+	// RFC 9126 pushed authorization requests, keyed by request uri
+	GetPushedAuthorizationRequestStore(ctx context.Context) client.PushedAuthorizationRequestStore
+
+	// RFC 8628 device authorization requests, keyed by user code
+	GetDeviceCodeStore(ctx context.Context) client.DeviceCodeStore
+
 	// Encryption keys (currently RSA)
 	GetKeyStore(ctx context.Context) keys.Keystore
 
@@ -32,4 +40,13 @@ type DaoSource interface {
 	// eg: multiple client-authorizatons can come from a single simple-oidc session
 	// TODO: rename to GetSimpleOidcSessionStore
 	GetSessionStore(ctx context.Context) session.SessionStore
+
+	// previously seen JWT ID's, for replay detection (eg: client assertions)
+	GetJtiStore(ctx context.Context) jwtutil.JtiStore
+
+	// security events (eg: token reuse), for administrators to review
+	GetEventStore(ctx context.Context) audit.EventStore
+
+	// back-channel logout notifications that are still to be delivered to clients
+	GetPendingLogoutStore(ctx context.Context) client.PendingLogoutStore
 }
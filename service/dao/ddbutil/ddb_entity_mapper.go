@@ -2,6 +2,7 @@ package ddbutil
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -92,6 +93,53 @@ func (d *DdbEntityMapper[T]) Save(ctx context.Context, value *T) error {
 	return err
 }
 
+// a conditional put. returns false (and no error) if the condition was not met
+func (d *DdbEntityMapper[T]) SaveIf(ctx context.Context, value *T, condition string, names map[string]string, values map[string]types.AttributeValue) (bool, error) {
+	mapValue, err := attributevalue.MarshalMap(value)
+	if err != nil {
+		return false, err
+	}
+	_, err = d.Ddb.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 &d.TableName,
+		Item:                      mapValue,
+		ConditionExpression:       &condition,
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	})
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// a conditional update. returns false (and no error) if the condition was not met
+func (d *DdbEntityMapper[T]) UpdateIf(ctx context.Context, partitionKey string, sortKey string, update string, condition string, names map[string]string, values map[string]types.AttributeValue) (bool, error) {
+	key, err := d.key(partitionKey, sortKey)
+	if err != nil {
+		return false, err
+	}
+	_, err = d.Ddb.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 &d.TableName,
+		Key:                       key,
+		UpdateExpression:          &update,
+		ConditionExpression:       &condition,
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	})
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (d *DdbEntityMapper[T]) DeleteById(ctx context.Context, partitionKey string, sortKey string) error {
 	key, err := d.key(partitionKey, sortKey)
 	if err != nil {
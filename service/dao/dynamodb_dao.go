@@ -2,13 +2,19 @@ package dao
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/kncept-oauth/simple-oidc/service/audit"
 	"github.com/kncept-oauth/simple-oidc/service/client"
 	"github.com/kncept-oauth/simple-oidc/service/dao/ddbutil"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
 	"github.com/kncept-oauth/simple-oidc/service/keys"
 	"github.com/kncept-oauth/simple-oidc/service/session"
 	"github.com/kncept-oauth/simple-oidc/service/users"
@@ -60,6 +66,28 @@ func (d *DdbAuthorizationCodeStore) SaveAuthorizationCode(ctx context.Context, c
 	return d.Save(ctx, code)
 }
 
+func (d *DdbAuthorizationCodeStore) DeleteAuthorizationCode(ctx context.Context, code string) error {
+	return d.DeleteById(ctx, code, "")
+}
+
+// an unredeemed code has a NULL redeemed attribute
+func (d *DdbAuthorizationCodeStore) RedeemAuthorizationCode(ctx context.Context, code string, sessionId string) (bool, error) {
+	redeemed, err := attributevalue.Marshal(time.Now().UTC())
+	if err != nil {
+		return false, err
+	}
+	return d.UpdateIf(ctx, code, "",
+		"SET redeemed = :redeemed, sessionId = :sessionId",
+		"attribute_exists(code) AND (attribute_not_exists(redeemed) OR attribute_type(redeemed, :null))",
+		nil,
+		map[string]types.AttributeValue{
+			":redeemed":  redeemed,
+			":sessionId": &types.AttributeValueMemberS{Value: sessionId},
+			":null":      &types.AttributeValueMemberS{Value: "NULL"},
+		},
+	)
+}
+
 func (d *DynamoDbDaoSource) GetAuthorizationCodeStore(ctx context.Context) client.AuthorizationCodeStore {
 	return &DdbAuthorizationCodeStore{
 		DdbEntityMapper: ddbutil.DdbEntityMapper[client.AuthorizationCode]{
@@ -153,7 +181,25 @@ func (d *DdbClientStore) RemoveClient(ctx context.Context, clientId string) erro
 }
 
 func (d *DdbClientStore) SaveClient(ctx context.Context, client *client.Client) error {
-	return d.Save(ctx, client)
+	saved, err := d.SaveIf(ctx, client, "attribute_not_exists(clientId)", nil, nil)
+	if err != nil {
+		return err
+	}
+	if !saved {
+		return fmt.Errorf("client already exists: %v", client.ClientId)
+	}
+	return nil
+}
+
+func (d *DdbClientStore) UpdateClient(ctx context.Context, client *client.Client) error {
+	saved, err := d.SaveIf(ctx, client, "attribute_exists(clientId)", nil, nil)
+	if err != nil {
+		return err
+	}
+	if !saved {
+		return fmt.Errorf("no such client: %v", client.ClientId)
+	}
+	return nil
 }
 
 func (d *DynamoDbDaoSource) GetClientStore(ctx context.Context) client.ClientStore {
@@ -231,7 +277,7 @@ type DdbSessionStore struct {
 
 func (d *DdbSessionStore) ListUserSessions(ctx context.Context, userId string) ([]*session.Session, error) {
 	scroller := &ddbutil.DepaginatedScroller[session.Session]{}
-	d.ScrollQuery(
+	err := d.ScrollQuery(
 		ctx,
 		dynamodb.QueryInput{
 			TableName: &d.TableName,
@@ -246,6 +292,9 @@ func (d *DdbSessionStore) ListUserSessions(ctx context.Context, userId string) (
 		},
 		scroller.Scroll,
 	)
+	if err != nil {
+		return nil, err
+	}
 	return scroller.Results, nil
 }
 
@@ -269,3 +318,144 @@ func (d *DynamoDbDaoSource) GetSessionStore(ctx context.Context) session.Session
 		},
 	}
 }
+
+type DdbPushedAuthorizationRequestStore struct {
+	ddbutil.DdbEntityMapper[client.PushedAuthorizationRequest]
+}
+
+func (d *DdbPushedAuthorizationRequestStore) GetPushedAuthorizationRequest(ctx context.Context, requestUri string) (*client.PushedAuthorizationRequest, error) {
+	return d.Get(ctx, requestUri, "")
+}
+
+func (d *DdbPushedAuthorizationRequestStore) SavePushedAuthorizationRequest(ctx context.Context, request *client.PushedAuthorizationRequest) error {
+	return d.Save(ctx, request)
+}
+
+func (d *DdbPushedAuthorizationRequestStore) DeletePushedAuthorizationRequest(ctx context.Context, requestUri string) error {
+	return d.DeleteById(ctx, requestUri, "")
+}
+
+func (d *DynamoDbDaoSource) GetPushedAuthorizationRequestStore(ctx context.Context) client.PushedAuthorizationRequestStore {
+	return &DdbPushedAuthorizationRequestStore{
+		DdbEntityMapper: ddbutil.DdbEntityMapper[client.PushedAuthorizationRequest]{
+			DdbEntityDetails: ddbutil.DdbEntityDetails{
+				TableName:        d.tableName("pushed-authorization-requests"),
+				PartitionKeyName: "requestUri",
+			},
+			Ddb: d.ddb,
+		},
+	}
+}
+
+type DdbDeviceCodeStore struct {
+	ddbutil.DdbEntityMapper[client.DeviceCode]
+}
+
+func (d *DdbDeviceCodeStore) GetDeviceCode(ctx context.Context, userCode string) (*client.DeviceCode, error) {
+	return d.Get(ctx, userCode, "")
+}
+
+func (d *DdbDeviceCodeStore) SaveDeviceCode(ctx context.Context, deviceCode *client.DeviceCode) error {
+	return d.Save(ctx, deviceCode)
+}
+
+func (d *DdbDeviceCodeStore) DeleteDeviceCode(ctx context.Context, userCode string) error {
+	return d.DeleteById(ctx, userCode, "")
+}
+
+func (d *DynamoDbDaoSource) GetDeviceCodeStore(ctx context.Context) client.DeviceCodeStore {
+	return &DdbDeviceCodeStore{
+		DdbEntityMapper: ddbutil.DdbEntityMapper[client.DeviceCode]{
+			DdbEntityDetails: ddbutil.DdbEntityDetails{
+				TableName:        d.tableName("device-codes"),
+				PartitionKeyName: "userCode",
+			},
+			Ddb: d.ddb,
+		},
+	}
+}
+
+type DdbJtiStore struct {
+	ddbutil.DdbEntityMapper[jwtutil.UsedJti]
+}
+
+func (d *DdbJtiStore) GetJti(ctx context.Context, jti string) (*jwtutil.UsedJti, error) {
+	return d.Get(ctx, jti, "")
+}
+
+func (d *DdbJtiStore) SaveJti(ctx context.Context, usedJti *jwtutil.UsedJti) error {
+	return d.Save(ctx, usedJti)
+}
+
+// an expired record (that has not yet been removed by the ttl) may be replaced
+func (d *DdbJtiStore) SaveJtiIfAbsent(ctx context.Context, usedJti *jwtutil.UsedJti) (bool, error) {
+	return d.SaveIf(ctx, usedJti, "attribute_not_exists(jti) OR #ttl <= :now", map[string]string{
+		"#ttl": "ttl",
+	}, map[string]types.AttributeValue{
+		":now": &types.AttributeValueMemberN{Value: strconv.FormatInt(time.Now().Unix(), 10)},
+	})
+}
+
+func (d *DynamoDbDaoSource) GetJtiStore(ctx context.Context) jwtutil.JtiStore {
+	return &DdbJtiStore{
+		DdbEntityMapper: ddbutil.DdbEntityMapper[jwtutil.UsedJti]{
+			DdbEntityDetails: ddbutil.DdbEntityDetails{
+				TableName:        d.tableName("jti"),
+				PartitionKeyName: "jti",
+			},
+			Ddb: d.ddb,
+		},
+	}
+}
+
+type DdbEventStore struct {
+	ddbutil.DdbEntityMapper[audit.Event]
+}
+
+func (d *DdbEventStore) SaveEvent(ctx context.Context, event *audit.Event) error {
+	return d.Save(ctx, event)
+}
+
+func (d *DdbEventStore) ListEvents(ctx context.Context) ([]*audit.Event, error) {
+	return d.Scan(ctx)
+}
+
+func (d *DynamoDbDaoSource) GetEventStore(ctx context.Context) audit.EventStore {
+	return &DdbEventStore{
+		DdbEntityMapper: ddbutil.DdbEntityMapper[audit.Event]{
+			DdbEntityDetails: ddbutil.DdbEntityDetails{
+				TableName:        d.tableName("events"),
+				PartitionKeyName: "id",
+			},
+			Ddb: d.ddb,
+		},
+	}
+}
+
+type DdbPendingLogoutStore struct {
+	ddbutil.DdbEntityMapper[client.PendingLogout]
+}
+
+func (d *DdbPendingLogoutStore) SavePendingLogout(ctx context.Context, pending *client.PendingLogout) error {
+	return d.Save(ctx, pending)
+}
+
+func (d *DdbPendingLogoutStore) ListPendingLogouts(ctx context.Context) ([]*client.PendingLogout, error) {
+	return d.Scan(ctx)
+}
+
+func (d *DdbPendingLogoutStore) DeletePendingLogout(ctx context.Context, id string) error {
+	return d.DeleteById(ctx, id, "")
+}
+
+func (d *DynamoDbDaoSource) GetPendingLogoutStore(ctx context.Context) client.PendingLogoutStore {
+	return &DdbPendingLogoutStore{
+		DdbEntityMapper: ddbutil.DdbEntityMapper[client.PendingLogout]{
+			DdbEntityDetails: ddbutil.DdbEntityDetails{
+				TableName:        d.tableName("pending-logouts"),
+				PartitionKeyName: "id",
+			},
+			Ddb: d.ddb,
+		},
+	}
+}
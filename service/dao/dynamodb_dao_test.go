@@ -21,8 +21,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/audit"
 	"github.com/kncept-oauth/simple-oidc/service/client"
 	"github.com/kncept-oauth/simple-oidc/service/dao/ddbutil"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
 	"github.com/kncept-oauth/simple-oidc/service/keys"
 	"github.com/kncept-oauth/simple-oidc/service/session"
 	"github.com/kncept-oauth/simple-oidc/service/users"
@@ -136,6 +138,12 @@ func AllDdbEntityDetails(ctx context.Context) []*ddbutil.DdbEntityDetails {
 	if obj, ok := dao.GetClientAuthorizationStore(ctx).(*DdbClientAuthorizationStore); ok {
 		mappers = append(mappers, &obj.DdbEntityMapper.DdbEntityDetails)
 	}
+	if obj, ok := dao.GetPushedAuthorizationRequestStore(ctx).(*DdbPushedAuthorizationRequestStore); ok {
+		mappers = append(mappers, &obj.DdbEntityMapper.DdbEntityDetails)
+	}
+	if obj, ok := dao.GetDeviceCodeStore(ctx).(*DdbDeviceCodeStore); ok {
+		mappers = append(mappers, &obj.DdbEntityMapper.DdbEntityDetails)
+	}
 	if obj, ok := dao.GetKeyStore(ctx).(*DdbKeyStore); ok {
 		mappers = append(mappers, &obj.DdbEntityMapper.DdbEntityDetails)
 	}
@@ -145,6 +153,15 @@ func AllDdbEntityDetails(ctx context.Context) []*ddbutil.DdbEntityDetails {
 	if obj, ok := dao.GetSessionStore(ctx).(*DdbSessionStore); ok {
 		mappers = append(mappers, &obj.DdbEntityMapper.DdbEntityDetails)
 	}
+	if obj, ok := dao.GetJtiStore(ctx).(*DdbJtiStore); ok {
+		mappers = append(mappers, &obj.DdbEntityMapper.DdbEntityDetails)
+	}
+	if obj, ok := dao.GetEventStore(ctx).(*DdbEventStore); ok {
+		mappers = append(mappers, &obj.DdbEntityMapper.DdbEntityDetails)
+	}
+	if obj, ok := dao.GetPendingLogoutStore(ctx).(*DdbPendingLogoutStore); ok {
+		mappers = append(mappers, &obj.DdbEntityMapper.DdbEntityDetails)
+	}
 	slices.SortFunc(mappers, func(a, b *ddbutil.DdbEntityDetails) int {
 		return strings.Compare(a.TableName, b.TableName)
 	})
@@ -188,6 +205,8 @@ func TestClientStore(t *testing.T) {
 		fmt.Printf("Expected %v but got %v for the client id", newClient.ClientId, foundClient.ClientId)
 	}
 
+	assertSaveClientOnlyCreates(t, clientStore)
+
 	listedClients, err := clientStore.ListClients(ctx)
 	if err != nil {
 		t.Fatalf("ListClients error")
@@ -346,6 +365,133 @@ func TestAuthorizationCodeStore(t *testing.T) {
 	if code.Code != newAuthCode.Code {
 		t.Fatalf("Expected %v but got %v as the code", newAuthCode.Code, code.Code)
 	}
+
+	sessionId := uuid.NewString()
+	redeemed, err := authCodes.RedeemAuthorizationCode(ctx, newAuthCode.Code, sessionId)
+	if err != nil {
+		t.Fatalf("RedeemAuthorizationCode failed: %v", err)
+	}
+	if !redeemed {
+		t.Fatalf("expected the code to be redeemed")
+	}
+	redeemed, err = authCodes.RedeemAuthorizationCode(ctx, newAuthCode.Code, uuid.NewString())
+	if err != nil {
+		t.Fatalf("RedeemAuthorizationCode failed: %v", err)
+	}
+	if redeemed {
+		t.Fatalf("a code must only be redeemed once")
+	}
+	code, err = authCodes.GetAuthorizationCode(ctx, newAuthCode.Code)
+	if err != nil {
+		t.Fatalf("GetAuthorizationCode failed: %s", err)
+	}
+	if !code.IsRedeemed() || code.SessionId != sessionId {
+		t.Fatalf("Expected the code to be redeemed into session %v: %+v", sessionId, code)
+	}
+
+	err = authCodes.DeleteAuthorizationCode(ctx, newAuthCode.Code)
+	if err != nil {
+		t.Fatalf("DeleteAuthorizationCode failed: %v", err)
+	}
+	code, err = authCodes.GetAuthorizationCode(ctx, newAuthCode.Code)
+	if err != nil {
+		t.Fatalf("GetAuthorizationCode failed: %s", err)
+	}
+	if code != nil {
+		t.Fatalf("Found a code after it was deleted: %+v", code)
+	}
+}
+
+func TestDeviceCodeStore(t *testing.T) {
+	cfg := *AwsCfg
+	ctx := t.Context()
+	dao := NewDynamoDbDao(cfg, "")
+	deviceCodes := dao.GetDeviceCodeStore(ctx)
+
+	dc, err := deviceCodes.GetDeviceCode(ctx, "does not exist")
+	if err != nil {
+		t.Fatalf("GetDeviceCode failed: %s", err)
+	}
+	if dc != nil {
+		t.Fatalf("Found a device code when it shouldn't have: %+v", dc)
+	}
+
+	newDeviceCode, err := client.NewDeviceCode(uuid.NewString(), "openid")
+	if err != nil {
+		t.Fatalf("NewDeviceCode failed: %v", err)
+	}
+	err = deviceCodes.SaveDeviceCode(ctx, newDeviceCode)
+	if err != nil {
+		t.Fatalf("SaveDeviceCode failed: %v", err)
+	}
+	dc, err = deviceCodes.GetDeviceCode(ctx, newDeviceCode.UserCode)
+	if err != nil {
+		t.Fatalf("GetDeviceCode failed: %s", err)
+	}
+	if dc == nil {
+		t.Fatalf("failed to find a device code")
+	}
+	if !dc.DeviceCodeMatches(newDeviceCode.DeviceCode) {
+		t.Fatalf("Expected %v but got %v as the device code", newDeviceCode.DeviceCode, dc.DeviceCode)
+	}
+
+	err = deviceCodes.DeleteDeviceCode(ctx, newDeviceCode.UserCode)
+	if err != nil {
+		t.Fatalf("DeleteDeviceCode failed: %v", err)
+	}
+	dc, err = deviceCodes.GetDeviceCode(ctx, newDeviceCode.UserCode)
+	if err != nil {
+		t.Fatalf("GetDeviceCode failed: %s", err)
+	}
+	if dc != nil {
+		t.Fatalf("Found a device code after it was deleted: %+v", dc)
+	}
+}
+
+func TestPushedAuthorizationRequestStore(t *testing.T) {
+	cfg := *AwsCfg
+	ctx := t.Context()
+	dao := NewDynamoDbDao(cfg, "")
+	pushedRequests := dao.GetPushedAuthorizationRequestStore(ctx)
+
+	request, err := pushedRequests.GetPushedAuthorizationRequest(ctx, "does not exist")
+	if err != nil {
+		t.Fatalf("GetPushedAuthorizationRequest failed: %s", err)
+	}
+	if request != nil {
+		t.Fatalf("Found a request when it shouldn't have: %+v", request)
+	}
+
+	newRequest, err := client.NewPushedAuthorizationRequest(uuid.NewString(), "response_type=code")
+	if err != nil {
+		t.Fatalf("NewPushedAuthorizationRequest failed: %v", err)
+	}
+	err = pushedRequests.SavePushedAuthorizationRequest(ctx, newRequest)
+	if err != nil {
+		t.Fatalf("SavePushedAuthorizationRequest failed: %v", err)
+	}
+	request, err = pushedRequests.GetPushedAuthorizationRequest(ctx, newRequest.RequestUri)
+	if err != nil {
+		t.Fatalf("GetPushedAuthorizationRequest failed: %s", err)
+	}
+	if request == nil {
+		t.Fatalf("failed to find a request")
+	}
+	if request.OidcParams != newRequest.OidcParams {
+		t.Fatalf("Expected %v but got %v as the params", newRequest.OidcParams, request.OidcParams)
+	}
+
+	err = pushedRequests.DeletePushedAuthorizationRequest(ctx, newRequest.RequestUri)
+	if err != nil {
+		t.Fatalf("DeletePushedAuthorizationRequest failed: %v", err)
+	}
+	request, err = pushedRequests.GetPushedAuthorizationRequest(ctx, newRequest.RequestUri)
+	if err != nil {
+		t.Fatalf("GetPushedAuthorizationRequest failed: %s", err)
+	}
+	if request != nil {
+		t.Fatalf("Found a request after it was deleted: %+v", request)
+	}
 }
 
 func TestKeystore(t *testing.T) {
@@ -513,6 +659,115 @@ func TestSessionStore(t *testing.T) {
 		fmt.Printf("session mismatch:\n%+v\n%+v\n", ses, foundSession)
 		t.Fatalf("session mismatch")
 	}
+
+	userSessions, err := sessionStore.ListUserSessions(ctx, ses.UserId)
+	if err != nil {
+		t.Fatalf("Unable to ListUserSessions: %v", err)
+	}
+	if len(userSessions) != 1 || userSessions[0].SessionId != ses.SessionId {
+		t.Fatalf("expected only the users session, but got %v", len(userSessions))
+	}
+}
+
+func TestEventStore(t *testing.T) {
+	cfg := *AwsCfg
+	ctx := t.Context()
+	dao := NewDynamoDbDao(cfg, "")
+
+	eventStore := dao.GetEventStore(ctx)
+
+	event, err := audit.NewEvent(audit.EventTypeRefreshTokenReuse)
+	if err != nil {
+		t.Fatalf("Unable to create event: %v", err)
+	}
+	event.UserId = uuid.NewString()
+	err = eventStore.SaveEvent(ctx, event)
+	if err != nil {
+		t.Fatalf("Unable to SaveEvent: %v", err)
+	}
+
+	events, err := eventStore.ListEvents(ctx)
+	if err != nil {
+		t.Fatalf("Unable to ListEvents: %v", err)
+	}
+	if !slices.ContainsFunc(events, func(e *audit.Event) bool {
+		return e.EventId == event.EventId && e.UserId == event.UserId
+	}) {
+		t.Fatalf("Unable to find event")
+	}
+}
+
+func TestPendingLogoutStore(t *testing.T) {
+	cfg := *AwsCfg
+	ctx := t.Context()
+	dao := NewDynamoDbDao(cfg, "")
+
+	pendingLogoutStore := dao.GetPendingLogoutStore(ctx)
+
+	pending, err := client.NewPendingLogout(uuid.NewString(), uuid.NewString(), uuid.NewString())
+	if err != nil {
+		t.Fatalf("Unable to create pending logout: %v", err)
+	}
+	err = pendingLogoutStore.SavePendingLogout(ctx, pending)
+	if err != nil {
+		t.Fatalf("Unable to SavePendingLogout: %v", err)
+	}
+	isListed := func() bool {
+		listed, err := pendingLogoutStore.ListPendingLogouts(ctx)
+		if err != nil {
+			t.Fatalf("Unable to ListPendingLogouts: %v", err)
+		}
+		return slices.ContainsFunc(listed, func(p *client.PendingLogout) bool {
+			return p.Id == pending.Id && p.SessionId == pending.SessionId
+		})
+	}
+	if !isListed() {
+		t.Fatalf("Unable to find pending logout")
+	}
+
+	err = pendingLogoutStore.DeletePendingLogout(ctx, pending.Id)
+	if err != nil {
+		t.Fatalf("Unable to DeletePendingLogout: %v", err)
+	}
+	if isListed() {
+		t.Fatalf("Pending logout was not deleted")
+	}
+}
+
+func TestJtiStore(t *testing.T) {
+	cfg := *AwsCfg
+	ctx := t.Context()
+	dao := NewDynamoDbDao(cfg, "")
+	jtiStore := dao.GetJtiStore(ctx)
+
+	key := uuid.NewString()
+	saved, err := jtiStore.SaveJtiIfAbsent(ctx, jwtutil.NewUsedJti(key, time.Now().Add(time.Minute)))
+	if err != nil {
+		t.Fatalf("SaveJtiIfAbsent failed: %v", err)
+	}
+	if !saved {
+		t.Fatalf("expected a new jti to be saved")
+	}
+	saved, err = jtiStore.SaveJtiIfAbsent(ctx, jwtutil.NewUsedJti(key, time.Now().Add(time.Minute)))
+	if err != nil {
+		t.Fatalf("SaveJtiIfAbsent failed: %v", err)
+	}
+	if saved {
+		t.Fatalf("a recorded jti must not be saved again")
+	}
+
+	expiredKey := uuid.NewString()
+	err = jtiStore.SaveJti(ctx, jwtutil.NewUsedJti(expiredKey, time.Now().Add(-time.Minute)))
+	if err != nil {
+		t.Fatalf("SaveJti failed: %v", err)
+	}
+	saved, err = jtiStore.SaveJtiIfAbsent(ctx, jwtutil.NewUsedJti(expiredKey, time.Now().Add(time.Minute)))
+	if err != nil {
+		t.Fatalf("SaveJtiIfAbsent failed: %v", err)
+	}
+	if !saved {
+		t.Fatalf("an expired jti may be replaced")
+	}
 }
 
 func TestTablesNamesMatchJson(t *testing.T) {
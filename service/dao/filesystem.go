@@ -5,13 +5,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 
+	"github.com/kncept-oauth/simple-oidc/service/audit"
 	"github.com/kncept-oauth/simple-oidc/service/client"
 	"github.com/kncept-oauth/simple-oidc/service/dao/ddbutil"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
 	"github.com/kncept-oauth/simple-oidc/service/keys"
 	"github.com/kncept-oauth/simple-oidc/service/session"
 	"github.com/kncept-oauth/simple-oidc/service/users"
@@ -21,6 +25,9 @@ type FilesystemDao struct {
 	RootDir string
 }
 
+// guards read-modify-write operations. the filesystem dao is only used by a single process
+var fsLock sync.Mutex
+
 func writeJson(rootDir string, id string, val interface{}) error {
 	data, err := json.Marshal(val)
 	if err != nil {
@@ -108,6 +115,41 @@ func (obj *FilesystemDao) GetAuthorizationCodeStore(ctx context.Context) client.
 	}
 }
 
+func (obj *FilesystemDao) GetPushedAuthorizationRequestStore(ctx context.Context) client.PushedAuthorizationRequestStore {
+	os.Mkdir(path.Join(obj.RootDir, "pushed-authorization-requests"), 0700)
+	return &fsPushedAuthorizationRequestStore{
+		RootDir: path.Join(obj.RootDir, "pushed-authorization-requests"),
+	}
+}
+
+func (obj *FilesystemDao) GetDeviceCodeStore(ctx context.Context) client.DeviceCodeStore {
+	os.Mkdir(path.Join(obj.RootDir, "device-codes"), 0700)
+	return &fsDeviceCodeStore{
+		RootDir: path.Join(obj.RootDir, "device-codes"),
+	}
+}
+
+func (obj *FilesystemDao) GetJtiStore(ctx context.Context) jwtutil.JtiStore {
+	os.Mkdir(path.Join(obj.RootDir, "jti"), 0700)
+	return &fsJtiStore{
+		RootDir: path.Join(obj.RootDir, "jti"),
+	}
+}
+
+func (obj *FilesystemDao) GetEventStore(ctx context.Context) audit.EventStore {
+	os.Mkdir(path.Join(obj.RootDir, "events"), 0700)
+	return &fsEventStore{
+		RootDir: path.Join(obj.RootDir, "events"),
+	}
+}
+
+func (obj *FilesystemDao) GetPendingLogoutStore(ctx context.Context) client.PendingLogoutStore {
+	os.Mkdir(path.Join(obj.RootDir, "pending-logouts"), 0700)
+	return &fsPendingLogoutStore{
+		RootDir: path.Join(obj.RootDir, "pending-logouts"),
+	}
+}
+
 // returns things like /tmp/go-build2313914230/b001 in test
 func RootDirFromExePath() (string, error) {
 	ex, err := os.Executable()
@@ -161,6 +203,26 @@ type authorizationCodeStore struct {
 	RootDir string
 }
 
+type fsPushedAuthorizationRequestStore struct {
+	RootDir string
+}
+
+type fsDeviceCodeStore struct {
+	RootDir string
+}
+
+type fsJtiStore struct {
+	RootDir string
+}
+
+type fsEventStore struct {
+	RootDir string
+}
+
+type fsPendingLogoutStore struct {
+	RootDir string
+}
+
 func (c *clientAuthorizationStore) All(scrollFn func(page []*client.ClientAuthorization) bool) error {
 	files, err := listDir(c.RootDir)
 	if err != nil {
@@ -240,6 +302,28 @@ func (c *fsClientStore) GetClient(ctx context.Context, clientId string) (*client
 }
 
 func (c *fsClientStore) SaveClient(ctx context.Context, client *client.Client) error {
+	fsLock.Lock()
+	defer fsLock.Unlock()
+	existing, err := c.GetClient(ctx, client.ClientId)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("client already exists: %v", client.ClientId)
+	}
+	return writeJson(c.RootDir, client.ClientId, client)
+}
+
+func (c *fsClientStore) UpdateClient(ctx context.Context, client *client.Client) error {
+	fsLock.Lock()
+	defer fsLock.Unlock()
+	existing, err := c.GetClient(ctx, client.ClientId)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return fmt.Errorf("no such client: %v", client.ClientId)
+	}
 	return writeJson(c.RootDir, client.ClientId, client)
 }
 
@@ -298,13 +382,15 @@ func (c *fsSessionStore) ListUserSessions(ctx context.Context, userId string) ([
 	if err != nil {
 		return nil, err
 	}
-	sessions := make([]*session.Session, len(ids))
-	for idx, id := range ids {
+	sessions := make([]*session.Session, 0)
+	for _, id := range ids {
 		ses, err := c.LoadSession(ctx, id, "")
 		if err != nil {
 			return nil, err
 		}
-		sessions[idx] = ses
+		if ses != nil && ses.UserId == userId {
+			sessions = append(sessions, ses)
+		}
 	}
 	return sessions, nil
 }
@@ -316,3 +402,127 @@ func (a *authorizationCodeStore) GetAuthorizationCode(ctx context.Context, code
 func (a *authorizationCodeStore) SaveAuthorizationCode(ctx context.Context, code *client.AuthorizationCode) error {
 	return writeJson(a.RootDir, code.Code, code)
 }
+
+func (a *authorizationCodeStore) RedeemAuthorizationCode(ctx context.Context, code string, sessionId string) (bool, error) {
+	fsLock.Lock()
+	defer fsLock.Unlock()
+	authCode, err := a.GetAuthorizationCode(ctx, code)
+	if err != nil {
+		return false, err
+	}
+	if authCode == nil || authCode.IsRedeemed() {
+		return false, nil
+	}
+	authCode.Redeem(sessionId)
+	return true, a.SaveAuthorizationCode(ctx, authCode)
+}
+
+func (a *authorizationCodeStore) DeleteAuthorizationCode(ctx context.Context, code string) error {
+	err := deleteJson(a.RootDir, code)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// request uris are urns, so are escaped to be used as a filename
+func (p *fsPushedAuthorizationRequestStore) GetPushedAuthorizationRequest(ctx context.Context, requestUri string) (*client.PushedAuthorizationRequest, error) {
+	return readJson[client.PushedAuthorizationRequest](p.RootDir, url.QueryEscape(requestUri))
+}
+
+func (p *fsPushedAuthorizationRequestStore) SavePushedAuthorizationRequest(ctx context.Context, request *client.PushedAuthorizationRequest) error {
+	return writeJson(p.RootDir, url.QueryEscape(request.RequestUri), request)
+}
+
+func (p *fsPushedAuthorizationRequestStore) DeletePushedAuthorizationRequest(ctx context.Context, requestUri string) error {
+	err := deleteJson(p.RootDir, url.QueryEscape(requestUri))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (d *fsDeviceCodeStore) GetDeviceCode(ctx context.Context, userCode string) (*client.DeviceCode, error) {
+	return readJson[client.DeviceCode](d.RootDir, url.PathEscape(userCode))
+}
+
+func (d *fsDeviceCodeStore) SaveDeviceCode(ctx context.Context, deviceCode *client.DeviceCode) error {
+	return writeJson(d.RootDir, url.PathEscape(deviceCode.UserCode), deviceCode)
+}
+
+func (d *fsDeviceCodeStore) DeleteDeviceCode(ctx context.Context, userCode string) error {
+	err := deleteJson(d.RootDir, url.PathEscape(userCode))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// jti values are namespaced and client supplied, so must be escaped to be used as a filename
+func (j *fsJtiStore) GetJti(ctx context.Context, jti string) (*jwtutil.UsedJti, error) {
+	return readJson[jwtutil.UsedJti](j.RootDir, url.PathEscape(jti))
+}
+
+func (j *fsJtiStore) SaveJti(ctx context.Context, usedJti *jwtutil.UsedJti) error {
+	return writeJson(j.RootDir, url.PathEscape(usedJti.Jti), usedJti)
+}
+
+func (j *fsJtiStore) SaveJtiIfAbsent(ctx context.Context, usedJti *jwtutil.UsedJti) (bool, error) {
+	fsLock.Lock()
+	defer fsLock.Unlock()
+	existing, err := j.GetJti(ctx, usedJti.Jti)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil && !existing.IsExpired() {
+		return false, nil
+	}
+	return true, j.SaveJti(ctx, usedJti)
+}
+
+func (e *fsEventStore) SaveEvent(ctx context.Context, event *audit.Event) error {
+	return writeJson(e.RootDir, event.EventId, event)
+}
+
+func (e *fsEventStore) ListEvents(ctx context.Context) ([]*audit.Event, error) {
+	ids, err := listDir(e.RootDir)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*audit.Event, len(ids))
+	for idx, id := range ids {
+		event, err := readJson[audit.Event](e.RootDir, id)
+		if err != nil {
+			return nil, err
+		}
+		events[idx] = event
+	}
+	return events, nil
+}
+
+func (p *fsPendingLogoutStore) SavePendingLogout(ctx context.Context, pending *client.PendingLogout) error {
+	return writeJson(p.RootDir, pending.Id, pending)
+}
+
+func (p *fsPendingLogoutStore) ListPendingLogouts(ctx context.Context) ([]*client.PendingLogout, error) {
+	ids, err := listDir(p.RootDir)
+	if err != nil {
+		return nil, err
+	}
+	pending := make([]*client.PendingLogout, len(ids))
+	for idx, id := range ids {
+		pending[idx], err = readJson[client.PendingLogout](p.RootDir, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pending, nil
+}
+
+func (p *fsPendingLogoutStore) DeletePendingLogout(ctx context.Context, id string) error {
+	err := deleteJson(p.RootDir, id)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
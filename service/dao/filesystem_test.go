@@ -2,6 +2,9 @@ package dao
 
 import (
 	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/client"
 )
 
 func TestIsDaoSource(t *testing.T) {
@@ -12,3 +15,48 @@ func assertIsDaoSource(receiver DaoSource) {}
 func TestNew(t *testing.T) {
 	NewDefaultFilesystemDao()
 }
+
+func TestSaveClientOnlyCreates(t *testing.T) {
+	ctx := t.Context()
+	assertSaveClientOnlyCreates(t, NewMemoryDao().GetClientStore(ctx))
+	assertSaveClientOnlyCreates(t, NewFilesystemDao(t.TempDir()).GetClientStore(ctx))
+}
+
+// SaveClient must never silently replace an existing client, updates are explicit
+func assertSaveClientOnlyCreates(t *testing.T, clientStore client.ClientStore) {
+	t.Helper()
+	ctx := t.Context()
+	newClient := &client.Client{
+		ClientId:   uuid.NewString(),
+		PublicName: "created",
+	}
+	err := clientStore.UpdateClient(ctx, newClient)
+	if err == nil {
+		t.Fatalf("expected UpdateClient to fail for a new client")
+	}
+	err = clientStore.SaveClient(ctx, newClient)
+	if err != nil {
+		t.Fatalf("SaveClient error: %v", err)
+	}
+	err = clientStore.SaveClient(ctx, &client.Client{
+		ClientId:   newClient.ClientId,
+		PublicName: "overwritten",
+	})
+	if err == nil {
+		t.Fatalf("expected SaveClient to fail for an existing client")
+	}
+	err = clientStore.UpdateClient(ctx, &client.Client{
+		ClientId:   newClient.ClientId,
+		PublicName: "updated",
+	})
+	if err != nil {
+		t.Fatalf("UpdateClient error: %v", err)
+	}
+	foundClient, err := clientStore.GetClient(ctx, newClient.ClientId)
+	if err != nil {
+		t.Fatalf("GetClient error: %v", err)
+	}
+	if foundClient == nil || foundClient.PublicName != "updated" {
+		t.Fatalf("expected the updated client, but got %+v", foundClient)
+	}
+}
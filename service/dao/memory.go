@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/kncept-oauth/simple-oidc/service/audit"
 	"github.com/kncept-oauth/simple-oidc/service/client"
 	"github.com/kncept-oauth/simple-oidc/service/dao/ddbutil"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
 	"github.com/kncept-oauth/simple-oidc/service/keys"
 	"github.com/kncept-oauth/simple-oidc/service/session"
 	"github.com/kncept-oauth/simple-oidc/service/users"
@@ -19,6 +21,11 @@ type MemoryDao struct {
 	sessions             sync.Map
 	clientAuthorizations sync.Map
 	authorizationCodes   sync.Map
+	deviceCodes          sync.Map
+	pushedRequests       sync.Map
+	jtis                 sync.Map
+	events               sync.Map
+	pendingLogouts       sync.Map
 }
 
 func NewMemoryDao() DaoSource {
@@ -53,6 +60,26 @@ func (obj *MemoryDao) GetAuthorizationCodeStore(ctx context.Context) client.Auth
 	return obj
 }
 
+func (obj *MemoryDao) GetPushedAuthorizationRequestStore(ctx context.Context) client.PushedAuthorizationRequestStore {
+	return obj
+}
+
+func (obj *MemoryDao) GetDeviceCodeStore(ctx context.Context) client.DeviceCodeStore {
+	return obj
+}
+
+func (obj *MemoryDao) GetJtiStore(ctx context.Context) jwtutil.JtiStore {
+	return obj
+}
+
+func (obj *MemoryDao) GetEventStore(ctx context.Context) audit.EventStore {
+	return obj
+}
+
+func (obj *MemoryDao) GetPendingLogoutStore(ctx context.Context) client.PendingLogoutStore {
+	return obj
+}
+
 func (obj *MemoryDao) GetKey(ctx context.Context, kid string) (*keys.JwkKeypair, error) {
 	keypair, ok := obj.keys.Load(kid)
 	if ok {
@@ -84,13 +111,18 @@ func (obj *MemoryDao) GetClient(ctx context.Context, clientId string) (*client.C
 }
 
 func (obj *MemoryDao) SaveClient(ctx context.Context, c *client.Client) error {
-	existing, err := obj.GetClient(ctx, c.ClientId)
-	if err != nil {
-		return err
-	}
-	if existing != nil {
+	_, loaded := obj.clients.LoadOrStore(c.ClientId, c)
+	if loaded {
 		return fmt.Errorf("client already exists: %v", c.ClientId)
 	}
+	return nil
+}
+
+func (obj *MemoryDao) UpdateClient(ctx context.Context, c *client.Client) error {
+	_, ok := obj.clients.Load(c.ClientId)
+	if !ok {
+		return fmt.Errorf("no such client: %v", c.ClientId)
+	}
 	obj.clients.Store(c.ClientId, c)
 	return nil
 }
@@ -134,13 +166,16 @@ func (obj *MemoryDao) SaveSession(ctx context.Context, session *session.Session)
 	return nil
 }
 func (obj *MemoryDao) LoadSession(ctx context.Context, sessionId string, userId string) (*session.Session, error) {
-	sessionObj, _ := obj.sessions.Load(sessionId)
+	sessionObj, ok := obj.sessions.Load(sessionId)
+	if !ok {
+		return nil, nil
+	}
 	return sessionObj.(*session.Session), nil
 }
 func (obj *MemoryDao) ListUserSessions(ctx context.Context, userId string) ([]*session.Session, error) {
 	sessions := make([]*session.Session, 0)
-	obj.clients.Range(func(key, value any) bool {
-		if s, ok := value.(*session.Session); ok {
+	obj.sessions.Range(func(key, value any) bool {
+		if s, ok := value.(*session.Session); ok && s.UserId == userId {
 			sessions = append(sessions, s)
 		}
 		return true
@@ -193,11 +228,132 @@ func (obj *MemoryDao) GetClientAuthorization(ctx context.Context, userId string,
 }
 
 func (obj *MemoryDao) GetAuthorizationCode(ctx context.Context, code string) (*client.AuthorizationCode, error) {
-	c, _ := obj.clientAuthorizations.Load(code)
+	c, ok := obj.authorizationCodes.Load(code)
+	if !ok {
+		return nil, nil
+	}
 	return c.(*client.AuthorizationCode), nil
 }
 
 func (obj *MemoryDao) SaveAuthorizationCode(ctx context.Context, code *client.AuthorizationCode) error {
-	obj.clientAuthorizations.Store(code.Code, code)
+	obj.authorizationCodes.Store(code.Code, code)
+	return nil
+}
+
+func (obj *MemoryDao) RedeemAuthorizationCode(ctx context.Context, code string, sessionId string) (bool, error) {
+	for {
+		c, ok := obj.authorizationCodes.Load(code)
+		if !ok || c.(*client.AuthorizationCode).IsRedeemed() {
+			return false, nil
+		}
+		// the stored value is shared with readers, so a copy is swapped in
+		redeemed := *c.(*client.AuthorizationCode)
+		redeemed.Redeem(sessionId)
+		if obj.authorizationCodes.CompareAndSwap(code, c, &redeemed) {
+			return true, nil
+		}
+	}
+}
+
+func (obj *MemoryDao) DeleteAuthorizationCode(ctx context.Context, code string) error {
+	obj.authorizationCodes.Delete(code)
+	return nil
+}
+
+func (obj *MemoryDao) GetPushedAuthorizationRequest(ctx context.Context, requestUri string) (*client.PushedAuthorizationRequest, error) {
+	request, ok := obj.pushedRequests.Load(requestUri)
+	if !ok {
+		return nil, nil
+	}
+	return request.(*client.PushedAuthorizationRequest), nil
+}
+
+func (obj *MemoryDao) SavePushedAuthorizationRequest(ctx context.Context, request *client.PushedAuthorizationRequest) error {
+	obj.pushedRequests.Store(request.RequestUri, request)
+	return nil
+}
+
+func (obj *MemoryDao) DeletePushedAuthorizationRequest(ctx context.Context, requestUri string) error {
+	obj.pushedRequests.Delete(requestUri)
+	return nil
+}
+
+func (obj *MemoryDao) GetDeviceCode(ctx context.Context, userCode string) (*client.DeviceCode, error) {
+	dc, ok := obj.deviceCodes.Load(userCode)
+	if !ok {
+		return nil, nil
+	}
+	return dc.(*client.DeviceCode), nil
+}
+
+func (obj *MemoryDao) SaveDeviceCode(ctx context.Context, deviceCode *client.DeviceCode) error {
+	obj.deviceCodes.Store(deviceCode.UserCode, deviceCode)
+	return nil
+}
+
+func (obj *MemoryDao) DeleteDeviceCode(ctx context.Context, userCode string) error {
+	obj.deviceCodes.Delete(userCode)
+	return nil
+}
+
+func (obj *MemoryDao) GetJti(ctx context.Context, jti string) (*jwtutil.UsedJti, error) {
+	usedJti, ok := obj.jtis.Load(jti)
+	if !ok {
+		return nil, nil
+	}
+	return usedJti.(*jwtutil.UsedJti), nil
+}
+
+func (obj *MemoryDao) SaveJti(ctx context.Context, usedJti *jwtutil.UsedJti) error {
+	obj.jtis.Store(usedJti.Jti, usedJti)
+	return nil
+}
+
+func (obj *MemoryDao) SaveJtiIfAbsent(ctx context.Context, usedJti *jwtutil.UsedJti) (bool, error) {
+	for {
+		existing, loaded := obj.jtis.LoadOrStore(usedJti.Jti, usedJti)
+		if !loaded {
+			return true, nil
+		}
+		if !existing.(*jwtutil.UsedJti).IsExpired() {
+			return false, nil
+		}
+		// replace the expired record, unless another save got there first
+		if obj.jtis.CompareAndSwap(usedJti.Jti, existing, usedJti) {
+			return true, nil
+		}
+	}
+}
+
+func (obj *MemoryDao) SaveEvent(ctx context.Context, event *audit.Event) error {
+	obj.events.Store(event.EventId, event)
+	return nil
+}
+
+func (obj *MemoryDao) ListEvents(ctx context.Context) ([]*audit.Event, error) {
+	events := make([]*audit.Event, 0)
+	obj.events.Range(func(key, value any) bool {
+		events = append(events, value.(*audit.Event))
+		return true
+	})
+	return events, nil
+}
+
+func (obj *MemoryDao) SavePendingLogout(ctx context.Context, pending *client.PendingLogout) error {
+	obj.pendingLogouts.Store(pending.Id, pending)
+	return nil
+}
+
+func (obj *MemoryDao) ListPendingLogouts(ctx context.Context) ([]*client.PendingLogout, error) {
+	pending := make([]*client.PendingLogout, 0)
+	obj.pendingLogouts.Range(func(key, value any) bool {
+		pending = append(pending, value.(*client.PendingLogout))
+		return true
+	})
+	return pending, nil
+}
+
+func (obj *MemoryDao) DeletePendingLogout(ctx context.Context, id string) error {
+	obj.pendingLogouts.Delete(id)
 	return nil
 }
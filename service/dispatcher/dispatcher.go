@@ -6,6 +6,7 @@ import (
 
 	"github.com/klauspost/compress/gzhttp"
 
+	"github.com/kncept-oauth/simple-oidc/service/client"
 	"github.com/kncept-oauth/simple-oidc/service/dao"
 	"github.com/kncept-oauth/simple-oidc/service/dispatcher/httpdispatcher"
 	"github.com/kncept-oauth/simple-oidc/service/dispatcher/oapidispatcher"
@@ -18,15 +19,19 @@ import (
 func NewApplication(
 	daoSource dao.DaoSource,
 	urlPrefix string,
+	registration *client.RegistrationSettings,
 	devModeLiveFilesystemBase *string,
 ) (http.HandlerFunc, error) {
 	urlPrefix = strings.TrimSuffix(urlPrefix, "/")
+	if err := registration.Validate(); err != nil {
+		return nil, err
+	}
 
 	serveMux := httpdispatcher.NewAcceptOidcHandler(daoSource, urlPrefix, devModeLiveFilesystemBase)
 	staticFileHandler := httpdispatcher.NewStaticFilesDispatcher(devModeLiveFilesystemBase)
 	templateHandler := httpdispatcher.NewTemplateDispatcher(devModeLiveFilesystemBase)
 
-	openApiHandler := oapidispatcher.NewOapiDispatcher(daoSource, urlPrefix)
+	openApiHandler := oapidispatcher.NewOapiDispatcher(daoSource, urlPrefix, registration, templateHandler)
 
 	server, err := api.NewServer(
 		openApiHandler,
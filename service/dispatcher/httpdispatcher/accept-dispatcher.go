@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,8 +16,11 @@ import (
 	"github.com/kncept-oauth/simple-oidc/service/dao/ddbutil"
 	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
 	"github.com/kncept-oauth/simple-oidc/service/keys"
+	"github.com/kncept-oauth/simple-oidc/service/logout"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
 	"github.com/kncept-oauth/simple-oidc/service/params"
 	"github.com/kncept-oauth/simple-oidc/service/session"
+	"github.com/kncept-oauth/simple-oidc/service/tokens"
 	"github.com/kncept-oauth/simple-oidc/service/users"
 )
 
@@ -30,7 +34,8 @@ type acceptOidcHandler struct {
 	daoSource dao.DaoSource
 	urlPrefix string
 
-	templateDispatcher *TemplateDispatcher
+	templateDispatcher  *TemplateDispatcher
+	backChannelNotifier *logout.BackChannelNotifier
 }
 
 func NewAcceptOidcHandler(
@@ -44,6 +49,10 @@ func NewAcceptOidcHandler(
 		urlPrefix:          urlPrefix,
 		daoSource:          daoSource,
 		templateDispatcher: NewTemplateDispatcher(devModeLiveFilesystemBase),
+		backChannelNotifier: &logout.BackChannelNotifier{
+			DaoSource: daoSource,
+			Issuer:    urlPrefix,
+		},
 	}
 
 	serveMux.Handle("/snippet/", acceptOidcHandler.snippetHandler())
@@ -57,6 +66,7 @@ func NewAcceptOidcHandler(
 	// serveMux.Handle("/htmx.js", acceptOidcHandler.respondWithStaticFile("htmx.js", "application/javascript", 200))
 	// serveMux.Handle("/header.js", acceptOidcHandler.respondWithStaticFile("header.js", "application/javascript", 200))
 	serveMux.Handle("/confirm", acceptOidcHandler.confirmLogin())
+	serveMux.Handle("/device", acceptOidcHandler.deviceHandler())
 
 	serveMux.Handle("/deauthorize/", acceptOidcHandler.deauthClientHandler())
 
@@ -75,10 +85,24 @@ func (obj *acceptOidcHandler) myAccountHandler() http.HandlerFunc {
 
 		userId := claims.Sub
 
+		statusCode := 200
+		var profileErr error
+		if req.Method == http.MethodPost {
+			req.ParseForm()
+			userService := &users.UserService{
+				UserStore: obj.daoSource.GetUserStore(ctx),
+			}
+			_, profileErr = userService.UpdateProfile(ctx, userId, profileFromForm(req.Form))
+			if profileErr != nil {
+				statusCode = 400
+			}
+		}
+
 		user, err := obj.daoSource.GetUserStore(ctx).GetUser(ctx, userId)
 		if err != nil {
 			fmt.Printf("%v\n", err)
 			res.WriteHeader(500)
+			return
 		}
 
 		clientAuthorizations := &ddbutil.DepaginatedScroller[client.ClientAuthorization]{}
@@ -92,14 +116,32 @@ func (obj *acceptOidcHandler) myAccountHandler() http.HandlerFunc {
 		type account_page_params struct {
 			User                 *users.OidcUser
 			ClientAuthorizations []*client.ClientAuthorization
+			ProfileErr           error
+			ProfileSaved         bool
 		}
 
 		params := account_page_params{
 			User:                 user,
 			ClientAuthorizations: clientAuthorizations.Results,
+			ProfileErr:           profileErr,
+			ProfileSaved:         req.Method == http.MethodPost && profileErr == nil,
 		}
 
-		obj.templateDispatcher.RespondWithTemplate("account.html", 200, res, params)
+		obj.templateDispatcher.RespondWithTemplate("account.html", statusCode, res, params)
+	}
+}
+
+// the verified flags are not user editable
+func profileFromForm(form url.Values) users.UserProfile {
+	return users.UserProfile{
+		Name:        strings.TrimSpace(form.Get("name")),
+		GivenName:   strings.TrimSpace(form.Get("given_name")),
+		FamilyName:  strings.TrimSpace(form.Get("family_name")),
+		Picture:     strings.TrimSpace(form.Get("picture")),
+		Locale:      strings.TrimSpace(form.Get("locale")),
+		Zoneinfo:    strings.TrimSpace(form.Get("zoneinfo")),
+		Email:       strings.TrimSpace(form.Get("email")),
+		PhoneNumber: strings.TrimSpace(form.Get("phone_number")),
 	}
 }
 
@@ -123,6 +165,18 @@ func (obj *acceptOidcHandler) userId(req *http.Request) string {
 	return claims.Sub
 }
 
+// when the user logged in to simple-oidc (NOT when they accepted the client)
+func (obj *acceptOidcHandler) userAuthTime(req *http.Request) time.Time {
+	claims := obj.userClaims(req)
+	if claims == nil {
+		return time.Time{}
+	}
+	if claims.AuthTime != 0 {
+		return time.Unix(claims.AuthTime, 0).UTC()
+	}
+	return time.Unix(claims.Iat, 0).UTC()
+}
+
 func (obj *acceptOidcHandler) userClaims(req *http.Request) *jwtutil.IdToken {
 	ctx := req.Context()
 	soJwt, err := req.Cookie(LoginJwtCookieName) // Simple Oidc Session JWT (if present)
@@ -132,6 +186,10 @@ func (obj *acceptOidcHandler) userClaims(req *http.Request) *jwtutil.IdToken {
 	if soJwt == nil {
 		return nil
 	}
+	// access tokens are handed to resource servers, so must never be accepted as a login
+	if jwtutil.JwtHasType(soJwt.Value, jwtutil.AccessTokenType) || jwtutil.JwtHasType(soJwt.Value, jwtutil.RefreshTokenType) {
+		return nil
+	}
 	kid := jwtutil.JwtKeyId(soJwt.Value)
 	if kid == "" {
 		return nil
@@ -155,16 +213,19 @@ func (obj *acceptOidcHandler) userClaims(req *http.Request) *jwtutil.IdToken {
 	if err != nil {
 		return nil
 	}
+	// only the id token for a simple-oidc login, not one issued to a client
+	if jwtToken.AZP != client.ClientId_SimpleOidc {
+		return nil
+	}
 
-	// // jwtToken.SessionId
-	// session, err := obj.daoSource.GetSessionStore(ctx).LoadSession(ctx, jwtToken.SessionId)
-	// if err == nil {
-	// 	return nil
-	// }
-	// // session has expired, or been revoked
-	// if session == nil {
-	// 	return nil
-	// }
+	ses, err := obj.daoSource.GetSessionStore(ctx).LoadSession(ctx, jwtToken.Sid, jwtToken.Sub)
+	if err != nil {
+		return nil
+	}
+	// session has expired, or been revoked (logged out)
+	if ses == nil || !ses.IsActive() || ses.ClientId != client.ClientId_SimpleOidc {
+		return nil
+	}
 
 	return jwtToken
 }
@@ -208,16 +269,43 @@ func (obj *acceptOidcHandler) acceptLogin() http.HandlerFunc {
 			return
 		}
 
+		soCurrent, err = obj.resolvePushedAuthorizationRequest(ctx, soCurrent)
+		if errors.Is(err, errInvalidRequestUri) {
+			res.WriteHeader(400)
+			return
+		}
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			res.WriteHeader(500)
+			return
+		}
+
 		if !soCurrent.IsValid() {
 			// TODO: Send to an 'invalid state' page (unrecoverable)
 			res.WriteHeader(400)
 			return
 		}
 
+		// a tampered request is rejected before the user is asked to accept it
+		if !soCurrent.IsDeviceAuthorization() {
+			_, err = obj.authorizationRequestClient(ctx, soCurrent)
+			if _, ok := oautherror.As(err); ok {
+				fmt.Printf("%v\n", err)
+				res.WriteHeader(400)
+				return
+			}
+			if err != nil {
+				fmt.Printf("%v\n", err)
+				res.WriteHeader(500)
+				return
+			}
+		}
+
 		type accept_page_params struct {
 			Params                params.OidcAuthCodeFlowParams
 			ExistingAuthorization *client.ClientAuthorization
 			ClientAuthorizations  []*client.ClientAuthorization
+			CsrfToken             string
 		}
 
 		acceptPageParams := accept_page_params{
@@ -244,6 +332,12 @@ func (obj *acceptOidcHandler) acceptLogin() http.HandlerFunc {
 					}
 				}
 				acceptPageParams.ClientAuthorizations = clientAuthorizations
+				acceptPageParams.CsrfToken, err = newConfirmCsrfToken(res, soCurrentParams)
+				if err != nil {
+					fmt.Printf("%v\n", err)
+					res.WriteHeader(500)
+					return
+				}
 				obj.templateDispatcher.RespondWithTemplate("accept_authenticated.html", 200, res, acceptPageParams)
 				return
 			}
@@ -260,14 +354,24 @@ func (obj *acceptOidcHandler) acceptLogin() http.HandlerFunc {
 }
 
 // click 'confirm' ==> redirect back to app
+// only the form on the accept page may confirm, so that another site can't confirm for the user
 func (obj *acceptOidcHandler) confirmLogin() http.HandlerFunc {
 	return func(res http.ResponseWriter, req *http.Request) {
 		ctx := req.Context()
+		if req.Method != http.MethodPost {
+			res.Header().Set("Allow", http.MethodPost)
+			res.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
 		soCurrentParams := ""
 		soCurrentCookie, _ := req.Cookie(CurrentOperationParamsCookieName)
 		if soCurrentCookie != nil {
 			soCurrentParams = soCurrentCookie.Value
 		}
+		if !isValidConfirmCsrfToken(req, soCurrentParams) {
+			res.WriteHeader(http.StatusForbidden)
+			return
+		}
 		soCurrent, err := params.OidcParamsFromQuery(soCurrentParams)
 		if err != nil {
 			fmt.Printf("%v\n", err)
@@ -294,12 +398,38 @@ func (obj *acceptOidcHandler) confirmLogin() http.HandlerFunc {
 			return
 		}
 
+		soCurrent, err = obj.resolvePushedAuthorizationRequest(ctx, soCurrent)
+		if errors.Is(err, errInvalidRequestUri) {
+			res.WriteHeader(400)
+			return
+		}
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			res.WriteHeader(500)
+			return
+		}
+
 		if !soCurrent.IsValid() {
 			// TODO: Send to an 'invalid state' page (unrecoverable)
 			res.WriteHeader(400)
 			return
 		}
 
+		var oidcClient *client.Client
+		if !soCurrent.IsDeviceAuthorization() {
+			oidcClient, err = obj.authorizationRequestClient(ctx, soCurrent)
+			if _, ok := oautherror.As(err); ok {
+				fmt.Printf("%v\n", err)
+				res.WriteHeader(400)
+				return
+			}
+			if err != nil {
+				fmt.Printf("%v\n", err)
+				res.WriteHeader(500)
+				return
+			}
+		}
+
 		userId := obj.userId(req)
 		if userId == "" {
 			res.WriteHeader(400)
@@ -335,38 +465,151 @@ func (obj *acceptOidcHandler) confirmLogin() http.HandlerFunc {
 			}
 		}
 
-		authCodeStore := obj.daoSource.GetAuthorizationCodeStore(ctx)
-		authCode, err := client.NewAuthorizationCode(userId, soCurrent.ToQueryParams())
+		if soCurrent.IsDeviceAuthorization() {
+			err = obj.approveDeviceCode(ctx, userId, obj.userAuthTime(req), soCurrent)
+			if errors.Is(err, errInvalidUserCode) {
+				obj.templateDispatcher.RespondWithTemplate("device.html", http.StatusBadRequest, res, map[string]any{
+					"Err": err,
+				})
+				return
+			}
+			if err != nil {
+				fmt.Printf("%v\n", err)
+				res.WriteHeader(500)
+				return
+			}
+			// the request is complete, so it can't be approved again
+			http.SetCookie(res, &http.Cookie{
+				Name:     CurrentOperationParamsCookieName,
+				Value:    "",
+				MaxAge:   -1, // expire cookie
+				HttpOnly: true,
+				SameSite: http.SameSiteDefaultMode,
+			})
+			obj.templateDispatcher.RespondWithTemplate("device_approved.html", 200, res, nil)
+			return
+		}
+
+		responseParams, err := obj.authorizationResponse(ctx, oidcClient, userId, obj.userAuthTime(req), soCurrent)
 		if err != nil {
 			fmt.Printf("%v\n", err)
 			res.WriteHeader(500)
 			return
 		}
-		err = authCodeStore.SaveAuthorizationCode(ctx, authCode)
+		err = obj.completePushedAuthorizationRequest(ctx, soCurrent)
 		if err != nil {
 			fmt.Printf("%v\n", err)
 			res.WriteHeader(500)
 			return
 		}
-		code := authCode.Code
-		state := soCurrent.State
+		if soCurrent.State != "" {
+			responseParams.Add("state", soCurrent.State)
+		}
 
-		u, err := url.Parse(soCurrent.RedirectUri)
+		responseMode := client.EffectiveResponseMode(soCurrent.ResponseType, soCurrent.ResponseMode)
+		if responseMode == client.ResponseModeFormPost {
+			// the response contains codes and/or tokens
+			res.Header().Add("Cache-Control", "no-store")
+			obj.templateDispatcher.RespondWithTemplate("form_post.html", 200, res, formPostParams(soCurrent.RedirectUri, responseParams))
+			return
+		}
+		location, err := AuthorizationResponseRedirect(soCurrent.RedirectUri, responseParams, responseMode == client.ResponseModeFragment)
 		if err != nil {
 			fmt.Printf("%v\n", err)
 			res.WriteHeader(500)
 			return
 		}
-		q := u.Query()
-		q.Add("code", code)
-		if state != "" {
-			q.Add("state", state)
+		res.Header().Add("Location", location)
+		res.WriteHeader(302)
+	}
+}
+
+// the operation params are round tripped via the browser (unless they are kept server side),
+// so the request is validated again in full. validation normalizes the response type and reduces the scope
+func (obj *acceptOidcHandler) authorizationRequestClient(ctx context.Context, soCurrent *params.OidcAuthCodeFlowParams) (*client.Client, error) {
+	oidcClient, err := obj.daoSource.GetClientStore(ctx).GetClient(ctx, soCurrent.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	if oidcClient == nil {
+		return nil, oautherror.New(oautherror.InvalidRequest, "unknown client %v", soCurrent.ClientId)
+	}
+	// pushed (and signed) requests are kept server side, otherwise the request could be edited in the browser
+	if (oidcClient.RequirePushedAuthorizationRequests || oidcClient.RequireSignedRequestObject) && soCurrent.RequestUri == "" {
+		return nil, oautherror.New(oautherror.InvalidRequest, "server side authorization request required for client %v", soCurrent.ClientId)
+	}
+	if !oidcClient.IsValidRedirectUri(soCurrent.RedirectUri) {
+		return nil, oautherror.New(oautherror.InvalidRequest, "invalid redirect uri: %v", soCurrent.RedirectUri)
+	}
+	err = oidcClient.ValidateAuthorizeRequest(soCurrent)
+	if err != nil {
+		return nil, err
+	}
+	return oidcClient, nil
+}
+
+// the code and/or tokens for the requested response_type.
+// the request must have been validated for the client
+func (obj *acceptOidcHandler) authorizationResponse(ctx context.Context, oidcClient *client.Client, userId string, authTime time.Time, soCurrent *params.OidcAuthCodeFlowParams) (url.Values, error) {
+	responseType := soCurrent.ResponseType
+	grantedScope := soCurrent.Scope
+	responseParams := url.Values{}
+
+	var err error
+	var authCode *client.AuthorizationCode
+	if client.ResponseTypeIncludes(responseType, client.ResponseTypeCode) {
+		authCode, err = client.NewAuthorizationCode(userId, soCurrent.ClientId, soCurrent.RedirectUri, soCurrent.ToQueryParams())
+		if err != nil {
+			return nil, err
+		}
+		authCode.Nonce = soCurrent.Nonce
+		authCode.Scope = grantedScope
+		authCode.AuthTime = authTime
+		authCode.CodeChallenge = soCurrent.CodeChallenge
+		authCode.CodeChallengeMethod = soCurrent.CodeChallengeMethod
+		responseParams.Add("code", authCode.Code)
+	}
+
+	if client.ResponseTypeIncludes(responseType, client.ResponseTypeIdToken) {
+		ses, err := session.NewSession(userId, soCurrent.ClientId)
+		if err != nil {
+			return nil, err
 		}
-		u.RawQuery = q.Encode()
+		ses.Nonce = soCurrent.Nonce
+		ses.Scope = grantedScope
+		ses.AuthTime = authTime
 
-		res.Header().Add("Location", u.String())
-		res.WriteHeader(302)
+		returnAccessToken := client.ResponseTypeIncludes(responseType, "token")
+		options := tokens.IssueOptions{
+			AccessToken: returnAccessToken,
+		}
+		if authCode != nil {
+			options.Code = authCode.Code
+			// the code is redeemed into the same session
+			authCode.SessionId = ses.SessionId
+		}
+		issued, err := (&tokens.TokenService{
+			DaoSource: obj.daoSource,
+			Issuer:    obj.urlPrefix,
+		}).IssueTokens(ctx, ses, oidcClient, options)
+		if err != nil {
+			return nil, err
+		}
+		responseParams.Add("id_token", issued.IdToken)
+		if returnAccessToken {
+			responseParams.Add("access_token", issued.AccessToken)
+			responseParams.Add("token_type", "Bearer")
+			responseParams.Add("expires_in", strconv.FormatInt(issued.ExpiresIn(), 10))
+		}
+	}
+
+	if authCode != nil {
+		err := obj.daoSource.GetAuthorizationCodeStore(ctx).SaveAuthorizationCode(ctx, authCode)
+		if err != nil {
+			return nil, err
+		}
 	}
+	return responseParams, nil
 }
 
 func (obj *acceptOidcHandler) createUserSession(ctx context.Context, res http.ResponseWriter, user *users.OidcUser) {
@@ -427,7 +670,7 @@ func (obj *acceptOidcHandler) createUserSession(ctx context.Context, res http.Re
 		})
 		return
 	}
-	rt, err := jwtutil.ClaimsToJwt(refreshToken, key.Kid, rsaKey)
+	rt, err := jwtutil.ClaimsToTypedJwt(refreshToken, jwtutil.RefreshTokenType, key.Kid, rsaKey)
 	if err != nil {
 		obj.templateDispatcher.RespondWithTemplate("register.html", 500, res, map[string]any{
 			"err": err,
@@ -561,21 +804,6 @@ func (obj *acceptOidcHandler) loginHandler() http.HandlerFunc {
 	}
 }
 
-func (obj *acceptOidcHandler) logoutHandler() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		// expire login cookie
-		loginCookie := &http.Cookie{
-			Name:     LoginJwtCookieName,
-			Value:    "",
-			MaxAge:   0, // expire cookie
-			HttpOnly: true,
-			SameSite: http.SameSiteDefaultMode,
-		}
-		http.SetCookie(w, loginCookie)
-		http.Redirect(w, r, "/", http.StatusFound)
-	}
-}
-
 func (obj *acceptOidcHandler) deauthClientHandler() http.HandlerFunc {
 	return func(res http.ResponseWriter, req *http.Request) {
 		// fmt.Printf("snippetHandler req.RequestURI: %v\n", 1req.RequestURI)
@@ -605,6 +833,14 @@ func (obj *acceptOidcHandler) deauthClientHandler() http.HandlerFunc {
 					res.WriteHeader(http.StatusInternalServerError)
 					return
 				}
+				// the client may no longer act on behalf of the user
+				endedSessions, err := session.RevokeUserClientSessions(ctx, obj.daoSource.GetSessionStore(ctx), userId, clientId)
+				if err != nil {
+					fmt.Printf("%v\n", err)
+					res.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				obj.backChannelNotifier.SessionsEnded(ctx, endedSessions)
 
 				// postback?!?
 				res.Header().Add("Location", "/me")
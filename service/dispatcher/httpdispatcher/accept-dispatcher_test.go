@@ -0,0 +1,215 @@
+package httpdispatcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/keys"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/params"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+	"github.com/kncept-oauth/simple-oidc/service/tokens"
+	"github.com/kncept-oauth/simple-oidc/service/users"
+)
+
+func TestAcceptRevalidatesAuthorizationRequest(t *testing.T) {
+	ctx := t.Context()
+	daoSource := dao.NewMemoryDao()
+	oidcClient := &client.Client{
+		ClientId:            uuid.NewString(),
+		AllowedRedirectUris: []string{"https://client/callback"},
+		ExactRedirectUris:   true,
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, oidcClient)
+	handler := &acceptOidcHandler{
+		daoSource: daoSource,
+	}
+	authRequest := func() *params.OidcAuthCodeFlowParams {
+		return &params.OidcAuthCodeFlowParams{
+			ResponseType: "code",
+			ClientId:     oidcClient.ClientId,
+			Scope:        "openid",
+			RedirectUri:  "https://client/callback",
+		}
+	}
+	expectInvalid := func(authRequest *params.OidcAuthCodeFlowParams) {
+		t.Helper()
+		if _, err := handler.authorizationRequestClient(ctx, authRequest); err == nil {
+			t.Fatalf("expected an invalid request: %+v", authRequest)
+		} else if _, ok := oautherror.As(err); !ok {
+			t.Fatalf("expected an oauth error but got %v", err)
+		}
+	}
+
+	if _, err := handler.authorizationRequestClient(ctx, authRequest()); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// the params are round tripped via the browser, so can be edited
+	crafted := authRequest()
+	crafted.RedirectUri = "https://evil/callback"
+	expectInvalid(crafted)
+	crafted = authRequest()
+	crafted.ResponseType = "id_token token"
+	crafted.Nonce = "nonce"
+	expectInvalid(crafted)
+	oidcClient.RequirePkce = true
+	daoSource.GetClientStore(ctx).UpdateClient(ctx, oidcClient)
+	expectInvalid(authRequest())
+}
+
+func TestLoginCookieOnlyAcceptsSimpleOidcLogins(t *testing.T) {
+	ctx := t.Context()
+	issuer := "https://issuer.example.com"
+	daoSource := dao.NewMemoryDao()
+	keypair, err := keys.GenerateJwkKeypair()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = daoSource.GetKeyStore(ctx).SaveKey(ctx, keypair)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	user := &users.OidcUser{
+		Id: "username",
+	}
+	daoSource.GetUserStore(ctx).SaveUser(ctx, user)
+	oidcClient := &client.Client{
+		ClientId: uuid.NewString(),
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, oidcClient)
+	handler := &acceptOidcHandler{
+		daoSource: daoSource,
+		urlPrefix: issuer,
+	}
+	withLoginCookie := func(value string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/authorize", nil)
+		req.AddCookie(&http.Cookie{Name: LoginJwtCookieName, Value: value})
+		return req
+	}
+
+	loginRec := httptest.NewRecorder()
+	handler.createUserSession(ctx, loginRec, user)
+	for _, cookie := range loginRec.Result().Cookies() {
+		if cookie.Name == LoginJwtCookieName {
+			if claims := handler.userClaims(withLoginCookie(cookie.Value)); claims == nil || claims.Sub != user.Id {
+				t.Fatalf("expected the simple-oidc login to be accepted, but got %+v", claims)
+			}
+		}
+	}
+
+	// tokens issued to a client are signed by the same key, but are not a login
+	ses, err := session.NewSession(user.Id, oidcClient.ClientId)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	issued, err := (&tokens.TokenService{
+		DaoSource: daoSource,
+		Issuer:    issuer,
+	}).IssueTokens(ctx, ses, oidcClient, tokens.IssueOptions{AccessToken: true})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if claims := handler.userClaims(withLoginCookie(issued.IdToken)); claims != nil {
+		t.Fatalf("a client id token must not be accepted as a login: %+v", claims)
+	}
+	if claims := handler.userClaims(withLoginCookie(issued.AccessToken)); claims != nil {
+		t.Fatalf("an access token must not be accepted as a login: %+v", claims)
+	}
+}
+
+func TestDeviceConfirmationRequiresTheAcceptForm(t *testing.T) {
+	ctx := t.Context()
+	issuer := "https://issuer.example.com"
+	daoSource := dao.NewMemoryDao()
+	keypair, err := keys.GenerateJwkKeypair()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = daoSource.GetKeyStore(ctx).SaveKey(ctx, keypair)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	user := &users.OidcUser{
+		Id: "username",
+	}
+	daoSource.GetUserStore(ctx).SaveUser(ctx, user)
+	dc, err := client.NewDeviceCode(uuid.NewString(), "openid")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	daoSource.GetDeviceCodeStore(ctx).SaveDeviceCode(ctx, dc)
+	handler := &acceptOidcHandler{
+		daoSource:          daoSource,
+		urlPrefix:          issuer,
+		templateDispatcher: NewTemplateDispatcher(nil),
+	}
+
+	loginRec := httptest.NewRecorder()
+	handler.createUserSession(ctx, loginRec, user)
+	cookies := loginRec.Result().Cookies()
+	deviceReq := httptest.NewRequest(http.MethodPost, "/device", strings.NewReader(url.Values{"user_code": {dc.UserCode}}.Encode()))
+	deviceReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	deviceRec := httptest.NewRecorder()
+	handler.deviceHandler()(deviceRec, deviceReq)
+	cookies = append(cookies, deviceRec.Result().Cookies()...)
+
+	acceptReq := httptest.NewRequest(http.MethodGet, "/accept", nil)
+	for _, cookie := range cookies {
+		acceptReq.AddCookie(cookie)
+	}
+	acceptRec := httptest.NewRecorder()
+	handler.acceptLogin()(acceptRec, acceptReq)
+	if acceptRec.Code != http.StatusOK {
+		t.Fatalf("expected the accept page, but got %v", acceptRec.Code)
+	}
+	match := regexp.MustCompile(`name="csrf_token" value="([^"]+)"`).FindStringSubmatch(acceptRec.Body.String())
+	if match == nil {
+		t.Fatalf("the accept page has no csrf token")
+	}
+	cookies = append(cookies, acceptRec.Result().Cookies()...)
+
+	confirm := func(method string, csrfToken string) int {
+		var req *http.Request
+		if method == http.MethodPost {
+			req = httptest.NewRequest(method, "/confirm", strings.NewReader(url.Values{ConfirmCsrfFormName: {csrfToken}}.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else {
+			req = httptest.NewRequest(method, "/confirm", nil)
+		}
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		rec := httptest.NewRecorder()
+		handler.confirmLogin()(rec, req)
+		return rec.Code
+	}
+	isApproved := func() bool {
+		loaded, err := daoSource.GetDeviceCodeStore(ctx).GetDeviceCode(ctx, dc.UserCode)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return loaded.IsApproved()
+	}
+
+	// eg: a link or form on another site
+	if status := confirm(http.MethodGet, ""); status != http.StatusMethodNotAllowed || isApproved() {
+		t.Fatalf("expected a GET to be rejected, but got %v", status)
+	}
+	if status := confirm(http.MethodPost, ""); status != http.StatusForbidden || isApproved() {
+		t.Fatalf("expected a missing csrf token to be rejected, but got %v", status)
+	}
+	if status := confirm(http.MethodPost, "forged"); status != http.StatusForbidden || isApproved() {
+		t.Fatalf("expected a forged csrf token to be rejected, but got %v", status)
+	}
+	if status := confirm(http.MethodPost, match[1]); status != http.StatusOK || !isApproved() {
+		t.Fatalf("expected the device to be approved, but got %v", status)
+	}
+}
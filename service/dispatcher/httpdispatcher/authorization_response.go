@@ -0,0 +1,42 @@
+package httpdispatcher
+
+import (
+	"io"
+	"net/url"
+)
+
+// builds the redirect back to the client with the authorization response parameters,
+// in either the query or the fragment (form_post is rendered as a page instead)
+// see https://openid.net/specs/oauth-v2-multiple-response-types-1_0.html#ResponseModes
+func AuthorizationResponseRedirect(redirectUri string, responseParams url.Values, fragment bool) (string, error) {
+	u, err := url.Parse(redirectUri)
+	if err != nil {
+		return "", err
+	}
+	if fragment {
+		u.Fragment = ""
+		u.RawFragment = ""
+		return u.String() + "#" + responseParams.Encode(), nil
+	}
+	q := u.Query()
+	for k, values := range responseParams {
+		for _, v := range values {
+			q.Add(k, v)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// the auto submitting form that posts the authorization response parameters to the client
+// see https://openid.net/specs/oauth-v2-form-post-response-mode-1_0.html
+func (obj *TemplateDispatcher) RenderFormPost(w io.Writer, redirectUri string, responseParams url.Values) error {
+	return obj.templates().ExecuteTemplate(w, "form_post.html", formPostParams(redirectUri, responseParams))
+}
+
+func formPostParams(redirectUri string, responseParams url.Values) map[string]any {
+	return map[string]any{
+		"RedirectUri": redirectUri,
+		"Params":      responseParams,
+	}
+}
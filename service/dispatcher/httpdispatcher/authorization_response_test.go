@@ -0,0 +1,74 @@
+package httpdispatcher
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestAuthorizationResponseRedirect(t *testing.T) {
+	responseParams := url.Values{}
+	responseParams.Add("code", "abc")
+	responseParams.Add("state", "a b&c")
+
+	location, err := AuthorizationResponseRedirect("https://client/callback?existing=1", responseParams, false)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if location != "https://client/callback?code=abc&existing=1&state=a+b%26c" {
+		t.Fatalf("unexpected query response: %v", location)
+	}
+
+	location, err = AuthorizationResponseRedirect("https://client/callback?existing=1", responseParams, true)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if location != "https://client/callback?existing=1#code=abc&state=a+b%26c" {
+		t.Fatalf("unexpected fragment response: %v", location)
+	}
+}
+
+func TestFormPostTemplate(t *testing.T) {
+	responseParams := url.Values{}
+	responseParams.Add("code", "abc")
+	responseParams.Add("state", `"><script>`)
+
+	res := httptest.NewRecorder()
+	NewTemplateDispatcher(nil).RespondWithTemplate("form_post.html", 200, res, map[string]any{
+		"RedirectUri": "https://client/callback",
+		"Params":      responseParams,
+	})
+	body := res.Body.String()
+	if !strings.Contains(body, `action="https://client/callback"`) {
+		t.Fatalf("form must post to the redirect uri: %v", body)
+	}
+	if !strings.Contains(body, `name="code" value="abc"`) {
+		t.Fatalf("form must contain the code: %v", body)
+	}
+	if strings.Contains(body, `"><script>`) {
+		t.Fatalf("params must be escaped: %v", body)
+	}
+}
+
+func TestRenderFormPost(t *testing.T) {
+	responseParams := url.Values{}
+	responseParams.Add("error", "access_denied")
+	responseParams.Add("state", `"><script>`)
+
+	res := &strings.Builder{}
+	err := NewTemplateDispatcher(nil).RenderFormPost(res, "https://client/callback", responseParams)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	body := res.String()
+	if !strings.Contains(body, `action="https://client/callback"`) {
+		t.Fatalf("form must post to the redirect uri: %v", body)
+	}
+	if !strings.Contains(body, `name="error" value="access_denied"`) {
+		t.Fatalf("form must contain the error: %v", body)
+	}
+	if strings.Contains(body, `"><script>`) {
+		t.Fatalf("params must be escaped: %v", body)
+	}
+}
@@ -0,0 +1,51 @@
+package httpdispatcher
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+)
+
+const ConfirmCsrfCookieName = "so-csrf"
+const ConfirmCsrfFormName = "csrf_token"
+
+// confirming is a state change (eg: approving a device code), so must only be done by the user
+// submitting the accept page. the form token is an hmac of the current operation params, keyed by
+// a random value that only the users browser has (in a cookie).
+// see https://datatracker.ietf.org/doc/html/rfc8628#section-5.4
+func newConfirmCsrfToken(res http.ResponseWriter, currentOperationParams string) (string, error) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", err
+	}
+	http.SetCookie(res, &http.Cookie{
+		Name:     ConfirmCsrfCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString(key),
+		MaxAge:   15 * 60, // 15 min, the same as the current operation
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return confirmCsrfToken(key, currentOperationParams), nil
+}
+
+func isValidConfirmCsrfToken(req *http.Request, currentOperationParams string) bool {
+	csrfCookie, _ := req.Cookie(ConfirmCsrfCookieName)
+	if csrfCookie == nil {
+		return false
+	}
+	key, err := base64.RawURLEncoding.DecodeString(csrfCookie.Value)
+	if err != nil || len(key) == 0 {
+		return false
+	}
+	expected := confirmCsrfToken(key, currentOperationParams)
+	return hmac.Equal([]byte(expected), []byte(req.PostFormValue(ConfirmCsrfFormName)))
+}
+
+func confirmCsrfToken(key []byte, currentOperationParams string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(currentOperationParams))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
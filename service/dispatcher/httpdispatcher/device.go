@@ -0,0 +1,105 @@
+package httpdispatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/params"
+)
+
+var errInvalidUserCode = errors.New("invalid or expired code")
+
+// the verification page for the device authorization grant.
+// the user enters the code shown on the device, then logs in and approves with the usual accept page
+// see https://datatracker.ietf.org/doc/html/rfc8628#section-3.3
+func (obj *acceptOidcHandler) deviceHandler() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		if req.Method != http.MethodGet && req.Method != http.MethodPost {
+			res.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		req.ParseForm()
+
+		type device_page_params struct {
+			UserCode string
+			Err      error
+		}
+
+		userCode := req.Form.Get("user_code")
+		if req.Method == http.MethodGet {
+			// the verification_uri_complete prefills the code, but the user still has to submit it
+			obj.templateDispatcher.RespondWithTemplate("device.html", http.StatusOK, res, device_page_params{
+				UserCode: userCode,
+			})
+			return
+		}
+
+		dc, err := obj.pendingDeviceCode(ctx, userCode)
+		if errors.Is(err, errInvalidUserCode) {
+			obj.templateDispatcher.RespondWithTemplate("device.html", http.StatusBadRequest, res, device_page_params{
+				UserCode: userCode,
+				Err:      err,
+			})
+			return
+		}
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// replaces any authorization request in progress
+		soCurrent := &params.OidcAuthCodeFlowParams{
+			ClientId: dc.ClientId,
+			Scope:    dc.Scope,
+			UserCode: dc.UserCode,
+		}
+		http.SetCookie(res, &http.Cookie{
+			Name:     CurrentOperationParamsCookieName,
+			Value:    soCurrent.ToQueryParams(),
+			MaxAge:   15 * 60, // 15 min
+			HttpOnly: true,
+			SameSite: http.SameSiteDefaultMode,
+		})
+		res.Header().Add("Location", "/accept")
+		res.WriteHeader(http.StatusFound)
+	}
+}
+
+// a device code that is waiting for the user to approve it
+func (obj *acceptOidcHandler) pendingDeviceCode(ctx context.Context, userCode string) (*client.DeviceCode, error) {
+	userCode = client.NormalizeUserCode(userCode)
+	if userCode == "" {
+		return nil, errInvalidUserCode
+	}
+	dc, err := obj.daoSource.GetDeviceCodeStore(ctx).GetDeviceCode(ctx, userCode)
+	if err != nil {
+		return nil, err
+	}
+	if dc == nil || dc.IsExpired() || dc.IsApproved() {
+		return nil, errInvalidUserCode
+	}
+	return dc, nil
+}
+
+// called from the accept page confirmation. The tokens are issued when the device next polls
+func (obj *acceptOidcHandler) approveDeviceCode(ctx context.Context, userId string, authTime time.Time, soCurrent *params.OidcAuthCodeFlowParams) error {
+	dc, err := obj.pendingDeviceCode(ctx, soCurrent.UserCode)
+	if err != nil {
+		return err
+	}
+	// the operation params are round tripped via the browser
+	if dc.ClientId != soCurrent.ClientId {
+		return errInvalidUserCode
+	}
+	err = dc.Approve(userId, authTime)
+	if err != nil {
+		return err
+	}
+	return obj.daoSource.GetDeviceCodeStore(ctx).SaveDeviceCode(ctx, dc)
+}
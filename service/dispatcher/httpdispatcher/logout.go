@@ -0,0 +1,209 @@
+package httpdispatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao/ddbutil"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+)
+
+// see https://openid.net/specs/openid-connect-rpinitiated-1_0.html#RPLogout
+type logoutRequest struct {
+	IdTokenHint           string
+	ClientId              string
+	PostLogoutRedirectUri string
+	State                 string
+}
+
+func logoutRequestFromForm(form url.Values) *logoutRequest {
+	return &logoutRequest{
+		IdTokenHint:           form.Get("id_token_hint"),
+		ClientId:              form.Get("client_id"),
+		PostLogoutRedirectUri: form.Get("post_logout_redirect_uri"),
+		State:                 form.Get("state"),
+	}
+}
+
+// identifies the client (from the client_id or id_token_hint), and checks that the
+// post logout redirect uri is registered for it. Returns the hinted id token, if any
+func (obj *acceptOidcHandler) validateLogoutRequest(ctx context.Context, logoutReq *logoutRequest) (*jwtutil.IdToken, error) {
+	var idTokenHint *jwtutil.IdToken
+	if logoutReq.IdTokenHint != "" {
+		// the hint is usually an expired id token, so only the signature and issuer are checked
+		claims := &jwtutil.IdToken{}
+		err := jwtutil.ParseSignedJwt(ctx, logoutReq.IdTokenHint, obj.daoSource.GetKeyStore(ctx), claims)
+		if err != nil || claims.Iss != obj.urlPrefix || len(claims.Aud) == 0 {
+			return nil, errors.New("invalid id_token_hint")
+		}
+		if logoutReq.ClientId == "" {
+			logoutReq.ClientId = claims.Aud[0]
+		} else if !slices.Contains(claims.Aud, logoutReq.ClientId) {
+			return nil, errors.New("client_id does not match the id_token_hint")
+		}
+		idTokenHint = claims
+	}
+
+	if logoutReq.PostLogoutRedirectUri != "" {
+		if logoutReq.ClientId == "" {
+			return nil, errors.New("client_id or id_token_hint is required with a post_logout_redirect_uri")
+		}
+		oidcClient, err := obj.daoSource.GetClientStore(ctx).GetClient(ctx, logoutReq.ClientId)
+		if err != nil {
+			return nil, err
+		}
+		if oidcClient == nil {
+			return nil, fmt.Errorf("unknown client %v", logoutReq.ClientId)
+		}
+		if !oidcClient.IsAllowedPostLogoutRedirectUri(logoutReq.PostLogoutRedirectUri) {
+			return nil, errors.New("post_logout_redirect_uri is not registered for the client")
+		}
+	}
+	return idTokenHint, nil
+}
+
+// GET asks the user to confirm (so that a third party can not silently log them out),
+// and the confirmation is POSTed back with the same parameters
+func (obj *acceptOidcHandler) logoutHandler() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		if req.Method != http.MethodGet && req.Method != http.MethodPost {
+			res.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		req.ParseForm()
+		logoutReq := logoutRequestFromForm(req.Form)
+
+		type logout_page_params struct {
+			Request *logoutRequest
+			Err     error
+		}
+
+		idTokenHint, err := obj.validateLogoutRequest(ctx, logoutReq)
+		if err != nil {
+			// never redirect to an unvalidated uri
+			obj.templateDispatcher.RespondWithTemplate("logout.html", http.StatusBadRequest, res, logout_page_params{
+				Err: err,
+			})
+			return
+		}
+
+		claims := obj.userClaims(req)
+		if claims != nil && req.Method == http.MethodGet {
+			obj.templateDispatcher.RespondWithTemplate("logout.html", http.StatusOK, res, logout_page_params{
+				Request: logoutReq,
+			})
+			return
+		}
+
+		sessionStore := obj.daoSource.GetSessionStore(ctx)
+		frontChannelLogoutUris := []string{}
+		if claims != nil {
+			// logging out of simple-oidc ends every session the user has, including with clients
+			var endedSessions []*session.Session
+			endedSessions, err = session.RevokeUserSessions(ctx, sessionStore, claims.Sub)
+			if err == nil {
+				obj.backChannelNotifier.SessionsEnded(ctx, endedSessions)
+				frontChannelLogoutUris, err = obj.frontChannelLogoutUris(ctx, claims.Sub, endedSessions)
+			}
+		} else if idTokenHint != nil && idTokenHint.Sid != "" {
+			// no simple-oidc login, so only end the client session the id token was issued for.
+			// that client initiated the logout, so is not notified
+			err = session.RevokeSession(ctx, sessionStore, idTokenHint.Sid, idTokenHint.Sub)
+		}
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// expire login cookies
+		loginCookie := &http.Cookie{
+			Name:     LoginJwtCookieName,
+			Value:    "",
+			MaxAge:   -1, // expire cookie
+			HttpOnly: true,
+			SameSite: http.SameSiteDefaultMode,
+		}
+		refreshCookie := &http.Cookie{
+			Name:     LoginRefreshTokenCookieName,
+			Value:    "",
+			MaxAge:   -1, // expire cookie
+			HttpOnly: true,
+			SameSite: http.SameSiteDefaultMode,
+		}
+		http.SetCookie(res, loginCookie)
+		http.SetCookie(res, refreshCookie)
+
+		location := ""
+		if logoutReq.PostLogoutRedirectUri != "" {
+			responseParams := url.Values{}
+			if logoutReq.State != "" {
+				responseParams.Add("state", logoutReq.State)
+			}
+			location, err = AuthorizationResponseRedirect(logoutReq.PostLogoutRedirectUri, responseParams, false)
+			if err != nil {
+				fmt.Printf("%v\n", err)
+				res.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			if len(frontChannelLogoutUris) == 0 {
+				res.Header().Add("Location", location)
+				res.WriteHeader(http.StatusFound)
+				return
+			}
+		}
+		// the logged out page loads the front channel logout iframes, THEN continues to the client
+		obj.templateDispatcher.RespondWithTemplate("logged_out.html", http.StatusOK, res, map[string]any{
+			"FrontChannelLogoutUris": frontChannelLogoutUris,
+			"PostLogoutRedirectUri":  location,
+		})
+	}
+}
+
+// the iframes to render for each client the user has authorized, that has a front channel logout uri
+// see https://openid.net/specs/openid-connect-frontchannel-1_0.html#RPLogout
+func (obj *acceptOidcHandler) frontChannelLogoutUris(ctx context.Context, userId string, endedSessions []*session.Session) ([]string, error) {
+	clientAuthorizations := &ddbutil.DepaginatedScroller[client.ClientAuthorization]{}
+	err := obj.daoSource.GetClientAuthorizationStore(ctx).ClientAuthorizationsByUser(ctx, userId, clientAuthorizations)
+	if err != nil {
+		return nil, err
+	}
+	frontChannelLogoutUris := []string{}
+	for _, clientAuthorization := range clientAuthorizations.Results {
+		oidcClient, err := obj.daoSource.GetClientStore(ctx).GetClient(ctx, clientAuthorization.ClientId)
+		if err != nil {
+			return nil, err
+		}
+		if oidcClient == nil || oidcClient.FrontchannelLogoutUri == "" {
+			continue
+		}
+		clientSessionIds := []string{}
+		for _, ses := range endedSessions {
+			if ses.ClientId == oidcClient.ClientId {
+				clientSessionIds = append(clientSessionIds, ses.SessionId)
+			}
+		}
+		if len(clientSessionIds) == 0 {
+			if oidcClient.FrontchannelLogoutSessionRequired {
+				// nothing to identify
+				continue
+			}
+			clientSessionIds = append(clientSessionIds, "")
+		}
+		for _, sessionId := range clientSessionIds {
+			frontChannelLogoutUri, err := oidcClient.FrontchannelLogoutUrl(obj.urlPrefix, sessionId)
+			if err != nil {
+				return nil, err
+			}
+			frontChannelLogoutUris = append(frontChannelLogoutUris, frontChannelLogoutUri)
+		}
+	}
+	return frontChannelLogoutUris, nil
+}
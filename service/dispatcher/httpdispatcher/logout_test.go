@@ -0,0 +1,162 @@
+package httpdispatcher
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/keys"
+	"github.com/kncept-oauth/simple-oidc/service/logout"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+	"github.com/kncept-oauth/simple-oidc/service/tokens"
+	"github.com/kncept-oauth/simple-oidc/service/users"
+)
+
+func TestRpInitiatedLogout(t *testing.T) {
+	ctx := t.Context()
+	issuer := "https://issuer.example.com"
+	daoSource := dao.NewMemoryDao()
+	keypair, err := keys.GenerateJwkKeypair()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = daoSource.GetKeyStore(ctx).SaveKey(ctx, keypair)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	user := &users.OidcUser{
+		Id: "username",
+	}
+	daoSource.GetUserStore(ctx).SaveUser(ctx, user)
+	oidcClient := &client.Client{
+		ClientId:                          uuid.NewString(),
+		PostLogoutRedirectUris:            []string{"https://client/logged-out"},
+		FrontchannelLogoutUri:             "https://client/frontchannel-logout",
+		FrontchannelLogoutSessionRequired: true,
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, oidcClient)
+	handler := &acceptOidcHandler{
+		daoSource:          daoSource,
+		urlPrefix:          issuer,
+		templateDispatcher: NewTemplateDispatcher(nil),
+		backChannelNotifier: &logout.BackChannelNotifier{
+			DaoSource: daoSource,
+			Issuer:    issuer,
+		},
+	}
+
+	clientSession := func() (*session.Session, string) {
+		ses, err := session.NewSession(user.Id, oidcClient.ClientId)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		issued, err := (&tokens.TokenService{
+			DaoSource: daoSource,
+			Issuer:    issuer,
+		}).IssueTokens(ctx, ses, oidcClient, tokens.IssueOptions{})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return ses, issued.IdToken
+	}
+	isActive := func(ses *session.Session) bool {
+		loaded, err := daoSource.GetSessionStore(ctx).LoadSession(ctx, ses.SessionId, ses.UserId)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return loaded.IsActive()
+	}
+	logout := func(method string, form url.Values, cookies []*http.Cookie) *http.Response {
+		var req *http.Request
+		if method == http.MethodPost {
+			req = httptest.NewRequest(method, "/logout", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else {
+			req = httptest.NewRequest(method, "/logout?"+form.Encode(), nil)
+		}
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		rec := httptest.NewRecorder()
+		handler.logoutHandler()(rec, req)
+		return rec.Result()
+	}
+
+	// unregistered redirect uris are never followed
+	ses, idToken := clientSession()
+	res := logout(http.MethodGet, url.Values{
+		"id_token_hint":            {idToken},
+		"post_logout_redirect_uri": {"https://attacker/"},
+	}, nil)
+	if res.StatusCode != http.StatusBadRequest || res.Header.Get("Location") != "" {
+		t.Fatalf("expected an error page, but got %v %v", res.StatusCode, res.Header.Get("Location"))
+	}
+	if !isActive(ses) {
+		t.Fatalf("an invalid logout request must not end the session")
+	}
+
+	// without a simple-oidc login, the hinted client session is ended
+	res = logout(http.MethodGet, url.Values{
+		"id_token_hint":            {idToken},
+		"post_logout_redirect_uri": {"https://client/logged-out"},
+		"state":                    {"xyz"},
+	}, nil)
+	if res.StatusCode != http.StatusFound || res.Header.Get("Location") != "https://client/logged-out?state=xyz" {
+		t.Fatalf("expected a redirect back to the client, but got %v %v", res.StatusCode, res.Header.Get("Location"))
+	}
+	if isActive(ses) {
+		t.Fatalf("the hinted session should be revoked")
+	}
+
+	// a logged in user must confirm, and then every session is ended
+	ses, idToken = clientSession()
+	daoSource.GetClientAuthorizationStore(ctx).SaveClientAuthorization(ctx, &client.ClientAuthorization{
+		ClientId: oidcClient.ClientId,
+		UserId:   user.Id,
+	})
+	loginRec := httptest.NewRecorder()
+	handler.createUserSession(ctx, loginRec, user)
+	loginCookies := loginRec.Result().Cookies()
+	form := url.Values{
+		"id_token_hint": {idToken},
+	}
+	res = logout(http.MethodGet, form, loginCookies)
+	if res.StatusCode != http.StatusOK || !isActive(ses) {
+		t.Fatalf("expected a logout confirmation page, but got %v", res.StatusCode)
+	}
+	res = logout(http.MethodPost, form, loginCookies)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected a logged out page, but got %v", res.StatusCode)
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	frontChannelLogoutUri := fmt.Sprintf("https://client/frontchannel-logout?iss=%v&amp;sid=%v", url.QueryEscape(issuer), ses.SessionId)
+	if !strings.Contains(string(body), frontChannelLogoutUri) {
+		t.Fatalf("expected a front channel logout iframe for %v:\n%s", frontChannelLogoutUri, body)
+	}
+	userSessions, err := daoSource.GetSessionStore(ctx).ListUserSessions(ctx, user.Id)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	for _, userSession := range userSessions {
+		if userSession.IsActive() {
+			t.Fatalf("session %v for client %v should be revoked", userSession.SessionId, userSession.ClientId)
+		}
+	}
+	expired := map[string]bool{}
+	for _, cookie := range res.Cookies() {
+		expired[cookie.Name] = cookie.MaxAge < 0
+	}
+	if !expired[LoginJwtCookieName] || !expired[LoginRefreshTokenCookieName] {
+		t.Fatalf("login cookies should be expired: %v", res.Cookies())
+	}
+}
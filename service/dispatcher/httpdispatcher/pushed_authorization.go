@@ -0,0 +1,40 @@
+package httpdispatcher
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kncept-oauth/simple-oidc/service/params"
+)
+
+var errInvalidRequestUri = errors.New("invalid or expired request_uri")
+
+// pushed authorization requests (RFC 9126) only round trip the request_uri via the browser,
+// the validated params are loaded from the store
+func (obj *acceptOidcHandler) resolvePushedAuthorizationRequest(ctx context.Context, soCurrent *params.OidcAuthCodeFlowParams) (*params.OidcAuthCodeFlowParams, error) {
+	if soCurrent.RequestUri == "" {
+		return soCurrent, nil
+	}
+	pushedRequest, err := obj.daoSource.GetPushedAuthorizationRequestStore(ctx).GetPushedAuthorizationRequest(ctx, soCurrent.RequestUri)
+	if err != nil {
+		return nil, err
+	}
+	// must have been started at the authorization endpoint
+	if pushedRequest == nil || pushedRequest.IsExpired() || !pushedRequest.IsStarted() || pushedRequest.ClientId != soCurrent.ClientId {
+		return nil, errInvalidRequestUri
+	}
+	resolved, err := params.OidcParamsFromQuery(pushedRequest.OidcParams)
+	if err != nil {
+		return nil, err
+	}
+	resolved.RequestUri = pushedRequest.RequestUri
+	return resolved, nil
+}
+
+// the request_uri can't be used again once the response has been issued
+func (obj *acceptOidcHandler) completePushedAuthorizationRequest(ctx context.Context, soCurrent *params.OidcAuthCodeFlowParams) error {
+	if soCurrent.RequestUri == "" {
+		return nil
+	}
+	return obj.daoSource.GetPushedAuthorizationRequestStore(ctx).DeletePushedAuthorizationRequest(ctx, soCurrent.RequestUri)
+}
@@ -1,31 +1,61 @@
 package oapidispatcher
 
 import (
+	"bytes"
 	"context"
-	"crypto/rsa"
-	"errors"
 	"fmt"
 	"log"
-	"regexp"
+	"net/url"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/kncept-oauth/simple-oidc/service/audit"
 	"github.com/kncept-oauth/simple-oidc/service/client"
 	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/dispatcher/httpdispatcher"
 	"github.com/kncept-oauth/simple-oidc/service/dispatcherauth"
 	"github.com/kncept-oauth/simple-oidc/service/gen/api"
 	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
-	"github.com/kncept-oauth/simple-oidc/service/keys"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
 	"github.com/kncept-oauth/simple-oidc/service/params"
 	"github.com/kncept-oauth/simple-oidc/service/session"
+	"github.com/kncept-oauth/simple-oidc/service/tokens"
 )
 
 type authorizationHandler struct {
 	DaoSource dao.DaoSource
 	Issuer    string
+	Templates *httpdispatcher.TemplateDispatcher // for form_post error responses
 }
 
 // TokenPost implements api.AuthorizationHandler.
 func (obj *authorizationHandler) TokenPost(ctx context.Context, req api.TokenPostReq) (api.TokenPostRes, error) {
+	res, err := obj.tokenPost(ctx, req)
+	if oauthErr, ok := oautherror.As(err); ok {
+		return obj.tokenErrorResponse(ctx, oauthErr)
+	}
+	return res, err
+}
+
+// see https://datatracker.ietf.org/doc/html/rfc6749#section-5.2
+func (obj *authorizationHandler) tokenErrorResponse(ctx context.Context, oauthErr *oautherror.OAuthError) (api.TokenPostRes, error) {
+	res := oauthErrorHeaders(ctx, oauthErr)
+	err := setDPoPNonce(ctx, &tokens.TokenService{
+		DaoSource: obj.DaoSource,
+		Issuer:    obj.Issuer,
+	}, res)
+	if err != nil {
+		return nil, err
+	}
+	if oauthErr.StatusCode() == 401 {
+		return (*api.TokenPostUnauthorized)(res), nil
+	}
+	return (*api.TokenPostBadRequest)(res), nil
+}
+
+// any *oautherror.OAuthError is returned to the client, anything else is a server error
+func (obj *authorizationHandler) tokenPost(ctx context.Context, req api.TokenPostReq) (api.TokenPostRes, error) {
 	var tokenRequestBody *api.TokenRequestBody
 
 	if jsonReq, ok := req.(*api.TokenPostApplicationJSON); ok {
@@ -35,101 +65,274 @@ func (obj *authorizationHandler) TokenPost(ctx context.Context, req api.TokenPos
 		tokenRequestBody = (*api.TokenRequestBody)(formReq)
 	}
 	if tokenRequestBody == nil {
-		return nil, errors.New("unable to parse request body")
+		return nil, oautherror.New(oautherror.InvalidRequest, "unable to parse request body")
+	}
+
+	credentials, err := clientCredentialsFromRequest(
+		ctx,
+		tokenRequestBody.ClientID.Or(""),
+		tokenRequestBody.ClientSecret.Or(""),
+		tokenRequestBody.ClientAssertionType.Or(""),
+		tokenRequestBody.ClientAssertion.Or(""),
+	)
+	if err != nil {
+		return nil, err
+	}
+	authenticatedClient, err := authenticateClient(ctx, obj.DaoSource, obj.Issuer, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenService := &tokens.TokenService{
+		DaoSource: obj.DaoSource,
+		Issuer:    obj.Issuer,
+	}
+	dpopJkt, err := tokenRequestDPoPJkt(ctx, tokenService)
+	if err != nil {
+		return nil, err
+	}
+	if dpopJkt == "" && authenticatedClient.DPoPBoundAccessTokens {
+		return nil, oautherror.New(oautherror.InvalidDPoPProof, "DPoP proof required for client: %v", authenticatedClient.ClientId)
 	}
 
 	grantType := strings.ToLower(tokenRequestBody.GrantType.Or(""))
+	if slices.Contains(client.SupportedGrantTypes, grantType) && !authenticatedClient.AllowsGrantType(grantType) {
+		return nil, oautherror.New(oautherror.UnauthorizedClient, "grant type not allowed for client: %v", grantType)
+	}
 	grantPayload := ""
 	// validate requried sets
 	switch grantType {
-	case "authorization_code":
+	case client.GrantTypeAuthorizationCode:
 		// code must be set
 		grantPayload = tokenRequestBody.Code.Or("")
 		if grantPayload == "" {
-			return nil, fmt.Errorf("query parameter \"code\" not set")
+			return nil, oautherror.New(oautherror.InvalidRequest, "parameter \"code\" not set")
 		}
-	case "refresh_token":
+	case client.GrantTypeRefreshToken:
 		grantPayload = tokenRequestBody.RefreshToken.Or("")
 		if grantPayload == "" {
-			return nil, fmt.Errorf("query parameter \"code\" not set")
+			return nil, oautherror.New(oautherror.InvalidRequest, "parameter \"refresh_token\" not set")
+		}
+	case client.GrantTypeDeviceCode:
+		grantPayload = tokenRequestBody.DeviceCode.Or("")
+		if grantPayload == "" {
+			return nil, oautherror.New(oautherror.InvalidRequest, "parameter \"device_code\" not set")
 		}
+	case client.GrantTypeClientCredentials:
+		// no user, so no session
+		return obj.clientCredentialsGrant(ctx, tokenRequestBody, authenticatedClient, dpopJkt)
+	case client.GrantTypeTokenExchange:
+		// no new session, the exchanged token belongs to the subject token's session (if any)
+		return obj.tokenExchangeGrant(ctx, tokenRequestBody, authenticatedClient, dpopJkt)
+	default:
+		return nil, oautherror.New(oautherror.UnsupportedGrantType, "unknown grant type: %s", grantType)
 	}
 
-	ses, err := obj.mapToSession(ctx, grantType, grantPayload)
+	ses, err := obj.mapToSession(ctx, grantType, grantPayload, tokenRequestBody, authenticatedClient)
 	if err != nil {
 		return nil, err
 	}
 	if ses == nil {
-		return nil, fmt.Errorf("Session Not Found")
+		return nil, oautherror.New(oautherror.InvalidGrant, "session not found")
+	}
+	// tokens are only ever issued to the client the session was created for
+	if ses.ClientId != authenticatedClient.ClientId {
+		return nil, oautherror.New(oautherror.InvalidGrant, "client_id mismatch")
+	}
+	// once bound, every token issued for the session (including on refresh) is bound to the same key
+	if ses.DPoPJkt != "" && ses.DPoPJkt != dpopJkt {
+		return nil, oautherror.New(oautherror.InvalidDPoPProof, "session is bound to a different DPoP key")
 	}
+	ses.DPoPJkt = dpopJkt
 
-	keyPair, err := keys.GetCurrentKey(ctx, obj.DaoSource.GetKeyStore(ctx))
+	issued, err := tokenService.IssueTokens(ctx, ses, authenticatedClient, tokens.IssueOptions{
+		AccessToken:  true,
+		RefreshToken: authenticatedClient.AllowsGrantType(client.GrantTypeRefreshToken),
+	})
 	if err != nil {
 		return nil, err
 	}
+	return tokenResponse(issued), nil
+}
 
-	decodedKey, err := keyPair.DecodePrivateKey()
+// see https://datatracker.ietf.org/doc/html/rfc6749#section-4.4
+func (obj *authorizationHandler) clientCredentialsGrant(ctx context.Context, tokenRequestBody *api.TokenRequestBody, authenticatedClient *client.Client, dpopJkt string) (api.TokenPostRes, error) {
+	if !authenticatedClient.AllowsClientCredentials() {
+		return nil, oautherror.New(oautherror.UnauthorizedClient, "client_credentials not allowed for client")
+	}
+	grantedScope, err := authenticatedClient.GrantMachineScopes(tokenRequestBody.Scope.Or(""))
 	if err != nil {
 		return nil, err
 	}
-	rsaKey, isRsaKey := decodedKey.(*rsa.PrivateKey)
-	if !isRsaKey {
-		return nil, errors.New("not an rsa key")
+	issued, err := (&tokens.TokenService{
+		DaoSource: obj.DaoSource,
+		Issuer:    obj.Issuer,
+	}).IssueClientCredentialsToken(ctx, authenticatedClient, grantedScope, dpopJkt)
+	if err != nil {
+		return nil, err
 	}
+	return tokenResponse(issued), nil
+}
 
-	idToken, refreshToken := ses.IssueTokens(obj.Issuer, ses.ClientId)
-	sessionStore := obj.DaoSource.GetSessionStore(ctx)
-	err = sessionStore.SaveSession(ctx, ses)
+// see https://datatracker.ietf.org/doc/html/rfc8693#section-2
+func (obj *authorizationHandler) tokenExchangeGrant(ctx context.Context, tokenRequestBody *api.TokenRequestBody, authenticatedClient *client.Client, dpopJkt string) (api.TokenPostRes, error) {
+	exchangeRequest := &tokens.TokenExchangeRequest{
+		SubjectToken:       tokenRequestBody.SubjectToken.Or(""),
+		SubjectTokenType:   tokenRequestBody.SubjectTokenType.Or(""),
+		ActorToken:         tokenRequestBody.ActorToken.Or(""),
+		ActorTokenType:     tokenRequestBody.ActorTokenType.Or(""),
+		RequestedTokenType: tokenRequestBody.RequestedTokenType.Or(""),
+		Audience:           tokenRequestBody.Audience.Or(""),
+		Scope:              tokenRequestBody.Scope.Or(""),
+		DPoPJkt:            dpopJkt,
+	}
+	issued, err := (&tokens.TokenService{
+		DaoSource: obj.DaoSource,
+		Issuer:    obj.Issuer,
+	}).ExchangeToken(ctx, authenticatedClient, exchangeRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	idTokenJwt, err := jwtutil.ClaimsToJwt(idToken, keyPair.Kid, rsaKey)
+	// every exchange is audited, as the issued token may act on behalf of someone else
+	eventType := audit.EventTypeTokenExchange
+	if exchangeRequest.SubjectTokenType == jwtutil.TokenTypeJwt {
+		eventType = audit.EventTypeImpersonation
+	}
+	event, err := audit.NewEvent(eventType)
 	if err != nil {
 		return nil, err
 	}
-
-	refreshTokenJwt, err := jwtutil.ClaimsToJwt(refreshToken, keyPair.Kid, rsaKey)
+	claims := issued.AccessTokenClaims
+	event.ClientId = authenticatedClient.ClientId
+	event.UserId = claims.Sub
+	event.SessionId = claims.Sid
+	event.Details = fmt.Sprintf("audience=%v", strings.Join(claims.Aud, " "))
+	if claims.Act != nil {
+		event.Details = fmt.Sprintf("%v actor=%v", event.Details, claims.Act.Sub)
+	}
+	err = audit.RecordEvent(ctx, obj.DaoSource.GetEventStore(ctx), event)
 	if err != nil {
 		return nil, err
 	}
+
+	res := tokenResponse(issued)
+	res.Response.IssuedTokenType.SetTo(jwtutil.TokenTypeAccessToken)
+	return res, nil
+}
+
+// see https://datatracker.ietf.org/doc/html/rfc6749#section-5.1
+func tokenResponse(issued *tokens.IssuedTokens) *api.LoginTokensHeaders {
+	loginTokens := api.LoginTokens{
+		AccessToken: issued.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   float64(issued.ExpiresIn()),
+	}
+	if issued.AccessTokenClaims.BoundJkt() != "" {
+		// see https://datatracker.ietf.org/doc/html/rfc9449#section-5
+		loginTokens.TokenType = jwtutil.DPoPTokenType
+	}
+	setOptString(&loginTokens.IDToken, issued.IdToken)
+	setOptString(&loginTokens.RefreshToken, issued.RefreshToken)
+	setOptString(&loginTokens.Scope, issued.AccessTokenClaims.Scope)
 	return &api.LoginTokensHeaders{
 		AccessControlAllowOrigin: api.NewOptString("*"),
-		Response: api.LoginTokens{
-			IDToken:      idTokenJwt,
-			AccessToken:  idTokenJwt,
-			RefreshToken: refreshTokenJwt,
-		},
-	}, nil
+		Response:                 loginTokens,
+	}
+}
 
+// with the hybrid flow, the session was created (and an id token issued) when the code was,
+// so the tokens issued for the code belong to the same session
+func (obj *authorizationHandler) sessionForAuthorizationCode(ctx context.Context, authCode *client.AuthorizationCode) (*session.Session, error) {
+	if authCode.SessionId != "" {
+		ses, err := obj.DaoSource.GetSessionStore(ctx).LoadSession(ctx, authCode.SessionId, authCode.UserId)
+		if err != nil {
+			return nil, err
+		}
+		if ses == nil || !ses.IsActive() || ses.ClientId != authCode.ClientId {
+			return nil, oautherror.New(oautherror.InvalidGrant, "session for authorization code is not active")
+		}
+		return ses, nil
+	}
+	ses, err := session.NewSession(authCode.UserId, authCode.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	ses.Nonce = authCode.Nonce
+	ses.Scope = authCode.Scope
+	if !authCode.AuthTime.IsZero() {
+		ses.AuthTime = authCode.AuthTime
+	}
+	return ses, nil
 }
 
-func (obj *authorizationHandler) mapToSession(ctx context.Context, grantType string, grantPayload string) (*session.Session, error) {
+func (obj *authorizationHandler) mapToSession(ctx context.Context, grantType string, grantPayload string, tokenRequestBody *api.TokenRequestBody, authenticatedClient *client.Client) (*session.Session, error) {
 	switch grantType {
-	case "authorization_code":
-		authCode, err := obj.DaoSource.GetAuthorizationCodeStore(ctx).GetAuthorizationCode(ctx, grantPayload)
+	case client.GrantTypeAuthorizationCode:
+		authCodeStore := obj.DaoSource.GetAuthorizationCodeStore(ctx)
+		authCode, err := authCodeStore.GetAuthorizationCode(ctx, grantPayload)
 		if err != nil {
 			return nil, err
 		}
+		if authCode == nil {
+			return nil, oautherror.New(oautherror.InvalidGrant, "invalid authorization code")
+		}
+		if authCode.IsRedeemed() {
+			return nil, obj.authorizationCodeReplayed(ctx, authCode)
+		}
+		if authCode.IsExpired() {
+			err = authCodeStore.DeleteAuthorizationCode(ctx, authCode.Code)
+			if err != nil {
+				return nil, err
+			}
+			return nil, oautherror.New(oautherror.InvalidGrant, "authorization code expired")
+		}
+		err = authCode.VerifyRedemption(authenticatedClient.ClientId, tokenRequestBody.RedirectURI.Or(""))
+		if err != nil {
+			return nil, oautherror.Wrap(oautherror.InvalidGrant, err)
+		}
+		// a code issued without a challenge (e.g. via a crafted /accept) can't be redeemed by a client that requires PKCE
+		if authenticatedClient.RequirePkce && authCode.CodeChallenge == "" {
+			return nil, oautherror.New(oautherror.InvalidGrant, "code_challenge required for client: %v", authenticatedClient.ClientId)
+		}
+		err = authCode.VerifyCodeVerifier(tokenRequestBody.CodeVerifier.Or(""))
+		if err != nil {
+			return nil, oautherror.Wrap(oautherror.InvalidGrant, err)
+		}
 
-		// obj.DaoSource.GetAuthorizationCodeStore().DeleteAuthorizationCode(ctx, tokenRequestBody.Code)
-		acParams, err := params.OidcParamsFromQuery(authCode.OidcParams)
+		ses, err := obj.sessionForAuthorizationCode(ctx, authCode)
 		if err != nil {
 			return nil, err
 		}
-		userId := authCode.UserId
-		clientId := acParams.ClientId
-
-		ses, err := session.NewSession(userId, clientId)
+		// the code is kept (marked as redeemed) until it expires, to detect replays.
+		// concurrent redemptions race to mark the code, and the losers are replays
+		redeemed, err := authCodeStore.RedeemAuthorizationCode(ctx, authCode.Code, ses.SessionId)
 		if err != nil {
 			return nil, err
 		}
+		if !redeemed {
+			authCode, err = authCodeStore.GetAuthorizationCode(ctx, grantPayload)
+			if err != nil {
+				return nil, err
+			}
+			if authCode == nil {
+				return nil, oautherror.New(oautherror.InvalidGrant, "invalid authorization code")
+			}
+			return nil, obj.authorizationCodeReplayed(ctx, authCode)
+		}
 		return ses, nil
-	case "refresh_token":
+	case client.GrantTypeRefreshToken:
+		// anything other than a refresh token is invalid, and must never count as refresh token reuse
+		if !jwtutil.JwtHasType(grantPayload, jwtutil.RefreshTokenType) {
+			return nil, oautherror.New(oautherror.InvalidGrant, "not a refresh token")
+		}
 		refreshClaims := &jwtutil.RefreshClaimsJwt{}
 		err := jwtutil.ParseJwt(ctx, grantPayload, obj.DaoSource.GetKeyStore(ctx), obj.Issuer, refreshClaims)
 		if err != nil {
-			return nil, err
+			return nil, oautherror.Wrap(oautherror.InvalidGrant, err)
+		}
+		if refreshClaims.Ses == "" || refreshClaims.Code == "" {
+			return nil, oautherror.New(oautherror.InvalidGrant, "not a refresh token")
 		}
 
 		userId := refreshClaims.Sub
@@ -143,36 +346,178 @@ func (obj *authorizationHandler) mapToSession(ctx context.Context, grantType str
 		if ses == nil {
 			return nil, nil
 		}
+		// revoked or logged out sessions can not be refreshed
+		if !ses.IsActive() {
+			return nil, oautherror.New(oautherror.InvalidGrant, "session revoked")
+		}
+		if ses.ClientId != authenticatedClient.ClientId {
+			return nil, oautherror.New(oautherror.InvalidGrant, "client_id mismatch")
+		}
 
 		if ses.RefreshCode != refreshClaims.Code {
-			fmt.Printf("Refresh Code Mismatch:\nses %v\njwt %v\n", ses.RefreshCode, refreshClaims.Code)
-			return nil, fmt.Errorf("refresh code mismatch")
+			// a rotated refresh token has been presented, so either the legitimate client
+			// or an attacker holds a stolen token. The whole session is suspect
+			// see https://datatracker.ietf.org/doc/html/rfc9700#section-4.14.2
+			err = obj.recordSecurityEvent(ctx, audit.EventTypeRefreshTokenReuse, ses.ClientId, ses.UserId, ses.SessionId)
+			if err != nil {
+				return nil, err
+			}
+			if authenticatedClient.RevokeOnRefreshTokenReuse() {
+				ses.Revoke()
+				err = obj.DaoSource.GetSessionStore(ctx).SaveSession(ctx, ses)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return nil, oautherror.New(oautherror.InvalidGrant, "refresh token reuse detected")
 		}
+		ses.ForgetNonce()
 		return ses, nil
+	case client.GrantTypeDeviceCode:
+		return obj.sessionForDeviceCode(ctx, grantPayload, authenticatedClient)
 	}
-	return nil, fmt.Errorf("Unknown grant type: %s", grantType)
+	return nil, oautherror.New(oautherror.UnsupportedGrantType, "unknown grant type: %s", grantType)
 }
 
-func (obj authorizationHandler) AuthorizeGet(ctx context.Context, params api.AuthorizeGetParams) (api.AuthorizeGetRes, error) {
-	client, err := obj.DaoSource.GetClientStore(ctx).GetClient(ctx, params.ClientID)
+// see https://datatracker.ietf.org/doc/html/rfc6749#section-4.1.2
+// the code has been replayed, so revoke the tokens previously issued for it
+func (obj *authorizationHandler) authorizationCodeReplayed(ctx context.Context, authCode *client.AuthorizationCode) error {
+	err := session.RevokeSession(ctx, obj.DaoSource.GetSessionStore(ctx), authCode.SessionId, authCode.UserId)
+	if err != nil {
+		return err
+	}
+	err = obj.recordSecurityEvent(ctx, audit.EventTypeAuthorizationCodeReuse, authCode.ClientId, authCode.UserId, authCode.SessionId)
+	if err != nil {
+		return err
+	}
+	err = obj.DaoSource.GetAuthorizationCodeStore(ctx).DeleteAuthorizationCode(ctx, authCode.Code)
+	if err != nil {
+		return err
+	}
+	return oautherror.New(oautherror.InvalidGrant, "authorization code already redeemed")
+}
+
+// the device polls until the user has approved the request on the verification page
+// see https://datatracker.ietf.org/doc/html/rfc8628#section-3.4
+func (obj *authorizationHandler) sessionForDeviceCode(ctx context.Context, deviceCode string, authenticatedClient *client.Client) (*session.Session, error) {
+	deviceCodeStore := obj.DaoSource.GetDeviceCodeStore(ctx)
+	dc, err := deviceCodeStore.GetDeviceCode(ctx, client.UserCodeFromDeviceCode(deviceCode))
+	if err != nil {
+		return nil, err
+	}
+	if dc == nil || !dc.DeviceCodeMatches(deviceCode) {
+		return nil, oautherror.New(oautherror.InvalidGrant, "invalid device code")
+	}
+	if dc.ClientId != authenticatedClient.ClientId {
+		return nil, oautherror.New(oautherror.InvalidGrant, "client_id mismatch")
+	}
+	if dc.IsRedeemed() {
+		return nil, oautherror.New(oautherror.InvalidGrant, "device code already redeemed")
+	}
+	if dc.IsExpired() {
+		err = deviceCodeStore.DeleteDeviceCode(ctx, dc.UserCode)
+		if err != nil {
+			return nil, err
+		}
+		return nil, oautherror.New(oautherror.ExpiredToken, "device code expired")
+	}
+	if !dc.IsApproved() {
+		slowDown := !dc.Poll(time.Now().UTC())
+		err = deviceCodeStore.SaveDeviceCode(ctx, dc)
+		if err != nil {
+			return nil, err
+		}
+		if slowDown {
+			return nil, oautherror.New(oautherror.SlowDown, "polling interval is now %v seconds", int64(dc.Interval.Seconds()))
+		}
+		return nil, oautherror.New(oautherror.AuthorizationPending, "")
+	}
+
+	ses, err := session.NewSession(dc.UserId, dc.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	ses.Scope = dc.Scope
+	ses.AuthTime = dc.AuthTime
+	dc.Redeem(ses.SessionId)
+	err = deviceCodeStore.SaveDeviceCode(ctx, dc)
+	if err != nil {
+		return nil, err
+	}
+	return ses, nil
+}
+
+func (obj *authorizationHandler) recordSecurityEvent(ctx context.Context, eventType string, clientId string, userId string, sessionId string) error {
+	event, err := audit.NewEvent(eventType)
+	if err != nil {
+		return err
+	}
+	event.ClientId = clientId
+	event.UserId = userId
+	event.SessionId = sessionId
+	return audit.RecordEvent(ctx, obj.DaoSource.GetEventStore(ctx), event)
+}
+
+func (obj authorizationHandler) AuthorizeGet(ctx context.Context, req api.AuthorizeGetParams) (api.AuthorizeGetRes, error) {
+	oidcClient, err := obj.DaoSource.GetClientStore(ctx).GetClient(ctx, req.ClientID)
 	if err != nil {
 		return nil, err
 	}
-	if client == nil {
-		return nil, fmt.Errorf("no such client: %v", params.ClientID)
+	if oidcClient == nil {
+		return nil, oautherror.New(oautherror.InvalidRequest, "no such client: %v", req.ClientID)
+	}
+	if strings.HasPrefix(req.RequestURI.Or(""), client.RequestUriPrefix) {
+		return obj.authorizePushedRequest(ctx, oidcClient, req.RequestURI.Value)
 	}
 
-	// TODO: what to do with this
-	// params.ResponseType // id_token token     or     code
+	authRequest := &params.OidcAuthCodeFlowParams{
+		ResponseType:        req.ResponseType.Or(""),
+		ClientId:            req.ClientID,
+		Scope:               req.Scope.Or(""),
+		RedirectUri:         req.RedirectURI.Or(""),
+		State:               req.State.Or(""),
+		ResponseMode:        req.ResponseMode.Or(""),
+		Nonce:               req.Nonce.Or(""),
+		CodeChallenge:       req.CodeChallenge.Or(""),
+		CodeChallengeMethod: req.CodeChallengeMethod.Or(""),
+	}
 
-	// validate allowed scopes
-	// allowedScopes := client.GetAllowedScopes()
-	// if allowedScopes != nil && len(allowedScopes) != 0 {
-	// }
+	// request objects, by value or by reference (RFC 9101)
+	requestObject := req.Request.Or("")
+	if req.RequestURI.Set {
+		if requestObject != "" {
+			return nil, oautherror.New(oautherror.InvalidRequest, "request and request_uri must not both be set")
+		}
+		requestObject, err = fetchRequestObject(ctx, oidcClient, req.RequestURI.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if requestObject != "" {
+		err = applyRequestObject(ctx, obj.DaoSource, oidcClient, obj.Issuer, authRequest, requestObject)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// until the redirect uri is validated, errors must NOT be redirected to it
+	// see https://datatracker.ietf.org/doc/html/rfc6749#section-4.1.2.1
+	if !oidcClient.IsValidRedirectUri(authRequest.RedirectUri) {
+		return nil, oautherror.New(oautherror.InvalidRequest, "invalid redirect uri: %v", authRequest.RedirectUri)
+	}
 
-	redirectUri := params.RedirectURI
-	if !isValidRedirectUri(client, redirectUri) {
-		return nil, fmt.Errorf("invalid redirect uri: %v", redirectUri)
+	err = oidcClient.ValidateAuthorizeRequest(authRequest)
+	if err == nil && oidcClient.RequirePushedAuthorizationRequests {
+		err = oautherror.New(oautherror.InvalidRequest, "pushed authorization request required for client: %v", oidcClient.ClientId)
+	}
+	if err == nil && oidcClient.RequireSignedRequestObject && requestObject == "" {
+		err = oautherror.New(oautherror.InvalidRequest, "signed request object required for client: %v", oidcClient.ClientId)
+	}
+	if oauthErr, ok := oautherror.As(err); ok {
+		return obj.authorizeErrorResponse(oauthErr, authRequest)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	// fetch simple-oidc(soidc) state cookie
@@ -182,50 +527,88 @@ func (obj authorizationHandler) AuthorizeGet(ctx context.Context, params api.Aut
 		// handle logged in (must click to approve access)
 	}
 
-	// redirect to /accept endpoint - no zero click logins are allowed
-	redirectLocation := fmt.Sprintf(
-		"/accept?response_type=%s&client_id=%s&scope=%s&redirect_uri=%s",
-		params.ResponseType,
-		params.ClientID,
-		params.Scope,
-		params.RedirectURI,
-	)
-	if params.State.Set {
-		redirectLocation = fmt.Sprintf("%s&state=%s", redirectLocation, params.State.Value)
-	}
-	if params.Nonce.Set {
-		redirectLocation = fmt.Sprintf("%s&nonce=%s", redirectLocation, params.Nonce.Value)
+	// the signed params are kept server side (as if they were pushed), so they can't be edited in the browser
+	if requestObject != "" {
+		pushedRequest, err := client.NewPushedAuthorizationRequest(oidcClient.ClientId, authRequest.ToQueryParams())
+		if err != nil {
+			return nil, err
+		}
+		pushedRequest.Start()
+		err = obj.DaoSource.GetPushedAuthorizationRequestStore(ctx).SavePushedAuthorizationRequest(ctx, pushedRequest)
+		if err != nil {
+			return nil, err
+		}
+		return acceptPushedRequest(pushedRequest), nil
 	}
+
+	// redirect to /accept endpoint - no zero click logins are allowed
 	return &api.AuthorizeGetFound{
-		Location: redirectLocation,
+		Location: "/accept?" + authRequest.ToQueryParams(),
 	}, nil
 
 	// redirect to a login/auth page
 }
 
-func isValidRedirectUri(client *client.Client, redirectUri string) bool {
-	if client.AllowRegexForRedirectUri {
-		// TOOD: Consider caching?
-		for _, allowedRedirectUri := range client.AllowedRedirectUris {
-			// regex match https://pkg.go.dev/regexp
-			r, err := regexp.Compile(allowedRedirectUri)
-			if err != nil {
-				log.Printf("%v\n", err)
-				continue
-			}
-			if r.Match([]byte(redirectUri)) {
-				return true
-			}
-		}
-		return false
+// the parameters were validated when they were pushed, so the browser only gets the request_uri
+// see https://datatracker.ietf.org/doc/html/rfc9126#section-4
+func (obj authorizationHandler) authorizePushedRequest(ctx context.Context, oidcClient *client.Client, requestUri string) (api.AuthorizeGetRes, error) {
+	pushedRequestStore := obj.DaoSource.GetPushedAuthorizationRequestStore(ctx)
+	pushedRequest, err := pushedRequestStore.GetPushedAuthorizationRequest(ctx, requestUri)
+	if err != nil {
+		return nil, err
+	}
+	if pushedRequest == nil || pushedRequest.IsExpired() || pushedRequest.ClientId != oidcClient.ClientId {
+		return nil, oautherror.New(oautherror.InvalidRequestUri, "invalid or expired request_uri")
+	}
+	// one time use
+	if pushedRequest.IsStarted() {
+		return nil, oautherror.New(oautherror.InvalidRequestUri, "request_uri has already been used")
+	}
+	pushedRequest.Start()
+	err = pushedRequestStore.SavePushedAuthorizationRequest(ctx, pushedRequest)
+	if err != nil {
+		return nil, err
 	}
+	return acceptPushedRequest(pushedRequest), nil
+}
 
-	// else this is just a 'starts with'... MUCH simpler
-	for _, allowedRedirectUri := range client.AllowedRedirectUris {
-		if strings.HasPrefix(redirectUri, allowedRedirectUri) {
-			return true
-		}
+// the browser only gets the request_uri, the params are loaded from the store
+func acceptPushedRequest(pushedRequest *client.PushedAuthorizationRequest) *api.AuthorizeGetFound {
+	return &api.AuthorizeGetFound{
+		Location: "/accept?" + url.Values{
+			"client_id":   {pushedRequest.ClientId},
+			"request_uri": {pushedRequest.RequestUri},
+		}.Encode(),
 	}
+}
 
-	return false
+// returns the error to the client, using the requested response mode (if it is valid)
+func (obj authorizationHandler) authorizeErrorResponse(oauthErr *oautherror.OAuthError, authRequest *params.OidcAuthCodeFlowParams) (api.AuthorizeGetRes, error) {
+	log.Printf("authorize request failed: %v\n", oauthErr)
+	redirectUri := authRequest.RedirectUri
+	responseType := client.NormalizeResponseType(authRequest.ResponseType)
+	responseParams := oauthErr.ResponseParams(authRequest.State)
+	responseMode := authRequest.ResponseMode
+	if client.ValidateResponseMode(responseType, responseMode) != nil {
+		responseMode = ""
+	}
+	responseMode = client.EffectiveResponseMode(responseType, responseMode)
+
+	if responseMode == client.ResponseModeFormPost && obj.Templates != nil {
+		page := &bytes.Buffer{}
+		err := obj.Templates.RenderFormPost(page, redirectUri, responseParams)
+		if err != nil {
+			return nil, err
+		}
+		return &api.AuthorizeGetOK{
+			Data: page,
+		}, nil
+	}
+	location, err := httpdispatcher.AuthorizationResponseRedirect(redirectUri, responseParams, responseMode == client.ResponseModeFragment)
+	if err != nil {
+		return nil, err
+	}
+	return &api.AuthorizeGetFound{
+		Location: location,
+	}, nil
 }
@@ -1,92 +1,414 @@
 package oapidispatcher
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
 
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/audit"
 	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao"
 	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/session"
 )
 
 var _ api.AuthorizationHandler = (*authorizationHandler)(nil)
 
-func TestRegexRedirectUriValidity(t *testing.T) {
-	client := &client.Client{
-		AllowedRedirectUris: []string{
-			"http://valid/$",
-			"http://wildcard/.*$",
-			"https://path/with/uri$",
-			"https://path/with/slash/",
-			"https://path/with/wildslah/*",
-		},
-		AllowRegexForRedirectUri: true,
-	}
-	validStrings := []string{
-		"http://valid/",
-		"http://wildcard/",
-		"http://wildcard/123",
-		"http://wildcard/123/xyz",
-		"https://path/with/uri",
-		"https://path/with/slash/",
-		"https://path/with/wildslah/*",
-	}
-	invalidStrings := []string{
-		"http://valid",
-		"http://valid/nope",
-		"https://valid/",
-		"http://wildcard",
-		"https://path/with/uri/",
-		"https://path/with/slash",
-	}
-
-	for _, validString := range validStrings {
-		valid := isValidRedirectUri(client, validString)
-		if !valid {
-			t.Errorf("Incorrectly Invalid: %v", validString)
-		}
+func TestAuthorizationCodeReplayRevokesSession(t *testing.T) {
+	ctx := t.Context()
+	daoSource := dao.NewMemoryDao()
+	handler := &authorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	oidcClient := &client.Client{
+		ClientId: uuid.NewString(),
 	}
+	redirectUri := "https://client/callback"
 
-	for _, invalidString := range invalidStrings {
-		valid := isValidRedirectUri(client, invalidString)
-		if valid {
-			t.Errorf("Incorrectly Valid: %v", invalidString)
-		}
+	authCode, err := client.NewAuthorizationCode(uuid.NewString(), oidcClient.ClientId, redirectUri, "")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = daoSource.GetAuthorizationCodeStore(ctx).SaveAuthorizationCode(ctx, authCode)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	tokenRequestBody := &api.TokenRequestBody{
+		RedirectURI: api.NewOptString(redirectUri),
+	}
+
+	otherClient := &client.Client{
+		ClientId: uuid.NewString(),
+	}
+	if _, err = handler.mapToSession(ctx, "authorization_code", authCode.Code, tokenRequestBody, otherClient); err == nil {
+		t.Fatalf("authorization code must only be redeemed by the client it was issued to")
+	}
+
+	ses, err := handler.mapToSession(ctx, "authorization_code", authCode.Code, tokenRequestBody, oidcClient)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = daoSource.GetSessionStore(ctx).SaveSession(ctx, ses)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if _, err = handler.mapToSession(ctx, "authorization_code", authCode.Code, tokenRequestBody, oidcClient); err == nil {
+		t.Fatalf("authorization code must be single use")
+	}
+	ses, err = daoSource.GetSessionStore(ctx).LoadSession(ctx, ses.SessionId, ses.UserId)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if ses.IsActive() {
+		t.Fatalf("replaying the authorization code should have revoked the session")
+	}
+}
+
+func TestConcurrentAuthorizationCodeRedemption(t *testing.T) {
+	ctx := t.Context()
+	daoSource := dao.NewMemoryDao()
+	handler := &authorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	oidcClient := &client.Client{
+		ClientId: uuid.NewString(),
+	}
+	redirectUri := "https://client/callback"
+
+	authCode, err := client.NewAuthorizationCode(uuid.NewString(), oidcClient.ClientId, redirectUri, "")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = daoSource.GetAuthorizationCodeStore(ctx).SaveAuthorizationCode(ctx, authCode)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	var redeemedCount atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := handler.mapToSession(ctx, "authorization_code", authCode.Code, &api.TokenRequestBody{
+				RedirectURI: api.NewOptString(redirectUri),
+			}, oidcClient)
+			if err == nil {
+				redeemedCount.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if redeemedCount.Load() != 1 {
+		t.Fatalf("expected the code to be redeemed once but was redeemed %v times", redeemedCount.Load())
+	}
+}
+
+func TestRequirePkceRejectsCodeWithoutChallenge(t *testing.T) {
+	ctx := t.Context()
+	daoSource := dao.NewMemoryDao()
+	handler := &authorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	oidcClient := &client.Client{
+		ClientId:    uuid.NewString(),
+		RequirePkce: true,
+	}
+	redirectUri := "https://client/callback"
+
+	authCode, err := client.NewAuthorizationCode(uuid.NewString(), oidcClient.ClientId, redirectUri, "")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = daoSource.GetAuthorizationCodeStore(ctx).SaveAuthorizationCode(ctx, authCode)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, err = handler.mapToSession(ctx, "authorization_code", authCode.Code, &api.TokenRequestBody{
+		RedirectURI: api.NewOptString(redirectUri),
+	}, oidcClient)
+	if oauthErr, ok := oautherror.As(err); !ok || oauthErr.Code != oautherror.InvalidGrant {
+		t.Fatalf("expected invalid_grant but got %v", err)
 	}
 }
 
-func TestPrefixRedirectUriValidity(t *testing.T) {
-	client := &client.Client{
-		AllowedRedirectUris: []string{
-			"http://valid/",
-			"http://noslash",
-			"https://params?",
-		},
-		AllowRegexForRedirectUri: false,
-	}
-
-	validStrings := []string{
-		"http://valid/",
-		"http://valid/yes",
-		"https://params?",
-		"https://params?a=b",
-	}
-	invalidStrings := []string{
-		"http://valid",
-		"https://valid/",
-		"http://params?",
-		"https://params#?a=b",
-	}
-
-	for _, validString := range validStrings {
-		valid := isValidRedirectUri(client, validString)
-		if !valid {
-			t.Errorf("Incorrectly Invalid: %v", validString)
+func TestHybridAuthorizationCodeRedeemsIntoSession(t *testing.T) {
+	ctx := t.Context()
+	daoSource := dao.NewMemoryDao()
+	handler := &authorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	oidcClient := &client.Client{
+		ClientId: uuid.NewString(),
+	}
+	redirectUri := "https://client/callback"
+	userId := uuid.NewString()
+
+	// the front channel id token has already been issued for this session
+	frontChannelSession, err := session.NewSession(userId, oidcClient.ClientId)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	frontChannelSession.Nonce = "n-0S6_WzA2Mj"
+	frontChannelSession.IssueTokens(testIssuer, oidcClient.ClientId)
+	err = daoSource.GetSessionStore(ctx).SaveSession(ctx, frontChannelSession)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	authCode, err := client.NewAuthorizationCode(userId, oidcClient.ClientId, redirectUri, "")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	authCode.SessionId = frontChannelSession.SessionId
+	err = daoSource.GetAuthorizationCodeStore(ctx).SaveAuthorizationCode(ctx, authCode)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	ses, err := handler.mapToSession(ctx, "authorization_code", authCode.Code, &api.TokenRequestBody{
+		RedirectURI: api.NewOptString(redirectUri),
+	}, oidcClient)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if ses.SessionId != frontChannelSession.SessionId {
+		t.Fatalf("hybrid authorization code should be redeemed into the front channel session")
+	}
+	idToken, _ := ses.IssueTokens(testIssuer, oidcClient.ClientId)
+	if idToken.Nonce != frontChannelSession.Nonce {
+		t.Fatalf("token endpoint id token should carry the nonce")
+	}
+}
+
+func TestRefreshTokenReuseRevokesSession(t *testing.T) {
+	ctx := t.Context()
+	daoSource, keypair := newSigningDaoSource(t)
+	handler := &authorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	rsaKey, err := keypair.DecodeRsaKey()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	oidcClient := &client.Client{
+		ClientId: uuid.NewString(),
+	}
+	ses, err := session.NewSession(uuid.NewString(), oidcClient.ClientId)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	issueRefreshToken := func() string {
+		_, refreshToken := ses.IssueTokens(testIssuer, ses.ClientId)
+		err = daoSource.GetSessionStore(ctx).SaveSession(ctx, ses)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		refreshTokenJwt, err := jwtutil.ClaimsToTypedJwt(refreshToken, jwtutil.RefreshTokenType, keypair.Kid, rsaKey)
+		if err != nil {
+			t.Fatalf("%v", err)
 		}
+		return refreshTokenJwt
+	}
+
+	// any other token (eg: an id token held by the client) is just invalid, not reuse
+	idToken, _ := ses.IssueTokens(testIssuer, ses.ClientId)
+	err = daoSource.GetSessionStore(ctx).SaveSession(ctx, ses)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	idTokenJwt, err := jwtutil.ClaimsToJwt(idToken, keypair.Kid, rsaKey)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, err = handler.mapToSession(ctx, "refresh_token", idTokenJwt, &api.TokenRequestBody{}, oidcClient)
+	if oauthErr, ok := oautherror.As(err); !ok || oauthErr.Code != oautherror.InvalidGrant {
+		t.Fatalf("expected invalid_grant but got %v", err)
+	}
+	if stored, _ := daoSource.GetSessionStore(ctx).LoadSession(ctx, ses.SessionId, ses.UserId); !stored.IsActive() {
+		t.Fatalf("presenting an id token must not revoke the session")
 	}
 
-	for _, invalidString := range invalidStrings {
-		valid := isValidRedirectUri(client, invalidString)
-		if valid {
-			t.Errorf("Incorrectly Valid: %v", invalidString)
+	staleRefreshToken := issueRefreshToken()
+	currentRefreshToken := issueRefreshToken()
+
+	if _, err = handler.mapToSession(ctx, "refresh_token", currentRefreshToken, &api.TokenRequestBody{}, oidcClient); err != nil {
+		t.Fatalf("current refresh token should be accepted: %v", err)
+	}
+	if _, err = handler.mapToSession(ctx, "refresh_token", staleRefreshToken, &api.TokenRequestBody{}, oidcClient); err == nil {
+		t.Fatalf("stale refresh token must be rejected")
+	}
+	if ses.IsActive() {
+		t.Fatalf("refresh token reuse should have revoked the session")
+	}
+	if _, err = handler.mapToSession(ctx, "refresh_token", currentRefreshToken, &api.TokenRequestBody{}, oidcClient); err == nil {
+		t.Fatalf("refresh token for a revoked session must be rejected")
+	}
+	events, err := daoSource.GetEventStore(ctx).ListEvents(ctx)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(events) != 1 || events[0].EventType != audit.EventTypeRefreshTokenReuse {
+		t.Fatalf("expected a refresh token reuse event: %+v", events)
+	}
+}
+
+func TestTokenPostErrorResponses(t *testing.T) {
+	ctx := t.Context()
+	daoSource := dao.NewMemoryDao()
+	handler := &authorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	oidcClient := &client.Client{
+		ClientId: uuid.NewString(),
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, oidcClient)
+
+	res, err := handler.TokenPost(basicAuthContext(ctx, "unknown", "secret"), &api.TokenPostApplicationXWwwFormUrlencoded{
+		GrantType: api.NewOptString(client.GrantTypeAuthorizationCode),
+		Code:      api.NewOptString("code"),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	unauthorized, ok := res.(*api.TokenPostUnauthorized)
+	if !ok || unauthorized.Response.Error != oautherror.InvalidClient {
+		t.Fatalf("expected a 401 invalid_client but got %+v", res)
+	}
+	if unauthorized.WWWAuthenticate.Or("") != "Basic" {
+		t.Fatalf("expected WWW-Authenticate for basic auth")
+	}
+
+	for grantType, expectedError := range map[string]string{
+		"password":                        oautherror.UnsupportedGrantType,
+		client.GrantTypeAuthorizationCode: oautherror.InvalidGrant,
+		client.GrantTypeClientCredentials: oautherror.UnauthorizedClient,
+	} {
+		res, err = handler.TokenPost(ctx, &api.TokenPostApplicationXWwwFormUrlencoded{
+			ClientID:  api.NewOptString(oidcClient.ClientId),
+			GrantType: api.NewOptString(grantType),
+			Code:      api.NewOptString("unknown-code"),
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		badRequest, ok := res.(*api.TokenPostBadRequest)
+		if !ok || badRequest.Response.Error != expectedError {
+			t.Fatalf("expected a 400 %v for %v but got %+v", expectedError, grantType, res)
 		}
 	}
 }
+
+func TestAuthorizeErrorsRedirectToClient(t *testing.T) {
+	ctx := t.Context()
+	daoSource := dao.NewMemoryDao()
+	handler := authorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	oidcClient := &client.Client{
+		ClientId:            uuid.NewString(),
+		AllowedRedirectUris: []string{"https://client/callback"},
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, oidcClient)
+
+	// an unvalidated redirect uri must never be redirected to
+	_, err := handler.AuthorizeGet(ctx, api.AuthorizeGetParams{
+		ResponseType: api.NewOptString("token"),
+		ClientID:     oidcClient.ClientId,
+		RedirectURI:  api.NewOptString("https://attacker/callback"),
+		State:        api.NewOptString("xyz"),
+	})
+	if oauthErr, ok := oautherror.As(err); !ok || oauthErr.Code != oautherror.InvalidRequest {
+		t.Fatalf("expected invalid_request error but got %v", err)
+	}
+
+	res, err := handler.AuthorizeGet(ctx, api.AuthorizeGetParams{
+		ResponseType: api.NewOptString("token"),
+		ClientID:     oidcClient.ClientId,
+		RedirectURI:  api.NewOptString("https://client/callback"),
+		State:        api.NewOptString("xyz"),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	found, ok := res.(*api.AuthorizeGetFound)
+	if !ok {
+		t.Fatalf("expected a redirect but got %+v", res)
+	}
+	// front channel response types get their errors in the fragment
+	expected := "https://client/callback#error=unsupported_response_type&error_description=unsupported+response_type%3A+token&state=xyz"
+	if found.Location != expected {
+		t.Fatalf("unexpected error redirect: %v", found.Location)
+	}
+}
+
+func TestClientCredentialsGrant(t *testing.T) {
+	ctx := t.Context()
+	daoSource, _ := newSigningDaoSource(t)
+	handler := &authorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	machineClient := &client.Client{
+		ClientId:         uuid.NewString(),
+		ClientType:       client.ClientTypeConfidential,
+		MachineScopes:    []string{"reports:read", "reports:write"},
+		MachineAudiences: []string{"https://api.example.com"},
+	}
+	err := machineClient.SetClientSecret("secret")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, machineClient)
+
+	res, err := handler.TokenPost(basicAuthContext(ctx, machineClient.ClientId, "secret"), &api.TokenPostApplicationXWwwFormUrlencoded{
+		GrantType: api.NewOptString(client.GrantTypeClientCredentials),
+		Scope:     api.NewOptString("reports:read"),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	loginTokens, ok := res.(*api.LoginTokensHeaders)
+	if !ok {
+		t.Fatalf("expected tokens but got %+v", res)
+	}
+	if loginTokens.Response.IDToken.Set || loginTokens.Response.RefreshToken.Set {
+		t.Fatalf("only an access token is issued for client credentials")
+	}
+	if loginTokens.Response.Scope.Or("") != "reports:read" {
+		t.Fatalf("unexpected scope: %v", loginTokens.Response.Scope)
+	}
+	claims, err := jwtutil.ParseAccessToken(ctx, loginTokens.Response.AccessToken, daoSource.GetKeyStore(ctx), testIssuer, "https://api.example.com")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if claims.Sub != machineClient.ClientId || claims.ClientId != machineClient.ClientId || claims.Sid != "" {
+		t.Fatalf("the client should be the subject, without a session: %+v", claims)
+	}
+	// not valid for /userinfo
+	if claims.HasAudience(testIssuer) {
+		t.Fatalf("machine tokens are only for the machine audiences")
+	}
+
+	res, err = handler.TokenPost(basicAuthContext(ctx, machineClient.ClientId, "secret"), &api.TokenPostApplicationXWwwFormUrlencoded{
+		GrantType: api.NewOptString(client.GrantTypeClientCredentials),
+		Scope:     api.NewOptString("openid"),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if badRequest, ok := res.(*api.TokenPostBadRequest); !ok || badRequest.Response.Error != oautherror.InvalidScope {
+		t.Fatalf("expected invalid_scope but got %+v", res)
+	}
+}
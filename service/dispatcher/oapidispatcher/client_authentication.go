@@ -0,0 +1,169 @@
+package oapidispatcher
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	cjwt "github.com/cristalhq/jwt/v5"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/dispatcherauth"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+)
+
+// client credentials as presented to an endpoint (eg: /token)
+type clientCredentials struct {
+	ClientId        string
+	ClientSecret    string
+	ClientAssertion string
+	AuthMethod      string // client.TokenEndpointAuthMethod*
+}
+
+// see https://datatracker.ietf.org/doc/html/rfc6749#section-2.3.1
+// and https://datatracker.ietf.org/doc/html/rfc7523#section-2.2
+// the body values are the client_id, client_secret, client_assertion_type and client_assertion request parameters
+func clientCredentialsFromRequest(ctx context.Context, bodyClientId string, bodyClientSecret string, clientAssertionType string, clientAssertion string) (*clientCredentials, error) {
+	basicAuth := dispatcherauth.GetBasicAuth(ctx)
+	if clientAssertionType != "" || clientAssertion != "" {
+		if basicAuth != nil || bodyClientSecret != "" {
+			return nil, oautherror.New(oautherror.InvalidRequest, "multiple client authentication methods used")
+		}
+		if clientAssertionType != jwtutil.ClientAssertionTypeJwtBearer {
+			return nil, oautherror.New(oautherror.InvalidClient, "unsupported client_assertion_type: %v", clientAssertionType)
+		}
+		// the client is identified by the (as yet unverified) assertion subject
+		claims := &jwtutil.ClientAssertionClaims{}
+		token, err := cjwt.ParseNoVerify([]byte(clientAssertion))
+		if err != nil {
+			return nil, oautherror.Wrap(oautherror.InvalidClient, err)
+		}
+		err = token.DecodeClaims(claims)
+		if err != nil {
+			return nil, oautherror.Wrap(oautherror.InvalidClient, err)
+		}
+		if bodyClientId != "" && bodyClientId != claims.Sub {
+			return nil, oautherror.New(oautherror.InvalidClient, "client_id mismatch")
+		}
+		// HMAC signed assertions are client_secret_jwt, anything else must be private_key_jwt
+		authMethod := client.TokenEndpointAuthMethodPrivateKeyJwt
+		if slices.Contains(jwtutil.SupportedClientSecretJwtSigningAlgs, token.Header().Algorithm.String()) {
+			authMethod = client.TokenEndpointAuthMethodClientSecretJwt
+		}
+		return &clientCredentials{
+			ClientId:        claims.Sub,
+			ClientAssertion: clientAssertion,
+			AuthMethod:      authMethod,
+		}, nil
+	}
+	if basicAuth != nil {
+		if bodyClientSecret != "" {
+			return nil, oautherror.New(oautherror.InvalidRequest, "multiple client authentication methods used")
+		}
+		// client id and secret are form-urlencoded before being used as the basic auth values
+		clientId, err := url.QueryUnescape(basicAuth.Username)
+		if err != nil {
+			return nil, oautherror.Wrap(oautherror.InvalidClient, err)
+		}
+		clientSecret, err := url.QueryUnescape(basicAuth.Password)
+		if err != nil {
+			return nil, oautherror.Wrap(oautherror.InvalidClient, err)
+		}
+		if bodyClientId != "" && bodyClientId != clientId {
+			return nil, oautherror.New(oautherror.InvalidClient, "client_id mismatch")
+		}
+		return &clientCredentials{
+			ClientId:     clientId,
+			ClientSecret: clientSecret,
+			AuthMethod:   client.TokenEndpointAuthMethodClientSecretBasic,
+		}, nil
+	}
+	if bodyClientSecret != "" {
+		return &clientCredentials{
+			ClientId:     bodyClientId,
+			ClientSecret: bodyClientSecret,
+			AuthMethod:   client.TokenEndpointAuthMethodClientSecretPost,
+		}, nil
+	}
+	return &clientCredentials{
+		ClientId:   bodyClientId,
+		AuthMethod: client.TokenEndpointAuthMethodNone,
+	}, nil
+}
+
+// confidential clients must present a valid secret or signed assertion.
+// public clients only identify themselves, and must NOT present a secret.
+func authenticateClient(ctx context.Context, daoSource dao.DaoSource, issuer string, credentials *clientCredentials) (*client.Client, error) {
+	if credentials.ClientId == "" {
+		return nil, oautherror.New(oautherror.InvalidClient, "client authentication failed")
+	}
+	oidcClient, err := daoSource.GetClientStore(ctx).GetClient(ctx, credentials.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	if oidcClient == nil {
+		return nil, oautherror.New(oautherror.InvalidClient, "client authentication failed")
+	}
+
+	if !oidcClient.IsConfidential() {
+		if credentials.AuthMethod != client.TokenEndpointAuthMethodNone {
+			return nil, oautherror.New(oautherror.InvalidClient, "client authentication failed: public client")
+		}
+		return oidcClient, nil
+	}
+
+	if credentials.AuthMethod == client.TokenEndpointAuthMethodNone {
+		return nil, oautherror.New(oautherror.InvalidClient, "client authentication failed: confidential client")
+	}
+	if oidcClient.TokenEndpointAuthMethod != "" && oidcClient.TokenEndpointAuthMethod != credentials.AuthMethod {
+		return nil, oautherror.New(oautherror.InvalidClient, "client authentication failed: %v not allowed", credentials.AuthMethod)
+	}
+	if credentials.ClientAssertion != "" {
+		err = verifyClientAssertion(ctx, daoSource, issuer, oidcClient, credentials)
+		if err != nil {
+			return nil, oautherror.New(oautherror.InvalidClient, "client authentication failed: %v", err)
+		}
+		return oidcClient, nil
+	}
+	if !oidcClient.ClientSecretMatches(credentials.ClientSecret) {
+		return nil, oautherror.New(oautherror.InvalidClient, "client authentication failed")
+	}
+	return oidcClient, nil
+}
+
+// see https://datatracker.ietf.org/doc/html/rfc7523#section-3
+func verifyClientAssertion(ctx context.Context, daoSource dao.DaoSource, issuer string, oidcClient *client.Client, credentials *clientCredentials) error {
+	claims := &jwtutil.ClientAssertionClaims{}
+	if credentials.AuthMethod == client.TokenEndpointAuthMethodClientSecretJwt {
+		if oidcClient.ClientSecretJwtKey == "" {
+			return fmt.Errorf("no %v key registered for client %v", client.TokenEndpointAuthMethodClientSecretJwt, oidcClient.ClientId)
+		}
+		err := jwtutil.JwtToClaimsWithHmacKey(credentials.ClientAssertion, oidcClient.ClientSecretJwtKey, claims)
+		if err != nil {
+			return err
+		}
+	} else {
+		jwks, err := oidcClient.PublicKeys(ctx)
+		if err != nil {
+			return err
+		}
+		err = jwtutil.JwtToClaimsWithJwks(credentials.ClientAssertion, jwks, claims)
+		if err != nil {
+			return err
+		}
+	}
+	err := claims.Verify(oidcClient.ClientId, issuer, fmt.Sprintf("%v/token", issuer))
+	if err != nil {
+		return err
+	}
+	return jwtutil.RecordJti(
+		ctx,
+		daoSource.GetJtiStore(ctx),
+		fmt.Sprintf("client-assertion:%v", oidcClient.ClientId),
+		claims.Jti,
+		time.Unix(claims.Exp, 0),
+	)
+}
@@ -0,0 +1,296 @@
+package oapidispatcher
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cjwt "github.com/cristalhq/jwt/v5"
+
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/dispatcherauth"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/keys"
+)
+
+const testIssuer = "https://issuer.example.com"
+
+// a memory dao with a signing key, for tests that issue or verify tokens
+func newSigningDaoSource(t *testing.T) (dao.DaoSource, *keys.JwkKeypair) {
+	t.Helper()
+	daoSource := dao.NewMemoryDao()
+	keypair, err := keys.GenerateJwkKeypair()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = daoSource.GetKeyStore(t.Context()).SaveKey(t.Context(), keypair)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return daoSource, keypair
+}
+
+func basicAuthContext(ctx context.Context, username string, password string) context.Context {
+	ctx, _ = (&dispatcherauth.Handler{}).HandleBasicAuth(ctx, api.TokenPostOperation, api.BasicAuth{
+		Username: url.QueryEscape(username),
+		Password: url.QueryEscape(password),
+	})
+	return ctx
+}
+
+func TestClientCredentialsFromRequest(t *testing.T) {
+	ctx := t.Context()
+	credentials, err := clientCredentialsFromRequest(ctx, "client", "", "", "")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if credentials.AuthMethod != client.TokenEndpointAuthMethodNone {
+		t.Fatalf("unexpected auth method: %v", credentials.AuthMethod)
+	}
+
+	credentials, err = clientCredentialsFromRequest(ctx, "client", "secret", "", "")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if credentials.AuthMethod != client.TokenEndpointAuthMethodClientSecretPost || credentials.ClientSecret != "secret" {
+		t.Fatalf("unexpected credentials: %+v", credentials)
+	}
+
+	basicCtx := basicAuthContext(ctx, "client:id", "se/cret")
+	credentials, err = clientCredentialsFromRequest(basicCtx, "", "", "", "")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if credentials.AuthMethod != client.TokenEndpointAuthMethodClientSecretBasic {
+		t.Fatalf("unexpected auth method: %v", credentials.AuthMethod)
+	}
+	if credentials.ClientId != "client:id" || credentials.ClientSecret != "se/cret" {
+		t.Fatalf("basic auth values not decoded: %+v", credentials)
+	}
+
+	_, err = clientCredentialsFromRequest(basicCtx, "", "secret", "", "")
+	if err == nil {
+		t.Fatalf("multiple authentication methods must be rejected")
+	}
+	_, err = clientCredentialsFromRequest(basicCtx, "other-client", "", "", "")
+	if err == nil {
+		t.Fatalf("client_id mismatch must be rejected")
+	}
+}
+
+func TestAuthenticateClient(t *testing.T) {
+	ctx := t.Context()
+	daoSource := dao.NewMemoryDao()
+
+	publicClient := &client.Client{
+		ClientId: uuid.NewString(),
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, publicClient)
+
+	rawSecret, err := client.GenerateClientSecret()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	confidentialClient := &client.Client{
+		ClientId:                uuid.NewString(),
+		ClientType:              client.ClientTypeConfidential,
+		TokenEndpointAuthMethod: client.TokenEndpointAuthMethodClientSecretBasic,
+	}
+	confidentialClient.SetClientSecret(rawSecret)
+	daoSource.GetClientStore(ctx).SaveClient(ctx, confidentialClient)
+
+	authenticate := func(ctx context.Context, clientId string, clientSecret string) (*client.Client, error) {
+		credentials, err := clientCredentialsFromRequest(ctx, clientId, clientSecret, "", "")
+		if err != nil {
+			return nil, err
+		}
+		return authenticateClient(ctx, daoSource, testIssuer, credentials)
+	}
+
+	if _, err = authenticate(ctx, publicClient.ClientId, ""); err != nil {
+		t.Fatalf("public client should authenticate: %v", err)
+	}
+	if _, err = authenticate(ctx, publicClient.ClientId, "secret"); err == nil {
+		t.Fatalf("public client must not present a secret")
+	}
+	if _, err = authenticate(ctx, uuid.NewString(), ""); err == nil {
+		t.Fatalf("unknown client must not authenticate")
+	}
+
+	if _, err = authenticate(ctx, confidentialClient.ClientId, ""); err == nil {
+		t.Fatalf("confidential client must present a secret")
+	}
+	if _, err = authenticate(ctx, confidentialClient.ClientId, rawSecret); err == nil {
+		t.Fatalf("client_secret_post must be rejected when restricted to client_secret_basic")
+	}
+	if _, err = authenticate(basicAuthContext(ctx, confidentialClient.ClientId, "wrong"), "", ""); err == nil {
+		t.Fatalf("incorrect secret must be rejected")
+	}
+	authenticated, err := authenticate(basicAuthContext(ctx, confidentialClient.ClientId, rawSecret), "", "")
+	if err != nil {
+		t.Fatalf("confidential client should authenticate: %v", err)
+	}
+	if authenticated.ClientId != confidentialClient.ClientId {
+		t.Fatalf("authenticated the wrong client")
+	}
+}
+
+func TestAuthenticateClientWithPrivateKeyJwt(t *testing.T) {
+	ctx := t.Context()
+	daoSource := dao.NewMemoryDao()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	keyId := uuid.NewString()
+	assertionClient := &client.Client{
+		ClientId:   uuid.NewString(),
+		ClientType: client.ClientTypeConfidential,
+		Jwks: &keys.JwkSet{
+			Keys: []*keys.JwkDetails{
+				keys.JwkFromEcDSA(keyId, &privateKey.PublicKey),
+			},
+		},
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, assertionClient)
+
+	signer, err := cjwt.NewSignerES(cjwt.ES256, privateKey)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	newAssertion := func(audience string) string {
+		token, err := cjwt.NewBuilder(signer, cjwt.WithKeyID(keyId)).Build(&jwtutil.ClientAssertionClaims{
+			Iss: assertionClient.ClientId,
+			Sub: assertionClient.ClientId,
+			Aud: []string{audience},
+			Exp: time.Now().Add(time.Minute).Unix(),
+			Jti: uuid.NewString(),
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return token.String()
+	}
+	authenticate := func(clientAssertion string) (*client.Client, error) {
+		credentials, err := clientCredentialsFromRequest(ctx, "", "", jwtutil.ClientAssertionTypeJwtBearer, clientAssertion)
+		if err != nil {
+			return nil, err
+		}
+		return authenticateClient(ctx, daoSource, testIssuer, credentials)
+	}
+
+	assertion := newAssertion(testIssuer + "/token")
+	authenticated, err := authenticate(assertion)
+	if err != nil {
+		t.Fatalf("client assertion should authenticate: %v", err)
+	}
+	if authenticated.ClientId != assertionClient.ClientId {
+		t.Fatalf("authenticated the wrong client")
+	}
+	if _, err = authenticate(assertion); err == nil {
+		t.Fatalf("replayed client assertion must be rejected")
+	}
+	// concurrent replays race to record the jti, only one may win
+	concurrentAssertion := newAssertion(testIssuer)
+	var authenticatedCount atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := authenticate(concurrentAssertion); err == nil {
+				authenticatedCount.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if authenticatedCount.Load() != 1 {
+		t.Fatalf("expected a single concurrent assertion to authenticate but got %v", authenticatedCount.Load())
+	}
+	if _, err = authenticate(newAssertion("https://other.example.com/token")); err == nil {
+		t.Fatalf("client assertion for another audience must be rejected")
+	}
+	if _, err = clientCredentialsFromRequest(ctx, "", "", "urn:unknown", newAssertion(testIssuer)); err == nil {
+		t.Fatalf("unknown client_assertion_type must be rejected")
+	}
+	if _, err = clientCredentialsFromRequest(basicAuthContext(ctx, assertionClient.ClientId, "secret"), "", "", jwtutil.ClientAssertionTypeJwtBearer, newAssertion(testIssuer)); err == nil {
+		t.Fatalf("multiple authentication methods must be rejected")
+	}
+}
+
+func TestAuthenticateClientWithClientSecretJwt(t *testing.T) {
+	ctx := t.Context()
+	daoSource := dao.NewMemoryDao()
+
+	secretJwtClient := &client.Client{
+		ClientId:                uuid.NewString(),
+		ClientType:              client.ClientTypeConfidential,
+		TokenEndpointAuthMethod: client.TokenEndpointAuthMethodClientSecretJwt,
+	}
+	rawKey, err := secretJwtClient.GenerateClientSecretJwtKey()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, secretJwtClient)
+
+	newAssertion := func(key string) string {
+		signer, err := cjwt.NewSignerHS(cjwt.HS256, []byte(key))
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		token, err := cjwt.NewBuilder(signer).Build(&jwtutil.ClientAssertionClaims{
+			Iss: secretJwtClient.ClientId,
+			Sub: secretJwtClient.ClientId,
+			Aud: []string{testIssuer + "/token"},
+			Exp: time.Now().Add(time.Minute).Unix(),
+			Jti: uuid.NewString(),
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return token.String()
+	}
+	authenticate := func(clientAssertion string) (*client.Client, error) {
+		credentials, err := clientCredentialsFromRequest(ctx, "", "", jwtutil.ClientAssertionTypeJwtBearer, clientAssertion)
+		if err != nil {
+			return nil, err
+		}
+		return authenticateClient(ctx, daoSource, testIssuer, credentials)
+	}
+
+	assertion := newAssertion(rawKey)
+	authenticated, err := authenticate(assertion)
+	if err != nil {
+		t.Fatalf("client assertion should authenticate: %v", err)
+	}
+	if authenticated.ClientId != secretJwtClient.ClientId {
+		t.Fatalf("authenticated the wrong client")
+	}
+	if _, err = authenticate(assertion); err == nil {
+		t.Fatalf("replayed client assertion must be rejected")
+	}
+	if _, err = authenticate(newAssertion("not-the-key")); err == nil {
+		t.Fatalf("client assertion signed with the wrong key must be rejected")
+	}
+
+	// HMAC assertions are only accepted from clients with a key
+	privateKeyJwtClient := &client.Client{
+		ClientId:   uuid.NewString(),
+		ClientType: client.ClientTypeConfidential,
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, privateKeyJwtClient)
+	secretJwtClient.ClientId = privateKeyJwtClient.ClientId
+	if _, err = authenticate(newAssertion(rawKey)); err == nil {
+		t.Fatalf("client assertion without a registered key must be rejected")
+	}
+}
@@ -0,0 +1,85 @@
+package oapidispatcher
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+)
+
+type deviceAuthorizationHandler struct {
+	DaoSource dao.DaoSource
+	Issuer    string
+}
+
+// DeviceAuthorizationPost implements [api.DeviceAuthorizationHandler].
+// see https://datatracker.ietf.org/doc/html/rfc8628#section-3.1
+func (obj *deviceAuthorizationHandler) DeviceAuthorizationPost(ctx context.Context, req *api.DeviceAuthorizationRequestBody) (api.DeviceAuthorizationPostRes, error) {
+	dc, err := obj.deviceAuthorizationPost(ctx, req)
+	if oauthErr, ok := oautherror.As(err); ok {
+		return oauthErrorResponse(ctx, oauthErr), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	verificationUri := obj.Issuer + "/device"
+	res := &api.DeviceAuthorizationResponse{
+		DeviceCode:      dc.DeviceCode,
+		UserCode:        dc.FormattedUserCode(),
+		VerificationURI: verificationUri,
+		ExpiresIn:       int64(time.Until(*dc.Expiry).Seconds()),
+	}
+	res.VerificationURIComplete.SetTo(verificationUri + "?" + url.Values{"user_code": {dc.FormattedUserCode()}}.Encode())
+	res.Interval.SetTo(int64(dc.Interval.Seconds()))
+	return res, nil
+}
+
+func (obj *deviceAuthorizationHandler) deviceAuthorizationPost(ctx context.Context, req *api.DeviceAuthorizationRequestBody) (*client.DeviceCode, error) {
+	credentials, err := clientCredentialsFromRequest(
+		ctx,
+		req.ClientID.Or(""),
+		req.ClientSecret.Or(""),
+		req.ClientAssertionType.Or(""),
+		req.ClientAssertion.Or(""),
+	)
+	if err != nil {
+		return nil, err
+	}
+	authenticatedClient, err := authenticateClient(ctx, obj.DaoSource, obj.Issuer, credentials)
+	if err != nil {
+		return nil, err
+	}
+	if !authenticatedClient.AllowsGrantType(client.GrantTypeDeviceCode) {
+		return nil, oautherror.New(oautherror.UnauthorizedClient, "grant type not allowed for client: %v", client.GrantTypeDeviceCode)
+	}
+	grantedScope, err := authenticatedClient.GrantScopes(req.Scope.Or(""))
+	if err != nil {
+		return nil, err
+	}
+
+	deviceCodeStore := obj.DaoSource.GetDeviceCodeStore(ctx)
+	// user codes are short, so make sure an outstanding one isn't reused
+	for range 5 {
+		dc, err := client.NewDeviceCode(authenticatedClient.ClientId, grantedScope)
+		if err != nil {
+			return nil, err
+		}
+		existing, err := deviceCodeStore.GetDeviceCode(ctx, dc.UserCode)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil && !existing.IsExpired() {
+			continue
+		}
+		err = deviceCodeStore.SaveDeviceCode(ctx, dc)
+		if err != nil {
+			return nil, err
+		}
+		return dc, nil
+	}
+	return nil, oautherror.New(oautherror.ServerError, "unable to allocate a user code")
+}
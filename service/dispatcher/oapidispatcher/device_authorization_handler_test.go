@@ -0,0 +1,101 @@
+package oapidispatcher
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/users"
+)
+
+var _ api.DeviceAuthorizationHandler = (*deviceAuthorizationHandler)(nil)
+
+func TestDeviceAuthorizationGrant(t *testing.T) {
+	ctx := t.Context()
+	daoSource, _ := newSigningDaoSource(t)
+	user := &users.OidcUser{
+		Id: "username",
+	}
+	daoSource.GetUserStore(ctx).SaveUser(ctx, user)
+	deviceClient := &client.Client{
+		ClientId: uuid.NewString(),
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, deviceClient)
+
+	deviceHandler := &deviceAuthorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	tokenHandler := &authorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+
+	res, err := deviceHandler.DeviceAuthorizationPost(ctx, &api.DeviceAuthorizationRequestBody{
+		ClientID: api.NewOptString(deviceClient.ClientId),
+		Scope:    api.NewOptString("openid"),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	deviceAuthorization, ok := res.(*api.DeviceAuthorizationResponse)
+	if !ok {
+		t.Fatalf("expected a device authorization but got %+v", res)
+	}
+	if deviceAuthorization.VerificationURI != testIssuer+"/device" {
+		t.Fatalf("unexpected verification uri: %v", deviceAuthorization.VerificationURI)
+	}
+	if !strings.HasPrefix(deviceAuthorization.VerificationURIComplete.Or(""), testIssuer+"/device?user_code=") {
+		t.Fatalf("unexpected complete verification uri: %v", deviceAuthorization.VerificationURIComplete)
+	}
+
+	poll := func() api.TokenPostRes {
+		res, err := tokenHandler.TokenPost(ctx, &api.TokenPostApplicationXWwwFormUrlencoded{
+			GrantType:  api.NewOptString(client.GrantTypeDeviceCode),
+			ClientID:   api.NewOptString(deviceClient.ClientId),
+			DeviceCode: api.NewOptString(deviceAuthorization.DeviceCode),
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return res
+	}
+	expectError := func(res api.TokenPostRes, code string) {
+		t.Helper()
+		if badRequest, ok := res.(*api.TokenPostBadRequest); !ok || badRequest.Response.Error != code {
+			t.Fatalf("expected %v but got %+v", code, res)
+		}
+	}
+
+	expectError(poll(), oautherror.AuthorizationPending)
+	expectError(poll(), oautherror.SlowDown)
+
+	// approved on the verification page
+	dc, err := daoSource.GetDeviceCodeStore(ctx).GetDeviceCode(ctx, client.NormalizeUserCode(deviceAuthorization.UserCode))
+	if err != nil || dc == nil {
+		t.Fatalf("device code not found: %v", err)
+	}
+	if dc.Interval != client.DeviceCodeInterval+client.DeviceCodeSlowDownIncrement {
+		t.Fatalf("slow_down should increase the interval, but it is %v", dc.Interval)
+	}
+	err = dc.Approve(user.Id, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	daoSource.GetDeviceCodeStore(ctx).SaveDeviceCode(ctx, dc)
+
+	loginTokens, ok := poll().(*api.LoginTokensHeaders)
+	if !ok {
+		t.Fatalf("expected tokens once approved")
+	}
+	if !loginTokens.Response.IDToken.Set || !loginTokens.Response.RefreshToken.Set {
+		t.Fatalf("expected id and refresh tokens: %+v", loginTokens.Response)
+	}
+
+	// single use
+	expectError(poll(), oautherror.InvalidGrant)
+}
@@ -0,0 +1,39 @@
+package oapidispatcher
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kncept-oauth/simple-oidc/service/dispatcherauth"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/tokens"
+)
+
+// the thumbprint of the key from a valid DPoP proof sent to /token, or "" if there was no proof
+// see https://datatracker.ietf.org/doc/html/rfc9449#section-5
+func tokenRequestDPoPJkt(ctx context.Context, tokenService *tokens.TokenService) (string, error) {
+	proof := dispatcherauth.GetDPoPProof(ctx)
+	if proof == "" {
+		return "", nil
+	}
+	dpopProof, err := tokenService.VerifyDPoPProof(ctx, proof, http.MethodPost, "/token", "")
+	if err != nil {
+		return "", err
+	}
+	return dpopProof.Jkt, nil
+}
+
+// the client can retry straight away with a fresh nonce
+// see https://datatracker.ietf.org/doc/html/rfc9449#section-8
+func setDPoPNonce(ctx context.Context, tokenService *tokens.TokenService, res *api.OAuthErrorHeaders) error {
+	if res.Response.Error != oautherror.UseDPoPNonce && res.Response.Error != oautherror.InvalidDPoPProof {
+		return nil
+	}
+	nonce, err := tokenService.NewDPoPNonce(ctx)
+	if err != nil {
+		return err
+	}
+	res.DPoPNonce = api.NewOptString(nonce)
+	return nil
+}
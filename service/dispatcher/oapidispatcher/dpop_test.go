@@ -0,0 +1,263 @@
+package oapidispatcher
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	cjwt "github.com/cristalhq/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dispatcherauth"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/keys"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/users"
+)
+
+// a client side DPoP key
+type dpopKey struct {
+	privateKey *ecdsa.PrivateKey
+	jwk        *keys.JwkDetails
+}
+
+func newDPoPKey(t *testing.T) *dpopKey {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	jwk := keys.JwkFromEcDSA("", &privateKey.PublicKey)
+	return &dpopKey{
+		privateKey: privateKey,
+		jwk:        jwk,
+	}
+}
+
+func (obj *dpopKey) proof(t *testing.T, htm string, htu string, accessToken string, nonce string) string {
+	claims := jwtutil.DPoPProofClaims{
+		Jti:   uuid.NewString(),
+		Htm:   htm,
+		Htu:   htu,
+		Iat:   time.Now().Unix(),
+		Nonce: nonce,
+	}
+	if accessToken != "" {
+		claims.Ath = jwtutil.DPoPAccessTokenHash(accessToken)
+	}
+	header, err := json.Marshal(map[string]any{
+		"typ": jwtutil.DPoPProofType,
+		"alg": cjwt.ES256,
+		"jwk": map[string]string{
+			"kty": obj.jwk.Kty,
+			"crv": obj.jwk.Crv,
+			"x":   obj.jwk.X,
+			"y":   obj.jwk.Y,
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	signer, err := cjwt.NewSignerES(cjwt.ES256, obj.privateKey)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	signature, err := signer.Sign([]byte(signingInput))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func dpopProofContext(ctx context.Context, proof string) context.Context {
+	ctx, _ = (&dispatcherauth.Handler{}).HandleDPoPProof(ctx, api.TokenPostOperation, api.DPoPProof{
+		APIKey: proof,
+	})
+	return ctx
+}
+
+func TestDPoPBoundTokens(t *testing.T) {
+	ctx := t.Context()
+	daoSource, _ := newSigningDaoSource(t)
+	user := &users.OidcUser{
+		Id: "username",
+	}
+	daoSource.GetUserStore(ctx).SaveUser(ctx, user)
+	oidcClient := &client.Client{
+		ClientId:            uuid.NewString(),
+		AllowedRedirectUris: []string{"https://client/callback"},
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, oidcClient)
+	newAuthCode := func(sessionId string) string {
+		authCode, err := client.NewAuthorizationCode(user.Id, oidcClient.ClientId, "https://client/callback", "")
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		authCode.Scope = "openid"
+		authCode.SessionId = sessionId
+		err = daoSource.GetAuthorizationCodeStore(ctx).SaveAuthorizationCode(ctx, authCode)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return authCode.Code
+	}
+
+	tokenHandler := &authorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	key := newDPoPKey(t)
+	jkt, err := key.jwk.Thumbprint()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	tokenPost := func(code string, proof string) api.TokenPostRes {
+		res, err := tokenHandler.TokenPost(dpopProofContext(ctx, proof), &api.TokenPostApplicationXWwwFormUrlencoded{
+			GrantType:   api.NewOptString(client.GrantTypeAuthorizationCode),
+			ClientID:    api.NewOptString(oidcClient.ClientId),
+			Code:        api.NewOptString(code),
+			RedirectURI: api.NewOptString("https://client/callback"),
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return res
+	}
+	expectError := func(res api.TokenPostRes, code string) *api.TokenPostBadRequest {
+		t.Helper()
+		badRequest, ok := res.(*api.TokenPostBadRequest)
+		if !ok || badRequest.Response.Error != code {
+			t.Fatalf("expected %v but got %+v", code, res)
+		}
+		return badRequest
+	}
+
+	// the first proof has no nonce, so the server provides one
+	code := newAuthCode("")
+	res := expectError(tokenPost(code, key.proof(t, http.MethodPost, testIssuer+"/token", "", "")), oautherror.UseDPoPNonce)
+	nonce := res.DPoPNonce.Or("")
+	if nonce == "" {
+		t.Fatalf("expected a DPoP-Nonce header")
+	}
+	expectError(tokenPost(code, key.proof(t, http.MethodPost, testIssuer+"/token", "", "made-up-nonce")), oautherror.UseDPoPNonce)
+	expectError(tokenPost(code, key.proof(t, http.MethodPost, testIssuer+"/revoke", "", nonce)), oautherror.InvalidDPoPProof)
+
+	proof := key.proof(t, http.MethodPost, testIssuer+"/token", "", nonce)
+	loginTokens, ok := tokenPost(code, proof).(*api.LoginTokensHeaders)
+	if !ok || loginTokens.Response.TokenType != jwtutil.DPoPTokenType {
+		t.Fatalf("expected DPoP tokens but got %+v", loginTokens)
+	}
+	claims, err := jwtutil.ParseAccessToken(ctx, loginTokens.Response.AccessToken, daoSource.GetKeyStore(ctx), testIssuer, testIssuer)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if claims.BoundJkt() != jkt {
+		t.Fatalf("expected the access token to be bound to %v but got %+v", jkt, claims.Cnf)
+	}
+	accessToken := loginTokens.Response.AccessToken
+
+	// proofs can only be used once
+	expectError(tokenPost(newAuthCode(""), proof), oautherror.InvalidDPoPProof)
+	// the session is bound, so further tokens for it need a proof from the same key
+	expectError(tokenPost(newAuthCode(claims.Sid), ""), oautherror.InvalidDPoPProof)
+	expectError(tokenPost(newAuthCode(claims.Sid), newDPoPKey(t).proof(t, http.MethodPost, testIssuer+"/token", "", nonce)), oautherror.InvalidDPoPProof)
+
+	userInfoHandler := &userInfoHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	userinfo := func(authorization string, proof string) api.UserinfoGetRes {
+		authCtx := ctx
+		if scheme, token, _ := strings.Cut(authorization, " "); scheme == "Bearer" {
+			authCtx, _ = (&dispatcherauth.Handler{}).HandleBearerAuth(ctx, api.UserinfoGetOperation, api.BearerAuth{
+				Token: token,
+			})
+		} else {
+			authCtx, _ = (&dispatcherauth.Handler{}).HandleDPoPAuth(ctx, api.UserinfoGetOperation, api.DPoPAuth{
+				APIKey: authorization,
+			})
+		}
+		res, err := userInfoHandler.UserinfoGet(dpopProofContext(authCtx, proof))
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return res
+	}
+	expectUnauthorized := func(res api.UserinfoGetRes, code string) {
+		t.Helper()
+		unauthorized, ok := res.(*api.OAuthErrorHeaders)
+		if !ok || unauthorized.Response.Error != code {
+			t.Fatalf("expected %v but got %+v", code, res)
+		}
+	}
+
+	// a bound token can't be downgraded to a bearer token
+	expectUnauthorized(userinfo("Bearer "+accessToken, ""), oautherror.InvalidToken)
+	expectUnauthorized(userinfo("DPoP "+accessToken, ""), oautherror.InvalidDPoPProof)
+	expectUnauthorized(userinfo("DPoP "+accessToken, key.proof(t, http.MethodGet, testIssuer+"/userinfo", "other-token", nonce)), oautherror.InvalidDPoPProof)
+	expectUnauthorized(userinfo("DPoP "+accessToken, newDPoPKey(t).proof(t, http.MethodGet, testIssuer+"/userinfo", accessToken, nonce)), oautherror.InvalidDPoPProof)
+	if info, ok := userinfo("DPoP "+accessToken, key.proof(t, http.MethodGet, testIssuer+"/userinfo?ignored=true", accessToken, nonce)).(*api.UserInfo); !ok || info.Sub != user.Id {
+		t.Fatalf("expected userinfo but got %+v", info)
+	}
+
+	// the resource server forwards the proof it was sent
+	resourceServer := &client.Client{
+		ClientId:   uuid.NewString(),
+		ClientType: client.ClientTypeConfidential,
+	}
+	err = resourceServer.SetClientSecret("secret")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, resourceServer)
+	introspectionHandler := &introspectionHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	resourceUri := "https://api.example.com/resource"
+	introspect := func(proof string) *api.IntrospectionResponse {
+		res, err := introspectionHandler.IntrospectPost(dpopProofContext(basicAuthContext(ctx, resourceServer.ClientId, "secret"), proof), &api.IntrospectionRequestBody{
+			Token:   accessToken,
+			DpopHtm: api.NewOptString(http.MethodGet),
+			DpopHtu: api.NewOptString(resourceUri),
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		introspection, ok := res.(*api.IntrospectionResponse)
+		if !ok {
+			t.Fatalf("expected an introspection response but got %+v", res)
+		}
+		return introspection
+	}
+	if introspection := introspect(""); introspection.Active {
+		t.Fatalf("a bound token is only active with a proof: %+v", introspection)
+	}
+	// the proof must be for the resource server's request
+	if introspection := introspect(key.proof(t, http.MethodPost, resourceUri, accessToken, "")); introspection.Active {
+		t.Fatalf("a proof for another method must not be accepted: %+v", introspection)
+	}
+	if introspection := introspect(key.proof(t, http.MethodGet, "https://other.example.com/resource", accessToken, "")); introspection.Active {
+		t.Fatalf("a proof for another uri must not be accepted: %+v", introspection)
+	}
+	resourceProof := key.proof(t, http.MethodGet, resourceUri, accessToken, "")
+	introspection := introspect(resourceProof)
+	if !introspection.Active || introspection.TokenType.Or("") != jwtutil.DPoPTokenType || introspection.Cnf.Value.Jkt.Or("") != jkt {
+		t.Fatalf("expected an active DPoP token but got %+v", introspection)
+	}
+	if introspection := introspect(resourceProof); introspection.Active {
+		t.Fatalf("a replayed proof must not be accepted: %+v", introspection)
+	}
+}
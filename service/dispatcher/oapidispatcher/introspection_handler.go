@@ -0,0 +1,88 @@
+package oapidispatcher
+
+import (
+	"context"
+
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/dispatcherauth"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/tokens"
+)
+
+type introspectionHandler struct {
+	DaoSource dao.DaoSource
+	Issuer    string
+}
+
+// IntrospectPost implements [api.IntrospectionHandler].
+// see https://datatracker.ietf.org/doc/html/rfc7662
+func (obj *introspectionHandler) IntrospectPost(ctx context.Context, req *api.IntrospectionRequestBody) (api.IntrospectPostRes, error) {
+	introspection, err := obj.introspectPost(ctx, req)
+	if oauthErr, ok := oautherror.As(err); ok {
+		return oauthErrorResponse(ctx, oauthErr), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := &api.IntrospectionResponse{
+		Active: introspection.Active,
+	}
+	if introspection.Active {
+		setOptString(&res.TokenType, introspection.TokenType)
+		setOptString(&res.Iss, introspection.Iss)
+		setOptString(&res.Sub, introspection.Sub)
+		setOptString(&res.ClientID, introspection.ClientId)
+		setOptString(&res.Scope, introspection.Scope)
+		setOptString(&res.Sid, introspection.Sid)
+		setOptInt64(&res.Exp, introspection.Exp)
+		setOptInt64(&res.Iat, introspection.Iat)
+		setOptInt64(&res.Nbf, introspection.Nbf)
+		if introspection.Jkt != "" {
+			// see https://datatracker.ietf.org/doc/html/rfc9449#section-6.2
+			res.Cnf.SetTo(api.Confirmation{
+				Jkt: api.NewOptString(introspection.Jkt),
+			})
+		}
+	}
+	return res, nil
+}
+
+func (obj *introspectionHandler) introspectPost(ctx context.Context, req *api.IntrospectionRequestBody) (*tokens.TokenIntrospection, error) {
+	credentials, err := clientCredentialsFromRequest(
+		ctx,
+		req.ClientID.Or(""),
+		req.ClientSecret.Or(""),
+		req.ClientAssertionType.Or(""),
+		req.ClientAssertion.Or(""),
+	)
+	if err != nil {
+		return nil, err
+	}
+	authenticatedClient, err := authenticateClient(ctx, obj.DaoSource, obj.Issuer, credentials)
+	if err != nil {
+		return nil, err
+	}
+	// anyone can identify as a public client, so token details are only released to resource
+	// servers that can actually authenticate
+	if !authenticatedClient.IsConfidential() {
+		return nil, oautherror.New(oautherror.InvalidClient, "client authentication required")
+	}
+	// the token type is determined from the token, so the token_type_hint is not needed.
+	// a resource server introspecting a DPoP bound token forwards the proof it was sent,
+	// along with the method and uri of the request it was sent with
+	return (&tokens.TokenService{
+		DaoSource: obj.DaoSource,
+		Issuer:    obj.Issuer,
+	}).IntrospectToken(ctx, req.Token, authenticatedClient, &tokens.ForwardedDPoPProof{
+		Proof: dispatcherauth.GetDPoPProof(ctx),
+		Htm:   req.DpopHtm.Or(""),
+		Htu:   req.DpopHtu.Or(""),
+	})
+}
+
+func setOptInt64(opt *api.OptInt64, value int64) {
+	if value != 0 {
+		opt.SetTo(value)
+	}
+}
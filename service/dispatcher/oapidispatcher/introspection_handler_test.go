@@ -0,0 +1,105 @@
+package oapidispatcher
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+	"github.com/kncept-oauth/simple-oidc/service/tokens"
+	"github.com/kncept-oauth/simple-oidc/service/users"
+)
+
+var _ api.IntrospectionHandler = (*introspectionHandler)(nil)
+
+func TestIntrospectPostReportsSessionState(t *testing.T) {
+	ctx := t.Context()
+	daoSource, _ := newSigningDaoSource(t)
+	user := &users.OidcUser{
+		Id: "username",
+	}
+	daoSource.GetUserStore(ctx).SaveUser(ctx, user)
+	oidcClient := &client.Client{
+		ClientId: uuid.NewString(),
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, oidcClient)
+	resourceServer := &client.Client{
+		ClientId:   uuid.NewString(),
+		ClientType: client.ClientTypeConfidential,
+	}
+	err := resourceServer.SetClientSecret("secret")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, resourceServer)
+
+	ses, err := session.NewSession(user.Id, oidcClient.ClientId)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	ses.Scope = "openid profile"
+	issued, err := (&tokens.TokenService{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}).IssueTokens(ctx, ses, oidcClient, tokens.IssueOptions{RefreshToken: true})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	handler := &introspectionHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	introspect := func(token string, clientId string, clientSecret string) api.IntrospectPostRes {
+		res, err := handler.IntrospectPost(ctx, &api.IntrospectionRequestBody{
+			Token:        token,
+			ClientID:     api.NewOptString(clientId),
+			ClientSecret: api.NewOptString(clientSecret),
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return res
+	}
+
+	res := introspect(issued.AccessToken, oidcClient.ClientId, "")
+	if oauthErr, ok := res.(*api.OAuthErrorHeaders); !ok || oauthErr.Response.Error != oautherror.InvalidClient {
+		t.Fatalf("public clients may not introspect tokens: %#v", res)
+	}
+
+	res = introspect(issued.AccessToken, resourceServer.ClientId, "secret")
+	introspection, ok := res.(*api.IntrospectionResponse)
+	if !ok || !introspection.Active {
+		t.Fatalf("expected an active access token: %#v", res)
+	}
+	if introspection.Sub.Or("") != user.Id ||
+		introspection.ClientID.Or("") != oidcClient.ClientId ||
+		introspection.Scope.Or("") != ses.Scope ||
+		introspection.Sid.Or("") != ses.SessionId ||
+		introspection.Exp.Or(0) != issued.AccessTokenClaims.Exp ||
+		introspection.Iat.Or(0) != issued.AccessTokenClaims.Iat {
+		t.Fatalf("unexpected introspection: %+v", introspection)
+	}
+
+	// refresh tokens are only reported to the client they were issued to
+	res = introspect(issued.RefreshToken, resourceServer.ClientId, "secret")
+	if introspection, ok := res.(*api.IntrospectionResponse); !ok || introspection.Active {
+		t.Fatalf("expected an inactive refresh token: %#v", res)
+	}
+
+	res = introspect("not-a-token", resourceServer.ClientId, "secret")
+	if introspection, ok := res.(*api.IntrospectionResponse); !ok || introspection.Active || introspection.Sub.Set {
+		t.Fatalf("expected an inactive token with no details: %#v", res)
+	}
+
+	err = session.RevokeSession(ctx, daoSource.GetSessionStore(ctx), ses.SessionId, ses.UserId)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	res = introspect(issued.AccessToken, resourceServer.ClientId, "secret")
+	if introspection, ok := res.(*api.IntrospectionResponse); !ok || introspection.Active {
+		t.Fatalf("access tokens for revoked sessions are inactive: %#v", res)
+	}
+}
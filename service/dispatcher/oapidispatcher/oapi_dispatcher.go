@@ -3,41 +3,136 @@ package oapidispatcher
 import (
 	"context"
 	"fmt"
+	"log"
 
+	"github.com/kncept-oauth/simple-oidc/service/client"
 	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/dispatcher/httpdispatcher"
+	"github.com/kncept-oauth/simple-oidc/service/dispatcherauth"
 	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
 )
 
 type oapiDispatcher struct {
 	authorizationHandler
 	wellKnownHandler
 	userInfoHandler
+	revocationHandler
+	introspectionHandler
+	deviceAuthorizationHandler
+	pushedAuthorizationHandler
+	registrationHandler
 }
 
 func NewOapiDispatcher(
 	daoSource dao.DaoSource,
 	urlPrefix string,
+	registration *client.RegistrationSettings,
+	templates *httpdispatcher.TemplateDispatcher,
 ) api.Handler {
 	return &oapiDispatcher{
 		authorizationHandler: authorizationHandler{
 			DaoSource: daoSource,
 			Issuer:    urlPrefix,
+			Templates: templates,
 		},
 		wellKnownHandler: wellKnownHandler{
+			DaoSource:    daoSource,
+			Issuer:       urlPrefix,
+			Registration: registration,
+		},
+		userInfoHandler: userInfoHandler{
 			DaoSource: daoSource,
 			Issuer:    urlPrefix,
 		},
-		userInfoHandler: userInfoHandler{
+		revocationHandler: revocationHandler{
 			DaoSource: daoSource,
 			Issuer:    urlPrefix,
 		},
+		introspectionHandler: introspectionHandler{
+			DaoSource: daoSource,
+			Issuer:    urlPrefix,
+		},
+		deviceAuthorizationHandler: deviceAuthorizationHandler{
+			DaoSource: daoSource,
+			Issuer:    urlPrefix,
+		},
+		pushedAuthorizationHandler: pushedAuthorizationHandler{
+			DaoSource: daoSource,
+			Issuer:    urlPrefix,
+		},
+		registrationHandler: registrationHandler{
+			DaoSource:    daoSource,
+			Issuer:       urlPrefix,
+			Registration: registration,
+		},
 	}
 }
 
-func (obj *oapiDispatcher) NewError(ctx context.Context, err error) *api.ErrRespStatusCode {
+// every error that isn't a typed response gets an OAuth error body.
+// unexpected errors are a server_error, without the (internal) details
+// see https://datatracker.ietf.org/doc/html/rfc6749#section-5.2
+func (obj *oapiDispatcher) NewError(ctx context.Context, err error) *api.ErrorResponseStatusCode {
 	fmt.Printf("General error occurred: %v\n", err)
-	return &api.ErrRespStatusCode{
+	if oauthErr, ok := oautherror.As(err); ok {
+		res := &api.ErrorResponseStatusCode{
+			StatusCode: oauthErr.StatusCode(),
+			Response: api.ErrorResponse{
+				Error: oauthErr.Code,
+			},
+		}
+		if oauthErr.Description != "" {
+			res.Response.ErrorDescription = api.NewOptString(oauthErr.Description)
+		}
+		return res
+	}
+	return &api.ErrorResponseStatusCode{
 		StatusCode: 500,
-		Response:   fmt.Sprintf("%v", err),
+		Response: api.ErrorResponse{
+			Error: oautherror.ServerError,
+		},
+	}
+}
+
+// error responses from the client (or token) authenticated endpoints (eg: /revoke and /introspect).
+// /token has distinct 400 and 401 response types, see tokenErrorResponse
+type oauthErrorRes interface {
+	api.RevokePostRes
+	api.IntrospectPostRes
+	api.DeviceAuthorizationPostRes
+	api.ParPostRes
+	api.RegisterClientRes
+	api.GetClientRegistrationRes
+	api.UpdateClientRegistrationRes
+	api.DeleteClientRegistrationRes
+}
+
+// see https://datatracker.ietf.org/doc/html/rfc6749#section-5.2
+func oauthErrorResponse(ctx context.Context, oauthErr *oautherror.OAuthError) oauthErrorRes {
+	res := oauthErrorHeaders(ctx, oauthErr)
+	if oauthErr.StatusCode() == 401 {
+		return res
+	}
+	return &res.Response
+}
+
+// the error body, with the headers a 401 needs
+func oauthErrorHeaders(ctx context.Context, oauthErr *oautherror.OAuthError) *api.OAuthErrorHeaders {
+	log.Printf("request failed: %v\n", oauthErr)
+	res := &api.OAuthErrorHeaders{
+		Response: api.OAuthError{
+			Error: oauthErr.Code,
+		},
+	}
+	if oauthErr.Description != "" {
+		res.Response.ErrorDescription = api.NewOptString(oauthErr.Description)
+	}
+	if oauthErr.Code == oautherror.InvalidToken {
+		// see https://datatracker.ietf.org/doc/html/rfc6750#section-3
+		res.WWWAuthenticate = api.NewOptString(fmt.Sprintf("Bearer error=%q", oauthErr.Code))
+	} else if oauthErr.StatusCode() == 401 && dispatcherauth.GetBasicAuth(ctx) != nil {
+		// the client tried to authenticate with the Authorization header
+		res.WWWAuthenticate = api.NewOptString("Basic")
 	}
+	return res
 }
@@ -1,7 +1,24 @@
 package oapidispatcher
 
 import (
+	"fmt"
+	"testing"
+
 	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
 )
 
 var _ api.Handler = (*oapiDispatcher)(nil)
+
+func TestNewErrorIsAnOAuthError(t *testing.T) {
+	dispatcher := &oapiDispatcher{}
+	res := dispatcher.NewError(t.Context(), oautherror.New(oautherror.InvalidRequest, "no such client: %v", "client"))
+	if res.StatusCode != 400 || res.Response.Error != oautherror.InvalidRequest || res.Response.ErrorDescription.Or("") != "no such client: client" {
+		t.Fatalf("unexpected error response: %+v", res)
+	}
+	// internal details aren't returned to the caller
+	res = dispatcher.NewError(t.Context(), fmt.Errorf("database unavailable"))
+	if res.StatusCode != 500 || res.Response.Error != oautherror.ServerError || res.Response.ErrorDescription.Set {
+		t.Fatalf("unexpected error response: %+v", res)
+	}
+}
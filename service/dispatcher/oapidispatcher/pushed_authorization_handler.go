@@ -0,0 +1,88 @@
+package oapidispatcher
+
+import (
+	"context"
+	"time"
+
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/params"
+)
+
+type pushedAuthorizationHandler struct {
+	DaoSource dao.DaoSource
+	Issuer    string
+}
+
+// ParPost implements [api.PushedAuthorizationHandler].
+// see https://datatracker.ietf.org/doc/html/rfc9126#section-2
+func (obj *pushedAuthorizationHandler) ParPost(ctx context.Context, req *api.PushedAuthorizationRequestBody) (api.ParPostRes, error) {
+	pushedRequest, err := obj.parPost(ctx, req)
+	if oauthErr, ok := oautherror.As(err); ok {
+		return oauthErrorResponse(ctx, oauthErr), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &api.PushedAuthorizationResponse{
+		RequestURI: pushedRequest.RequestUri,
+		ExpiresIn:  int64(time.Until(*pushedRequest.Expiry).Seconds()),
+	}, nil
+}
+
+func (obj *pushedAuthorizationHandler) parPost(ctx context.Context, req *api.PushedAuthorizationRequestBody) (*client.PushedAuthorizationRequest, error) {
+	credentials, err := clientCredentialsFromRequest(
+		ctx,
+		req.ClientID.Or(""),
+		req.ClientSecret.Or(""),
+		req.ClientAssertionType.Or(""),
+		req.ClientAssertion.Or(""),
+	)
+	if err != nil {
+		return nil, err
+	}
+	authenticatedClient, err := authenticateClient(ctx, obj.DaoSource, obj.Issuer, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	// the same checks as an inline authorization request, but the errors go straight back to the client
+	authRequest := &params.OidcAuthCodeFlowParams{
+		ResponseType:        req.ResponseType.Or(""),
+		ClientId:            authenticatedClient.ClientId,
+		Scope:               req.Scope.Or(""),
+		RedirectUri:         req.RedirectURI.Or(""),
+		State:               req.State.Or(""),
+		ResponseMode:        req.ResponseMode.Or(""),
+		Nonce:               req.Nonce.Or(""),
+		CodeChallenge:       req.CodeChallenge.Or(""),
+		CodeChallengeMethod: req.CodeChallengeMethod.Or(""),
+	}
+	if req.Request.Set {
+		err = applyRequestObject(ctx, obj.DaoSource, authenticatedClient, obj.Issuer, authRequest, req.Request.Value)
+		if err != nil {
+			return nil, err
+		}
+	} else if authenticatedClient.RequireSignedRequestObject {
+		return nil, oautherror.New(oautherror.InvalidRequest, "signed request object required for client: %v", authenticatedClient.ClientId)
+	}
+	if !authenticatedClient.IsValidRedirectUri(authRequest.RedirectUri) {
+		return nil, oautherror.New(oautherror.InvalidRequest, "invalid redirect uri: %v", authRequest.RedirectUri)
+	}
+	err = authenticatedClient.ValidateAuthorizeRequest(authRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	pushedRequest, err := client.NewPushedAuthorizationRequest(authenticatedClient.ClientId, authRequest.ToQueryParams())
+	if err != nil {
+		return nil, err
+	}
+	err = obj.DaoSource.GetPushedAuthorizationRequestStore(ctx).SavePushedAuthorizationRequest(ctx, pushedRequest)
+	if err != nil {
+		return nil, err
+	}
+	return pushedRequest, nil
+}
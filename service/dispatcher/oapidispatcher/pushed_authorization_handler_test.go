@@ -0,0 +1,176 @@
+package oapidispatcher
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/params"
+)
+
+var _ api.PushedAuthorizationHandler = (*pushedAuthorizationHandler)(nil)
+
+func TestPushedAuthorizationRequest(t *testing.T) {
+	ctx := t.Context()
+	daoSource := dao.NewMemoryDao()
+	oidcClient := &client.Client{
+		ClientId:                           uuid.NewString(),
+		ClientType:                         client.ClientTypeConfidential,
+		AllowedRedirectUris:                []string{"https://client/callback"},
+		RequirePushedAuthorizationRequests: true,
+	}
+	err := oidcClient.SetClientSecret("secret")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, oidcClient)
+
+	parHandler := &pushedAuthorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	authorizeHandler := authorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+
+	// the client must authenticate
+	res, err := parHandler.ParPost(basicAuthContext(ctx, oidcClient.ClientId, "wrong"), &api.PushedAuthorizationRequestBody{
+		ResponseType: api.NewOptString("code"),
+		Scope:        api.NewOptString("openid"),
+		RedirectURI:  api.NewOptString("https://client/callback"),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if unauthorized, ok := res.(*api.OAuthErrorHeaders); !ok || unauthorized.Response.Error != oautherror.InvalidClient {
+		t.Fatalf("expected invalid_client but got %+v", res)
+	}
+
+	// the full request is validated when it is pushed
+	res, err = parHandler.ParPost(basicAuthContext(ctx, oidcClient.ClientId, "secret"), &api.PushedAuthorizationRequestBody{
+		ResponseType: api.NewOptString("code"),
+		Scope:        api.NewOptString("openid"),
+		RedirectURI:  api.NewOptString("https://attacker/callback"),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if badRequest, ok := res.(*api.OAuthError); !ok || badRequest.Error != oautherror.InvalidRequest {
+		t.Fatalf("expected invalid_request but got %+v", res)
+	}
+
+	res, err = parHandler.ParPost(basicAuthContext(ctx, oidcClient.ClientId, "secret"), &api.PushedAuthorizationRequestBody{
+		ResponseType: api.NewOptString("code"),
+		Scope:        api.NewOptString("openid"),
+		RedirectURI:  api.NewOptString("https://client/callback"),
+		State:        api.NewOptString("a&b=c"),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	pushed, ok := res.(*api.PushedAuthorizationResponse)
+	if !ok {
+		t.Fatalf("expected a request uri but got %+v", res)
+	}
+	if !strings.HasPrefix(pushed.RequestURI, client.RequestUriPrefix) || pushed.ExpiresIn <= 0 {
+		t.Fatalf("unexpected pushed authorization response: %+v", pushed)
+	}
+	stored, err := daoSource.GetPushedAuthorizationRequestStore(ctx).GetPushedAuthorizationRequest(ctx, pushed.RequestURI)
+	if err != nil || stored == nil {
+		t.Fatalf("expected the request to be stored: %v", err)
+	}
+	storedParams, err := params.OidcParamsFromQuery(stored.OidcParams)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if storedParams.State != "a&b=c" || storedParams.RedirectUri != "https://client/callback" {
+		t.Fatalf("unexpected stored params: %+v", storedParams)
+	}
+
+	// inline requests are rejected for this client
+	authorizeRes, err := authorizeHandler.AuthorizeGet(ctx, api.AuthorizeGetParams{
+		ResponseType: api.NewOptString("code"),
+		ClientID:     oidcClient.ClientId,
+		Scope:        api.NewOptString("openid"),
+		RedirectURI:  api.NewOptString("https://client/callback"),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	found, ok := authorizeRes.(*api.AuthorizeGetFound)
+	if !ok || !strings.HasPrefix(found.Location, "https://client/callback?error=invalid_request") {
+		t.Fatalf("expected an invalid_request redirect but got %+v", authorizeRes)
+	}
+
+	// only the request_uri is passed on to the browser
+	authorizeRes, err = authorizeHandler.AuthorizeGet(ctx, api.AuthorizeGetParams{
+		ClientID:   oidcClient.ClientId,
+		RequestURI: api.NewOptString(pushed.RequestURI),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	found, ok = authorizeRes.(*api.AuthorizeGetFound)
+	if !ok {
+		t.Fatalf("expected a redirect but got %+v", authorizeRes)
+	}
+	expected := "/accept?" + url.Values{
+		"client_id":   {oidcClient.ClientId},
+		"request_uri": {pushed.RequestURI},
+	}.Encode()
+	if found.Location != expected {
+		t.Fatalf("unexpected redirect: %v", found.Location)
+	}
+
+	// one time use
+	_, err = authorizeHandler.AuthorizeGet(ctx, api.AuthorizeGetParams{
+		ClientID:   oidcClient.ClientId,
+		RequestURI: api.NewOptString(pushed.RequestURI),
+	})
+	if oauthErr, ok := oautherror.As(err); !ok || oauthErr.Code != oautherror.InvalidRequestUri {
+		t.Fatalf("expected invalid_request_uri but got %v", err)
+	}
+}
+
+func TestAuthorizeRedirectEscapesParams(t *testing.T) {
+	ctx := t.Context()
+	daoSource := dao.NewMemoryDao()
+	handler := authorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	oidcClient := &client.Client{
+		ClientId:            uuid.NewString(),
+		AllowedRedirectUris: []string{"https://client/callback"},
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, oidcClient)
+
+	res, err := handler.AuthorizeGet(ctx, api.AuthorizeGetParams{
+		ResponseType: api.NewOptString("code"),
+		ClientID:     oidcClient.ClientId,
+		Scope:        api.NewOptString("openid"),
+		RedirectURI:  api.NewOptString("https://client/callback"),
+		State:        api.NewOptString("xyz&redirect_uri=https://attacker/callback"),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	found, ok := res.(*api.AuthorizeGetFound)
+	if !ok {
+		t.Fatalf("expected a redirect but got %+v", res)
+	}
+	u, err := url.Parse(found.Location)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	accepted := params.OidcParamsFromMap(params.QueryParamsToMap(u))
+	if accepted.RedirectUri != "https://client/callback" || accepted.State != "xyz&redirect_uri=https://attacker/callback" {
+		t.Fatalf("the params were not escaped: %v", found.Location)
+	}
+}
@@ -0,0 +1,395 @@
+package oapidispatcher
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/dao/ddbutil"
+	"github.com/kncept-oauth/simple-oidc/service/dispatcherauth"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/scopes"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+)
+
+type registrationHandler struct {
+	DaoSource    dao.DaoSource
+	Issuer       string
+	Registration *client.RegistrationSettings
+}
+
+// RegisterClient implements [api.RegistrationHandler].
+// see https://datatracker.ietf.org/doc/html/rfc7591#section-3
+func (obj *registrationHandler) RegisterClient(ctx context.Context, req *api.ClientMetadata) (api.RegisterClientRes, error) {
+	res, err := obj.registerClient(ctx, req)
+	if oauthErr, ok := oautherror.As(err); ok {
+		return oauthErrorResponse(ctx, oauthErr), nil
+	}
+	return res, err
+}
+
+func (obj *registrationHandler) registerClient(ctx context.Context, req *api.ClientMetadata) (*api.ClientInformation, error) {
+	err := obj.Registration.AuthorizeRegistration(dispatcherauth.GetBearerAuth(ctx))
+	if err != nil {
+		return nil, err
+	}
+	// the client id is always assigned by the server
+	now := time.Now().UTC()
+	oidcClient := &client.Client{
+		ClientId:   uuid.NewString(),
+		Registered: &now,
+	}
+	err = applyClientMetadata(oidcClient, req)
+	if err != nil {
+		return nil, err
+	}
+	clientSecret, err := obj.issueClientSecret(oidcClient)
+	if err != nil {
+		return nil, err
+	}
+	registrationAccessToken, err := oidcClient.IssueRegistrationAccessToken()
+	if err != nil {
+		return nil, err
+	}
+	err = obj.DaoSource.GetClientStore(ctx).SaveClient(ctx, oidcClient)
+	if err != nil {
+		return nil, err
+	}
+	res := obj.clientInformation(oidcClient)
+	setOptString(&res.ClientSecret, clientSecret)
+	setOptString(&res.RegistrationAccessToken, registrationAccessToken)
+	return res, nil
+}
+
+// GetClientRegistration implements [api.RegistrationHandler].
+// see https://datatracker.ietf.org/doc/html/rfc7592#section-2.1
+func (obj *registrationHandler) GetClientRegistration(ctx context.Context, params api.GetClientRegistrationParams) (api.GetClientRegistrationRes, error) {
+	oidcClient, err := obj.registeredClient(ctx, params.ClientID)
+	if oauthErr, ok := oautherror.As(err); ok {
+		return oauthErrorResponse(ctx, oauthErr), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// only secret hashes are stored, so the secret can not be returned again
+	return obj.clientInformation(oidcClient), nil
+}
+
+// UpdateClientRegistration implements [api.RegistrationHandler].
+// the request replaces ALL of the client metadata
+// see https://datatracker.ietf.org/doc/html/rfc7592#section-2.2
+func (obj *registrationHandler) UpdateClientRegistration(ctx context.Context, req *api.ClientMetadata, params api.UpdateClientRegistrationParams) (api.UpdateClientRegistrationRes, error) {
+	res, err := obj.updateClientRegistration(ctx, req, params.ClientID)
+	if oauthErr, ok := oautherror.As(err); ok {
+		return oauthErrorResponse(ctx, oauthErr), nil
+	}
+	return res, err
+}
+
+func (obj *registrationHandler) updateClientRegistration(ctx context.Context, req *api.ClientMetadata, clientId string) (*api.ClientInformation, error) {
+	oidcClient, err := obj.registeredClient(ctx, clientId)
+	if err != nil {
+		return nil, err
+	}
+	if req.ClientID.Or("") != oidcClient.ClientId {
+		return nil, oautherror.New(oautherror.InvalidRequest, "client_id must match the registration")
+	}
+	err = applyClientMetadata(oidcClient, req)
+	if err != nil {
+		return nil, err
+	}
+	// eg: a public client that is now confidential
+	clientSecret, err := obj.issueClientSecret(oidcClient)
+	if err != nil {
+		return nil, err
+	}
+	err = obj.DaoSource.GetClientStore(ctx).UpdateClient(ctx, oidcClient)
+	if err != nil {
+		return nil, err
+	}
+	res := obj.clientInformation(oidcClient)
+	setOptString(&res.ClientSecret, clientSecret)
+	return res, nil
+}
+
+// DeleteClientRegistration implements [api.RegistrationHandler].
+// see https://datatracker.ietf.org/doc/html/rfc7592#section-2.3
+func (obj *registrationHandler) DeleteClientRegistration(ctx context.Context, params api.DeleteClientRegistrationParams) (api.DeleteClientRegistrationRes, error) {
+	oidcClient, err := obj.registeredClient(ctx, params.ClientID)
+	if oauthErr, ok := oautherror.As(err); ok {
+		return oauthErrorResponse(ctx, oauthErr), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = obj.revokeClientSessions(ctx, oidcClient.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	err = obj.DaoSource.GetClientStore(ctx).RemoveClient(ctx, oidcClient.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	return &api.DeleteClientRegistrationNoContent{}, nil
+}
+
+// a deleted client must not be able to keep using the grants it already has (eg: refresh tokens).
+// every session with a client is for a user that authorized it
+func (obj *registrationHandler) revokeClientSessions(ctx context.Context, clientId string) error {
+	clientAuthorizationStore := obj.DaoSource.GetClientAuthorizationStore(ctx)
+	clientAuthorizations := &ddbutil.DepaginatedScroller[client.ClientAuthorization]{}
+	err := clientAuthorizationStore.ClientAuthorizationsByClient(ctx, clientId, clientAuthorizations)
+	if err != nil {
+		return err
+	}
+	for _, clientAuthorization := range clientAuthorizations.Results {
+		_, err = session.RevokeUserClientSessions(ctx, obj.DaoSource.GetSessionStore(ctx), clientAuthorization.UserId, clientId)
+		if err != nil {
+			return err
+		}
+		err = clientAuthorizationStore.DeleteClientAuthorization(ctx, clientAuthorization.UserId, clientId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// the registration access token is the only way to manage a registration.
+// an unknown client is indistinguishable from an invalid token
+// see https://datatracker.ietf.org/doc/html/rfc7592#section-2
+func (obj *registrationHandler) registeredClient(ctx context.Context, clientId string) (*client.Client, error) {
+	registrationAccessToken := dispatcherauth.GetBearerAuth(ctx)
+	if registrationAccessToken == "" {
+		return nil, oautherror.New(oautherror.InvalidToken, "registration access token required")
+	}
+	oidcClient, err := obj.DaoSource.GetClientStore(ctx).GetClient(ctx, clientId)
+	if err != nil {
+		return nil, err
+	}
+	if oidcClient == nil || !oidcClient.RegistrationAccessTokenMatches(registrationAccessToken) {
+		return nil, oautherror.New(oautherror.InvalidToken, "invalid registration access token")
+	}
+	return oidcClient, nil
+}
+
+// confidential clients that authenticate with a client secret get a new one if they don't have one.
+// returns the raw secret, or "" if no secret was issued
+func (obj *registrationHandler) issueClientSecret(oidcClient *client.Client) (string, error) {
+	if !oidcClient.IsConfidential() {
+		oidcClient.ClientSecrets = nil
+		oidcClient.ClientSecretJwtKey = ""
+		return "", nil
+	}
+	// the client secret is the HMAC key (see https://datatracker.ietf.org/doc/html/rfc7591#section-3.2.1)
+	if oidcClient.TokenEndpointAuthMethod == client.TokenEndpointAuthMethodClientSecretJwt {
+		oidcClient.ClientSecrets = nil
+		if oidcClient.ClientSecretJwtKey != "" {
+			return "", nil
+		}
+		return oidcClient.GenerateClientSecretJwtKey()
+	}
+	oidcClient.ClientSecretJwtKey = ""
+	if oidcClient.TokenEndpointAuthMethod == client.TokenEndpointAuthMethodPrivateKeyJwt || len(oidcClient.ClientSecrets) != 0 {
+		return "", nil
+	}
+	clientSecret, err := client.GenerateClientSecret()
+	if err != nil {
+		return "", err
+	}
+	return clientSecret, oidcClient.SetClientSecret(clientSecret)
+}
+
+// validates the requested metadata, and replaces the client metadata with it.
+// administrator controlled settings (eg: machine access) are left alone
+// see https://datatracker.ietf.org/doc/html/rfc7591#section-2
+func applyClientMetadata(oidcClient *client.Client, req *api.ClientMetadata) error {
+	authMethod := req.TokenEndpointAuthMethod.Or(client.TokenEndpointAuthMethodClientSecretBasic)
+	if !slices.Contains(client.SupportedTokenEndpointAuthMethods, authMethod) {
+		return oautherror.New(oautherror.InvalidClientMetadata, "unsupported token_endpoint_auth_method: %v", authMethod)
+	}
+	jwksUri := req.JwksURI.Or("")
+	if jwksUri != "" {
+		if err := client.ValidateFetchedUri(jwksUri); err != nil {
+			return oautherror.Wrap(oautherror.InvalidClientMetadata, err)
+		}
+	}
+	if authMethod == client.TokenEndpointAuthMethodPrivateKeyJwt && jwksUri == "" {
+		return oautherror.New(oautherror.InvalidClientMetadata, "jwks_uri is required for private_key_jwt")
+	}
+	if req.RequireSignedRequestObject.Or(false) && jwksUri == "" {
+		return oautherror.New(oautherror.InvalidClientMetadata, "jwks_uri is required for require_signed_request_object")
+	}
+	// request objects are fetched by simple-oidc
+	for _, requestUri := range req.RequestUris {
+		if err := client.ValidateFetchedUri(requestUri); err != nil {
+			return oautherror.Wrap(oautherror.InvalidClientMetadata, err)
+		}
+	}
+
+	responseTypes := req.ResponseTypes
+	if len(responseTypes) == 0 {
+		responseTypes = []string{client.ResponseTypeCode}
+	}
+	for idx, responseType := range responseTypes {
+		responseTypes[idx] = client.NormalizeResponseType(responseType)
+		if !slices.Contains(client.SupportedResponseTypes, responseTypes[idx]) {
+			return oautherror.New(oautherror.InvalidClientMetadata, "unsupported response type: %v", responseType)
+		}
+	}
+
+	grantTypes := req.GrantTypes
+	if len(grantTypes) == 0 {
+		grantTypes = []string{client.GrantTypeAuthorizationCode}
+	}
+	for _, grantType := range grantTypes {
+		// machine access and token exchange are only ever granted by an administrator
+		if grantType == client.GrantTypeClientCredentials || grantType == client.GrantTypeTokenExchange || !slices.Contains(client.SupportedGrantTypes, grantType) {
+			return oautherror.New(oautherror.InvalidClientMetadata, "unsupported grant type: %v", grantType)
+		}
+	}
+	if slices.Contains(grantTypes, client.GrantTypeImplicit) && !slices.ContainsFunc(responseTypes, client.IsFrontChannelResponseType) {
+		return oautherror.New(oautherror.InvalidClientMetadata, "the implicit grant requires an implicit response type")
+	}
+
+	// only the device grant works without a redirect
+	needsRedirect := slices.ContainsFunc(grantTypes, func(grantType string) bool {
+		return grantType != client.GrantTypeDeviceCode && grantType != client.GrantTypeRefreshToken
+	})
+	if needsRedirect && len(req.RedirectUris) == 0 {
+		return oautherror.New(oautherror.InvalidRedirectUri, "redirect_uris required")
+	}
+	for _, redirectUri := range req.RedirectUris {
+		if err := client.ValidateRegisteredUri(redirectUri); err != nil {
+			return oautherror.Wrap(oautherror.InvalidRedirectUri, err)
+		}
+	}
+	for _, postLogoutRedirectUri := range req.PostLogoutRedirectUris {
+		if err := client.ValidateRegisteredUri(postLogoutRedirectUri); err != nil {
+			return oautherror.Wrap(oautherror.InvalidClientMetadata, err)
+		}
+	}
+	if backchannelLogoutUri := req.BackchannelLogoutURI.Or(""); backchannelLogoutUri != "" {
+		if err := client.ValidateFetchedUri(backchannelLogoutUri); err != nil {
+			return oautherror.Wrap(oautherror.InvalidClientMetadata, err)
+		}
+	}
+	// the front channel logout uri is loaded by the browser, not simple-oidc
+	if frontchannelLogoutUri := req.FrontchannelLogoutURI.Or(""); frontchannelLogoutUri != "" {
+		if err := validateHttpUri(frontchannelLogoutUri); err != nil {
+			return oautherror.Wrap(oautherror.InvalidClientMetadata, err)
+		}
+	}
+
+	oidcClient.TokenEndpointAuthMethod = authMethod
+	if authMethod == client.TokenEndpointAuthMethodNone {
+		oidcClient.ClientType = client.ClientTypePublic
+		// public clients can't keep a secret, so must use PKCE
+		oidcClient.RequirePkce = true
+	} else {
+		oidcClient.ClientType = client.ClientTypeConfidential
+	}
+	oidcClient.JwksUri = jwksUri
+	oidcClient.Jwks = nil
+	oidcClient.AllowedRedirectUris = req.RedirectUris
+	oidcClient.AllowRegexForRedirectUri = false
+	oidcClient.ExactRedirectUris = true
+	oidcClient.AllowedResponseTypes = responseTypes
+	oidcClient.GrantTypes = grantTypes
+	// no scopes would allow every scope, so a client that doesn't ask for any only gets openid
+	oidcClient.AllowedScopes = scopes.Parse(req.Scope.Or(""))
+	if len(oidcClient.AllowedScopes) == 0 {
+		oidcClient.AllowedScopes = []string{scopes.OpenId}
+	}
+	oidcClient.PostLogoutRedirectUris = req.PostLogoutRedirectUris
+	oidcClient.BackchannelLogoutUri = req.BackchannelLogoutURI.Or("")
+	oidcClient.FrontchannelLogoutUri = req.FrontchannelLogoutURI.Or("")
+	oidcClient.FrontchannelLogoutSessionRequired = req.FrontchannelLogoutSessionRequired.Or(false)
+	// see https://datatracker.ietf.org/doc/html/rfc9126#section-6
+	oidcClient.RequirePushedAuthorizationRequests = req.RequirePushedAuthorizationRequests.Or(false)
+	// see https://datatracker.ietf.org/doc/html/rfc9101#section-10.5
+	oidcClient.RequireSignedRequestObject = req.RequireSignedRequestObject.Or(false)
+	oidcClient.RequestUris = req.RequestUris
+	// see https://datatracker.ietf.org/doc/html/rfc9449#section-5.2
+	oidcClient.DPoPBoundAccessTokens = req.DpopBoundAccessTokens.Or(false)
+	oidcClient.PublicName = req.ClientName.Or("")
+	oidcClient.PublicWebsite = req.ClientURI.Or("")
+	return nil
+}
+
+// uris that the browser loads must be http(s)
+func validateHttpUri(uri string) error {
+	if !strings.HasPrefix(uri, "https://") && !strings.HasPrefix(uri, "http://") {
+		return fmt.Errorf("not an http uri: %v", uri)
+	}
+	return client.ValidateRegisteredUri(uri)
+}
+
+// the registered metadata, without any secrets
+// see https://datatracker.ietf.org/doc/html/rfc7591#section-3.2.1
+func (obj *registrationHandler) clientInformation(oidcClient *client.Client) *api.ClientInformation {
+	res := &api.ClientInformation{
+		ClientID:               oidcClient.ClientId,
+		RedirectUris:           oidcClient.AllowedRedirectUris,
+		GrantTypes:             registeredGrantTypes(oidcClient),
+		ResponseTypes:          oidcClient.AllowedResponseTypes,
+		PostLogoutRedirectUris: oidcClient.PostLogoutRedirectUris,
+		RequestUris:            oidcClient.RequestUris,
+	}
+	if oidcClient.Registered != nil {
+		res.ClientIDIssuedAt.SetTo(oidcClient.Registered.Unix())
+	}
+	if oidcClient.IsConfidential() && oidcClient.TokenEndpointAuthMethod != client.TokenEndpointAuthMethodPrivateKeyJwt {
+		// secrets don't expire until they are rotated
+		res.ClientSecretExpiresAt.SetTo(0)
+	}
+	res.RegistrationClientURI.SetTo(fmt.Sprintf("%v/connect/register/%v", obj.Issuer, oidcClient.ClientId))
+	setOptString(&res.TokenEndpointAuthMethod, oidcClient.TokenEndpointAuthMethod)
+	setOptString(&res.Scope, strings.Join(oidcClient.AllowedScopes, " "))
+	setOptString(&res.JwksURI, oidcClient.JwksUri)
+	setOptString(&res.BackchannelLogoutURI, oidcClient.BackchannelLogoutUri)
+	setOptString(&res.FrontchannelLogoutURI, oidcClient.FrontchannelLogoutUri)
+	setOptString(&res.ClientName, oidcClient.PublicName)
+	setOptString(&res.ClientURI, oidcClient.PublicWebsite)
+	if oidcClient.FrontchannelLogoutUri != "" {
+		res.FrontchannelLogoutSessionRequired.SetTo(oidcClient.FrontchannelLogoutSessionRequired)
+	}
+	if oidcClient.RequirePushedAuthorizationRequests {
+		res.RequirePushedAuthorizationRequests.SetTo(true)
+	}
+	if oidcClient.RequireSignedRequestObject {
+		res.RequireSignedRequestObject.SetTo(true)
+	}
+	if oidcClient.DPoPBoundAccessTokens {
+		res.DpopBoundAccessTokens.SetTo(true)
+	}
+	return res
+}
+
+// the registered grant types, or every grant the client is able to use if they aren't restricted
+func registeredGrantTypes(oidcClient *client.Client) []string {
+	if len(oidcClient.GrantTypes) != 0 {
+		return oidcClient.GrantTypes
+	}
+	grantTypes := []string{
+		client.GrantTypeAuthorizationCode,
+		client.GrantTypeRefreshToken,
+		client.GrantTypeDeviceCode,
+	}
+	if slices.ContainsFunc(oidcClient.AllowedResponseTypes, client.IsFrontChannelResponseType) {
+		grantTypes = append(grantTypes, client.GrantTypeImplicit)
+	}
+	if oidcClient.AllowsClientCredentials() {
+		grantTypes = append(grantTypes, client.GrantTypeClientCredentials)
+	}
+	if oidcClient.AllowsTokenExchange() {
+		grantTypes = append(grantTypes, client.GrantTypeTokenExchange)
+	}
+	return grantTypes
+}
@@ -0,0 +1,215 @@
+package oapidispatcher
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/dispatcherauth"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/scopes"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+)
+
+var _ api.RegistrationHandler = (*registrationHandler)(nil)
+
+func bearerAuthContext(ctx context.Context, token string) context.Context {
+	ctx, _ = (&dispatcherauth.Handler{}).HandleBearerAuth(ctx, api.GetClientRegistrationOperation, api.BearerAuth{
+		Token: token,
+	})
+	return ctx
+}
+
+func TestRegistrationPolicy(t *testing.T) {
+	ctx := t.Context()
+	metadata := &api.ClientMetadata{
+		RedirectUris: []string{"https://app.example.com/callback"},
+	}
+
+	closed := &registrationHandler{
+		DaoSource: dao.NewMemoryDao(),
+		Issuer:    testIssuer,
+	}
+	res, err := closed.RegisterClient(ctx, metadata)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if badRequest, ok := res.(*api.OAuthError); !ok || badRequest.Error != oautherror.AccessDenied {
+		t.Fatalf("expected access_denied but got %+v", res)
+	}
+
+	tokenRequired := &registrationHandler{
+		DaoSource: dao.NewMemoryDao(),
+		Issuer:    testIssuer,
+		Registration: &client.RegistrationSettings{
+			Policy:             client.RegistrationPolicyInitialAccessToken,
+			InitialAccessToken: "initial",
+		},
+	}
+	res, err = tokenRequired.RegisterClient(ctx, metadata)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	unauthorized, ok := res.(*api.OAuthErrorHeaders)
+	if !ok || unauthorized.Response.Error != oautherror.InvalidToken || unauthorized.WWWAuthenticate.Or("") != `Bearer error="invalid_token"` {
+		t.Fatalf("expected invalid_token but got %+v", res)
+	}
+	res, err = tokenRequired.RegisterClient(bearerAuthContext(ctx, "initial"), metadata)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, ok := res.(*api.ClientInformation); !ok {
+		t.Fatalf("expected a registered client but got %+v", res)
+	}
+}
+
+func TestRegistrationManagement(t *testing.T) {
+	ctx := t.Context()
+	daoSource := dao.NewMemoryDao()
+	handler := &registrationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+		Registration: &client.RegistrationSettings{
+			Policy: client.RegistrationPolicyOpen,
+		},
+	}
+
+	res, err := handler.RegisterClient(ctx, &api.ClientMetadata{
+		RedirectUris: []string{"http://app.example.com/callback"},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if badRequest, ok := res.(*api.OAuthError); !ok || badRequest.Error != oautherror.InvalidRedirectUri {
+		t.Fatalf("expected invalid_redirect_uri but got %+v", res)
+	}
+
+	// simple-oidc must not be made to call its own (or internal) addresses
+	res, err = handler.RegisterClient(ctx, &api.ClientMetadata{
+		RedirectUris: []string{"https://app.example.com/callback"},
+		JwksURI:      api.NewOptString("http://127.0.0.1:8080/jwks.json"),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if badRequest, ok := res.(*api.OAuthError); !ok || badRequest.Error != oautherror.InvalidClientMetadata {
+		t.Fatalf("expected invalid_client_metadata but got %+v", res)
+	}
+
+	res, err = handler.RegisterClient(ctx, &api.ClientMetadata{
+		RedirectUris: []string{"https://app.example.com/callback"},
+		ClientName:   api.NewOptString("Example App"),
+		Scope:        api.NewOptString("openid profile"),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	registered, ok := res.(*api.ClientInformation)
+	if !ok {
+		t.Fatalf("expected a registered client but got %+v", res)
+	}
+	if !slices.Equal(registered.GrantTypes, []string{client.GrantTypeAuthorizationCode}) {
+		t.Fatalf("expected only the default grant type, but got %v", registered.GrantTypes)
+	}
+	if registered.TokenEndpointAuthMethod.Or("") != client.TokenEndpointAuthMethodClientSecretBasic {
+		t.Fatalf("expected the default auth method, but got %v", registered.TokenEndpointAuthMethod)
+	}
+	if registered.RegistrationClientURI.Or("") != testIssuer+"/connect/register/"+registered.ClientID {
+		t.Fatalf("unexpected registration client uri: %v", registered.RegistrationClientURI)
+	}
+	oidcClient, err := daoSource.GetClientStore(ctx).GetClient(ctx, registered.ClientID)
+	if err != nil || oidcClient == nil {
+		t.Fatalf("client was not saved: %v", err)
+	}
+	if !oidcClient.IsConfidential() || !oidcClient.ClientSecretMatches(registered.ClientSecret.Or("")) {
+		t.Fatalf("expected a confidential client with the issued secret")
+	}
+	if !oidcClient.ExactRedirectUris || !oidcClient.IsValidRedirectUri("https://app.example.com/callback") || oidcClient.IsValidRedirectUri("https://app.example.com/callback/other") {
+		t.Fatalf("registered redirect uris must match exactly")
+	}
+
+	registrationCtx := bearerAuthContext(ctx, registered.RegistrationAccessToken.Or(""))
+	getRes, err := handler.GetClientRegistration(bearerAuthContext(ctx, "wrong"), api.GetClientRegistrationParams{ClientID: registered.ClientID})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, ok := getRes.(*api.OAuthErrorHeaders); !ok {
+		t.Fatalf("expected the wrong token to be rejected, but got %+v", getRes)
+	}
+	getRes, err = handler.GetClientRegistration(registrationCtx, api.GetClientRegistrationParams{ClientID: registered.ClientID})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	current, ok := getRes.(*api.ClientInformation)
+	if !ok || current.ClientName.Or("") != "Example App" || current.ClientSecret.Set || current.RegistrationAccessToken.Set {
+		t.Fatalf("expected the registered metadata without secrets, but got %+v", getRes)
+	}
+
+	// now a public (native) client
+	updateRes, err := handler.UpdateClientRegistration(registrationCtx, &api.ClientMetadata{
+		ClientID:                api.NewOptString(registered.ClientID),
+		RedirectUris:            []string{"com.example.app:/callback"},
+		TokenEndpointAuthMethod: api.NewOptString(client.TokenEndpointAuthMethodNone),
+	}, api.UpdateClientRegistrationParams{ClientID: registered.ClientID})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, ok := updateRes.(*api.ClientInformation); !ok {
+		t.Fatalf("expected the updated client but got %+v", updateRes)
+	}
+	oidcClient, _ = daoSource.GetClientStore(ctx).GetClient(ctx, registered.ClientID)
+	if oidcClient.IsConfidential() || len(oidcClient.ClientSecrets) != 0 || !oidcClient.RequirePkce || oidcClient.PublicName != "" {
+		t.Fatalf("expected the metadata to be replaced: %+v", oidcClient)
+	}
+	if !slices.Equal(oidcClient.AllowedScopes, []string{scopes.OpenId}) {
+		t.Fatalf("expected only the openid scope without a requested scope, but got %v", oidcClient.AllowedScopes)
+	}
+
+	// only the registered grant types may be used
+	tokenRes, err := (&authorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}).TokenPost(ctx, &api.TokenPostApplicationXWwwFormUrlencoded{
+		GrantType:  api.NewOptString(client.GrantTypeDeviceCode),
+		ClientID:   api.NewOptString(registered.ClientID),
+		DeviceCode: api.NewOptString("device-code"),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if badRequest, ok := tokenRes.(*api.TokenPostBadRequest); !ok || badRequest.Response.Error != oautherror.UnauthorizedClient {
+		t.Fatalf("expected unauthorized_client but got %+v", tokenRes)
+	}
+
+	// deleting the client ends the sessions that users have with it
+	ses, err := session.NewSession("username", registered.ClientID)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	daoSource.GetSessionStore(ctx).SaveSession(ctx, ses)
+	daoSource.GetClientAuthorizationStore(ctx).SaveClientAuthorization(ctx, &client.ClientAuthorization{
+		UserId:   ses.UserId,
+		ClientId: registered.ClientID,
+	})
+	deleteRes, err := handler.DeleteClientRegistration(registrationCtx, api.DeleteClientRegistrationParams{ClientID: registered.ClientID})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, ok := deleteRes.(*api.DeleteClientRegistrationNoContent); !ok {
+		t.Fatalf("expected the client to be deleted, but got %+v", deleteRes)
+	}
+	oidcClient, _ = daoSource.GetClientStore(ctx).GetClient(ctx, registered.ClientID)
+	if oidcClient != nil {
+		t.Fatalf("client was not deleted")
+	}
+	ses, err = daoSource.GetSessionStore(ctx).LoadSession(ctx, ses.SessionId, ses.UserId)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if ses.IsActive() {
+		t.Fatalf("expected the client's sessions to be revoked")
+	}
+}
@@ -0,0 +1,94 @@
+package oapidispatcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/netutil"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/params"
+)
+
+// verifies the request object with the client's public keys, then replaces the request with its claims.
+// only the signed claims are used, any (unsigned) query parameters are ignored.
+// a request object with a jti can only be used once
+// see https://datatracker.ietf.org/doc/html/rfc9101#section-6.3
+func applyRequestObject(ctx context.Context, daoSource dao.DaoSource, oidcClient *client.Client, issuer string, authRequest *params.OidcAuthCodeFlowParams, requestObject string) error {
+	if strings.HasPrefix(jwtutil.JwtAlgorithm(requestObject), "HS") {
+		return oautherror.New(oautherror.InvalidRequestObject, "symmetric signing algorithms are not supported")
+	}
+	jwks, err := oidcClient.PublicKeys(ctx)
+	if err != nil {
+		return oautherror.Wrap(oautherror.InvalidRequestObject, err)
+	}
+	claims := &jwtutil.RequestObjectClaims{}
+	err = jwtutil.JwtToClaimsWithJwks(requestObject, jwks, claims)
+	if err != nil {
+		return oautherror.Wrap(oautherror.InvalidRequestObject, err)
+	}
+	err = claims.Verify(oidcClient.ClientId, issuer)
+	if err != nil {
+		return oautherror.New(oautherror.InvalidRequestObject, "invalid request object claim: %v", err)
+	}
+	if claims.Jti != "" {
+		err = jwtutil.RecordJti(
+			ctx,
+			daoSource.GetJtiStore(ctx),
+			fmt.Sprintf("request-object:%v", oidcClient.ClientId),
+			claims.Jti,
+			time.Unix(claims.Exp, 0),
+		)
+		if err != nil {
+			return oautherror.Wrap(oautherror.InvalidRequestObject, err)
+		}
+	}
+
+	*authRequest = params.OidcAuthCodeFlowParams{
+		ResponseType:        claims.ResponseType,
+		ClientId:            claims.ClientId,
+		Scope:               claims.Scope,
+		RedirectUri:         claims.RedirectUri,
+		State:               claims.State,
+		ResponseMode:        claims.ResponseMode,
+		Nonce:               claims.Nonce,
+		CodeChallenge:       claims.CodeChallenge,
+		CodeChallengeMethod: claims.CodeChallengeMethod,
+	}
+	return nil
+}
+
+// request objects passed by reference are only fetched from the client's registered request uris
+// see https://datatracker.ietf.org/doc/html/rfc9101#section-5.2.3
+func fetchRequestObject(ctx context.Context, oidcClient *client.Client, requestUri string) (string, error) {
+	if !slices.Contains(oidcClient.RequestUris, requestUri) {
+		return "", oautherror.New(oautherror.InvalidRequestUri, "request_uri not registered for client: %v", requestUri)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUri, nil)
+	if err != nil {
+		return "", oautherror.Wrap(oautherror.InvalidRequestUri, err)
+	}
+	req.Header.Add("Accept", "application/oauth-authz-req+jwt")
+	res, err := netutil.FetchClient.Do(req)
+	if err != nil {
+		return "", oautherror.Wrap(oautherror.InvalidRequestUri, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", oautherror.Wrap(oautherror.InvalidRequestUri, fmt.Errorf("unable to fetch request object from %v: %v", requestUri, res.StatusCode))
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, 64*1024))
+	if err != nil {
+		return "", oautherror.Wrap(oautherror.InvalidRequestUri, err)
+	}
+	return strings.TrimSpace(string(body)), nil
+}
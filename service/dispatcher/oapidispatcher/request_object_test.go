@@ -0,0 +1,226 @@
+package oapidispatcher
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	cjwt "github.com/cristalhq/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/keys"
+	"github.com/kncept-oauth/simple-oidc/service/netutil"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/params"
+)
+
+func TestSignedRequestObject(t *testing.T) {
+	ctx := t.Context()
+	daoSource := dao.NewMemoryDao()
+	handler := authorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	keyId := uuid.NewString()
+	oidcClient := &client.Client{
+		ClientId:            uuid.NewString(),
+		AllowedRedirectUris: []string{"https://client/callback"},
+		Jwks: &keys.JwkSet{
+			Keys: []*keys.JwkDetails{
+				keys.JwkFromEcDSA(keyId, &privateKey.PublicKey),
+			},
+		},
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, oidcClient)
+
+	signer, err := cjwt.NewSignerES(cjwt.ES256, privateKey)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	newRequestObject := func(signer cjwt.Signer, claims *jwtutil.RequestObjectClaims) string {
+		token, err := cjwt.NewBuilder(signer, cjwt.WithKeyID(keyId)).Build(claims)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return token.String()
+	}
+	requestClaims := &jwtutil.RequestObjectClaims{
+		Iss:          oidcClient.ClientId,
+		Aud:          []string{testIssuer},
+		Exp:          time.Now().Add(time.Minute).Unix(),
+		ResponseType: "code",
+		ClientId:     oidcClient.ClientId,
+		Scope:        "openid",
+		RedirectUri:  "https://client/callback",
+		State:        "signed-state",
+	}
+	requestObject := newRequestObject(signer, requestClaims)
+
+	// only the signed claims are used, unsigned query parameters can't fill the gaps
+	res, err := handler.AuthorizeGet(ctx, api.AuthorizeGetParams{
+		ResponseType: api.NewOptString("code"),
+		ClientID:     oidcClient.ClientId,
+		Scope:        api.NewOptString("openid"),
+		State:        api.NewOptString("unsigned-state"),
+		Nonce:        api.NewOptString("unsigned-nonce"),
+		Request:      api.NewOptString(requestObject),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	accepted := acceptedRequest(t, daoSource, res)
+	if accepted.State != "signed-state" || accepted.RedirectUri != "https://client/callback" {
+		t.Fatalf("expected the signed claims to be used: %+v", accepted)
+	}
+	if accepted.Nonce != "" {
+		t.Fatalf("expected the query parameters to be ignored: %+v", accepted)
+	}
+
+	// an expiry is required
+	noExpiry := *requestClaims
+	noExpiry.Exp = 0
+	_, err = handler.AuthorizeGet(ctx, api.AuthorizeGetParams{
+		ClientID: oidcClient.ClientId,
+		Request:  api.NewOptString(newRequestObject(signer, &noExpiry)),
+	})
+	if oauthErr, ok := oautherror.As(err); !ok || oauthErr.Code != oautherror.InvalidRequestObject {
+		t.Fatalf("expected invalid_request_object but got %v", err)
+	}
+
+	// a request object with a jti can't be replayed
+	withJti := *requestClaims
+	withJti.Jti = uuid.NewString()
+	singleUse := newRequestObject(signer, &withJti)
+	res, err = handler.AuthorizeGet(ctx, api.AuthorizeGetParams{
+		ClientID: oidcClient.ClientId,
+		Request:  api.NewOptString(singleUse),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	acceptedRequest(t, daoSource, res)
+	_, err = handler.AuthorizeGet(ctx, api.AuthorizeGetParams{
+		ClientID: oidcClient.ClientId,
+		Request:  api.NewOptString(singleUse),
+	})
+	if oauthErr, ok := oautherror.As(err); !ok || oauthErr.Code != oautherror.InvalidRequestObject {
+		t.Fatalf("expected invalid_request_object but got %v", err)
+	}
+
+	// signed by someone else
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	otherSigner, err := cjwt.NewSignerES(cjwt.ES256, otherKey)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, err = handler.AuthorizeGet(ctx, api.AuthorizeGetParams{
+		ClientID: oidcClient.ClientId,
+		Request:  api.NewOptString(newRequestObject(otherSigner, requestClaims)),
+	})
+	if oauthErr, ok := oautherror.As(err); !ok || oauthErr.Code != oautherror.InvalidRequestObject {
+		t.Fatalf("expected invalid_request_object but got %v", err)
+	}
+
+	// intended for another authorization server
+	wrongAudience := *requestClaims
+	wrongAudience.Aud = []string{"https://other.example.com"}
+	_, err = handler.AuthorizeGet(ctx, api.AuthorizeGetParams{
+		ClientID: oidcClient.ClientId,
+		Request:  api.NewOptString(newRequestObject(signer, &wrongAudience)),
+	})
+	if oauthErr, ok := oautherror.As(err); !ok || oauthErr.Code != oautherror.InvalidRequestObject {
+		t.Fatalf("expected invalid_request_object but got %v", err)
+	}
+
+	// by reference, from a registered request uri only
+	requestUriServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Add("Content-Type", "application/oauth-authz-req+jwt")
+		res.Write([]byte(requestObject))
+	}))
+	defer requestUriServer.Close()
+	// the test server is on loopback, which is otherwise refused
+	fetchClient := netutil.FetchClient
+	netutil.FetchClient = netutil.NewFetchClient(func(addr netip.Addr) bool { return true })
+	t.Cleanup(func() { netutil.FetchClient = fetchClient })
+	_, err = handler.AuthorizeGet(ctx, api.AuthorizeGetParams{
+		ClientID:   oidcClient.ClientId,
+		RequestURI: api.NewOptString(requestUriServer.URL),
+	})
+	if oauthErr, ok := oautherror.As(err); !ok || oauthErr.Code != oautherror.InvalidRequestUri {
+		t.Fatalf("expected invalid_request_uri but got %v", err)
+	}
+	oidcClient.RequestUris = []string{requestUriServer.URL}
+	oidcClient.RequireSignedRequestObject = true
+	daoSource.GetClientStore(ctx).UpdateClient(ctx, oidcClient)
+	res, err = handler.AuthorizeGet(ctx, api.AuthorizeGetParams{
+		ClientID:   oidcClient.ClientId,
+		Nonce:      api.NewOptString("unsigned-nonce"),
+		RequestURI: api.NewOptString(requestUriServer.URL),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	accepted = acceptedRequest(t, daoSource, res)
+	// only the signed claims are used
+	if accepted.State != "signed-state" || accepted.Nonce != "" {
+		t.Fatalf("expected only the signed claims to be used: %+v", accepted)
+	}
+
+	// unsigned requests are rejected
+	res, err = handler.AuthorizeGet(ctx, api.AuthorizeGetParams{
+		ResponseType: api.NewOptString("code"),
+		ClientID:     oidcClient.ClientId,
+		Scope:        api.NewOptString("openid"),
+		RedirectURI:  api.NewOptString("https://client/callback"),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	found, ok := res.(*api.AuthorizeGetFound)
+	if !ok || !strings.HasPrefix(found.Location, "https://client/callback?error=invalid_request") {
+		t.Fatalf("expected an invalid_request redirect but got %+v", res)
+	}
+}
+
+// signed requests are kept server side, the same as a pushed request
+func acceptedRequest(t *testing.T, daoSource dao.DaoSource, res api.AuthorizeGetRes) *params.OidcAuthCodeFlowParams {
+	ctx := t.Context()
+	found, ok := res.(*api.AuthorizeGetFound)
+	if !ok {
+		t.Fatalf("expected a redirect but got %+v", res)
+	}
+	u, err := url.Parse(found.Location)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if u.Path != "/accept" || !strings.HasPrefix(u.Query().Get("request_uri"), client.RequestUriPrefix) {
+		t.Fatalf("expected only a request uri but got %v", found.Location)
+	}
+	stored, err := daoSource.GetPushedAuthorizationRequestStore(ctx).GetPushedAuthorizationRequest(ctx, u.Query().Get("request_uri"))
+	if err != nil || stored == nil || !stored.IsStarted() {
+		t.Fatalf("expected a started request: %v", err)
+	}
+	accepted, err := params.OidcParamsFromQuery(stored.OidcParams)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return accepted
+}
@@ -0,0 +1,46 @@
+package oapidispatcher
+
+import (
+	"context"
+
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/tokens"
+)
+
+type revocationHandler struct {
+	DaoSource dao.DaoSource
+	Issuer    string
+}
+
+// RevokePost implements [api.RevocationHandler].
+// see https://datatracker.ietf.org/doc/html/rfc7009
+func (obj *revocationHandler) RevokePost(ctx context.Context, req *api.RevocationRequestBody) (api.RevokePostRes, error) {
+	credentials, err := clientCredentialsFromRequest(
+		ctx,
+		req.ClientID.Or(""),
+		req.ClientSecret.Or(""),
+		req.ClientAssertionType.Or(""),
+		req.ClientAssertion.Or(""),
+	)
+	if err == nil {
+		authenticatedClient, authErr := authenticateClient(ctx, obj.DaoSource, obj.Issuer, credentials)
+		if authErr == nil {
+			// the token type is determined from the token, so the token_type_hint is not needed
+			err = (&tokens.TokenService{
+				DaoSource: obj.DaoSource,
+				Issuer:    obj.Issuer,
+			}).RevokeToken(ctx, req.Token, authenticatedClient)
+		} else {
+			err = authErr
+		}
+	}
+	if oauthErr, ok := oautherror.As(err); ok {
+		return oauthErrorResponse(ctx, oauthErr), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &api.RevokePostOK{}, nil
+}
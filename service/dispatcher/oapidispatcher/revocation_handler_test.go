@@ -0,0 +1,109 @@
+package oapidispatcher
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+	"github.com/kncept-oauth/simple-oidc/service/tokens"
+	"github.com/kncept-oauth/simple-oidc/service/users"
+)
+
+var _ api.RevocationHandler = (*revocationHandler)(nil)
+
+func TestRevokePostRevokesSession(t *testing.T) {
+	ctx := t.Context()
+	daoSource, _ := newSigningDaoSource(t)
+	user := &users.OidcUser{
+		Id: "username",
+	}
+	daoSource.GetUserStore(ctx).SaveUser(ctx, user)
+	oidcClient := &client.Client{
+		ClientId: uuid.NewString(),
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, oidcClient)
+	otherClient := &client.Client{
+		ClientId: uuid.NewString(),
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, otherClient)
+
+	tokenService := &tokens.TokenService{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	handler := &revocationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	issue := func() (*session.Session, *tokens.IssuedTokens) {
+		ses, err := session.NewSession(user.Id, oidcClient.ClientId)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		ses.Scope = "openid"
+		issued, err := tokenService.IssueTokens(ctx, ses, oidcClient, tokens.IssueOptions{RefreshToken: true})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return ses, issued
+	}
+	revoke := func(token string, clientId string) api.RevokePostRes {
+		res, err := handler.RevokePost(ctx, &api.RevocationRequestBody{
+			Token:    token,
+			ClientID: api.NewOptString(clientId),
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return res
+	}
+	isActive := func(ses *session.Session) bool {
+		loaded, err := daoSource.GetSessionStore(ctx).LoadSession(ctx, ses.SessionId, ses.UserId)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return loaded.IsActive()
+	}
+
+	ses, issued := issue()
+	res := revoke(issued.RefreshToken, otherClient.ClientId)
+	if oauthErr, ok := res.(*api.OAuthError); !ok || oauthErr.Error != oautherror.UnauthorizedClient {
+		t.Fatalf("tokens may only be revoked by the client they were issued to: %#v", res)
+	}
+	if !isActive(ses) {
+		t.Fatalf("session should not be revoked by another client")
+	}
+	if _, ok := revoke(issued.RefreshToken, oidcClient.ClientId).(*api.RevokePostOK); !ok {
+		t.Fatalf("expected refresh token to be revoked")
+	}
+	if isActive(ses) {
+		t.Fatalf("session should be revoked with the refresh token")
+	}
+
+	ses, issued = issue()
+	if _, ok := revoke(issued.AccessToken, oidcClient.ClientId).(*api.RevokePostOK); !ok {
+		t.Fatalf("expected access token to be revoked")
+	}
+	if isActive(ses) {
+		t.Fatalf("session should be revoked with the access token")
+	}
+	revoked, err := tokenService.IsAccessTokenRevoked(ctx, issued.AccessTokenClaims)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !revoked {
+		t.Fatalf("access token jti should be recorded until it expires")
+	}
+
+	// invalid tokens are not an error
+	if _, ok := revoke("not-a-token", oidcClient.ClientId).(*api.RevokePostOK); !ok {
+		t.Fatalf("invalid tokens should be ignored")
+	}
+	res = revoke("not-a-token", "")
+	if oauthErr, ok := res.(*api.OAuthErrorHeaders); !ok || oauthErr.Response.Error != oautherror.InvalidClient {
+		t.Fatalf("the client must be authenticated: %#v", res)
+	}
+}
@@ -0,0 +1,239 @@
+package oapidispatcher
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	cjwt "github.com/cristalhq/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/audit"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/keys"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+	"github.com/kncept-oauth/simple-oidc/service/tokens"
+	"github.com/kncept-oauth/simple-oidc/service/users"
+)
+
+func TestTokenExchange(t *testing.T) {
+	ctx := t.Context()
+	daoSource, _ := newSigningDaoSource(t)
+	tokenService := &tokens.TokenService{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	handler := &authorizationHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+
+	userClient := &client.Client{
+		ClientId: uuid.NewString(),
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, userClient)
+	accessTokenFor := func(userId string, tokenClient *client.Client) string {
+		daoSource.GetUserStore(ctx).SaveUser(ctx, &users.OidcUser{
+			Id: userId,
+		})
+		ses, err := session.NewSession(userId, tokenClient.ClientId)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		ses.Scope = "openid profile"
+		issued, err := tokenService.IssueTokens(ctx, ses, tokenClient, tokens.IssueOptions{})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return issued.AccessToken
+	}
+	newExchangeClient := func(policy *client.TokenExchangePolicy) *client.Client {
+		exchangeClient := &client.Client{
+			ClientId:            uuid.NewString(),
+			ClientType:          client.ClientTypeConfidential,
+			TokenExchangePolicy: policy,
+		}
+		err := exchangeClient.SetClientSecret("secret")
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		daoSource.GetClientStore(ctx).SaveClient(ctx, exchangeClient)
+		return exchangeClient
+	}
+	exchange := func(exchangeClient *client.Client, req *api.TokenPostApplicationXWwwFormUrlencoded) api.TokenPostRes {
+		req.GrantType = api.NewOptString(client.GrantTypeTokenExchange)
+		res, err := handler.TokenPost(basicAuthContext(ctx, exchangeClient.ClientId, "secret"), req)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return res
+	}
+	expectError := func(res api.TokenPostRes, code string) {
+		t.Helper()
+		if badRequest, ok := res.(*api.TokenPostBadRequest); !ok || badRequest.Response.Error != code {
+			t.Fatalf("expected %v but got %+v", code, res)
+		}
+	}
+	exchangedClaims := func(res api.TokenPostRes) *jwtutil.AccessToken {
+		t.Helper()
+		loginTokens, ok := res.(*api.LoginTokensHeaders)
+		if !ok || loginTokens.Response.IssuedTokenType.Or("") != jwtutil.TokenTypeAccessToken {
+			t.Fatalf("expected an exchanged access token but got %+v", res)
+		}
+		claims, err := jwtutil.ParseAccessToken(ctx, loginTokens.Response.AccessToken, daoSource.GetKeyStore(ctx), testIssuer, "https://api.example.com")
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return claims
+	}
+
+	// a backend for frontend swaps the user's token for a downstream audience
+	bff := newExchangeClient(&client.TokenExchangePolicy{
+		Audiences: []string{"https://api.example.com"},
+		Subjects:  []string{client.TokenExchangeAnySubject},
+	})
+	subjectToken := accessTokenFor("user", bff)
+	claims := exchangedClaims(exchange(bff, &api.TokenPostApplicationXWwwFormUrlencoded{
+		SubjectToken:     api.NewOptString(subjectToken),
+		SubjectTokenType: api.NewOptString(jwtutil.TokenTypeAccessToken),
+		Scope:            api.NewOptString("profile"),
+	}))
+	if claims.Sub != "user" || claims.ClientId != bff.ClientId || claims.Scope != "profile" || claims.Act != nil {
+		t.Fatalf("unexpected exchanged token: %+v", claims)
+	}
+	expectError(exchange(bff, &api.TokenPostApplicationXWwwFormUrlencoded{
+		SubjectToken:     api.NewOptString(subjectToken),
+		SubjectTokenType: api.NewOptString(jwtutil.TokenTypeAccessToken),
+		Audience:         api.NewOptString("https://other.example.com"),
+	}), oautherror.InvalidTarget)
+	expectError(exchange(bff, &api.TokenPostApplicationXWwwFormUrlencoded{
+		SubjectToken:     api.NewOptString(subjectToken),
+		SubjectTokenType: api.NewOptString(jwtutil.TokenTypeAccessToken),
+		Scope:            api.NewOptString("openid email"),
+	}), oautherror.InvalidScope)
+	expectError(exchange(newExchangeClient(nil), &api.TokenPostApplicationXWwwFormUrlencoded{
+		SubjectToken:     api.NewOptString(subjectToken),
+		SubjectTokenType: api.NewOptString(jwtutil.TokenTypeAccessToken),
+	}), oautherror.UnauthorizedClient)
+	// a token issued to another client can't be exchanged
+	expectError(exchange(bff, &api.TokenPostApplicationXWwwFormUrlencoded{
+		SubjectToken:     api.NewOptString(accessTokenFor("user", userClient)),
+		SubjectTokenType: api.NewOptString(jwtutil.TokenTypeAccessToken),
+	}), oautherror.InvalidGrant)
+	// the bff has no allowed actors
+	expectError(exchange(bff, &api.TokenPostApplicationXWwwFormUrlencoded{
+		SubjectToken:     api.NewOptString(subjectToken),
+		SubjectTokenType: api.NewOptString(jwtutil.TokenTypeAccessToken),
+		ActorToken:       api.NewOptString(accessTokenFor("service", bff)),
+		ActorTokenType:   api.NewOptString(jwtutil.TokenTypeAccessToken),
+	}), oautherror.UnauthorizedClient)
+
+	// support staff impersonate a user, asserted by the support tool
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	supportTool := newExchangeClient(&client.TokenExchangePolicy{
+		Audiences: []string{"https://api.example.com"},
+		Subjects:  []string{"user"},
+		Actors:    []string{"support-staff", "service"},
+	})
+	supportTool.Jwks = &keys.JwkSet{
+		Keys: []*keys.JwkDetails{
+			keys.JwkFromEcDSA("support", &privateKey.PublicKey),
+		},
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, supportTool)
+	signer, err := cjwt.NewSignerES(cjwt.ES256, privateKey)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assertSubject := func(subject string) string {
+		token, err := cjwt.NewBuilder(signer, cjwt.WithKeyID("support")).Build(&jwtutil.SubjectAssertionClaims{
+			Iss: supportTool.ClientId,
+			Sub: subject,
+			Aud: []string{testIssuer},
+			Exp: time.Now().Add(time.Minute).Unix(),
+			Jti: uuid.NewString(),
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return token.String()
+	}
+	staffToken := accessTokenFor("support-staff", supportTool)
+	// the actor must be logged in to the support tool, and allowed to act
+	expectError(exchange(supportTool, &api.TokenPostApplicationXWwwFormUrlencoded{
+		SubjectToken:     api.NewOptString(assertSubject("user")),
+		SubjectTokenType: api.NewOptString(jwtutil.TokenTypeJwt),
+		ActorToken:       api.NewOptString(accessTokenFor("support-staff", userClient)),
+		ActorTokenType:   api.NewOptString(jwtutil.TokenTypeAccessToken),
+		Scope:            api.NewOptString("openid"),
+	}), oautherror.InvalidGrant)
+	expectError(exchange(supportTool, &api.TokenPostApplicationXWwwFormUrlencoded{
+		SubjectToken:     api.NewOptString(assertSubject("user")),
+		SubjectTokenType: api.NewOptString(jwtutil.TokenTypeJwt),
+		ActorToken:       api.NewOptString(accessTokenFor("other-staff", supportTool)),
+		ActorTokenType:   api.NewOptString(jwtutil.TokenTypeAccessToken),
+		Scope:            api.NewOptString("openid"),
+	}), oautherror.UnauthorizedClient)
+	assertion := assertSubject("user")
+	expectError(exchange(supportTool, &api.TokenPostApplicationXWwwFormUrlencoded{
+		SubjectToken:     api.NewOptString(assertion),
+		SubjectTokenType: api.NewOptString(jwtutil.TokenTypeJwt),
+		Scope:            api.NewOptString("openid"),
+	}), oautherror.InvalidRequest)
+	impersonation := exchange(supportTool, &api.TokenPostApplicationXWwwFormUrlencoded{
+		SubjectToken:     api.NewOptString(assertion),
+		SubjectTokenType: api.NewOptString(jwtutil.TokenTypeJwt),
+		ActorToken:       api.NewOptString(staffToken),
+		ActorTokenType:   api.NewOptString(jwtutil.TokenTypeAccessToken),
+		Scope:            api.NewOptString("openid"),
+	})
+	claims = exchangedClaims(impersonation)
+	if claims.Sub != "user" || claims.Act == nil || claims.Act.Sub != "support-staff" {
+		t.Fatalf("expected the support user to be the actor: %+v", claims)
+	}
+	// assertions are single use
+	expectError(exchange(supportTool, &api.TokenPostApplicationXWwwFormUrlencoded{
+		SubjectToken:     api.NewOptString(assertion),
+		SubjectTokenType: api.NewOptString(jwtutil.TokenTypeJwt),
+		ActorToken:       api.NewOptString(staffToken),
+		ActorTokenType:   api.NewOptString(jwtutil.TokenTypeAccessToken),
+		Scope:            api.NewOptString("openid"),
+	}), oautherror.InvalidGrant)
+	expectError(exchange(supportTool, &api.TokenPostApplicationXWwwFormUrlencoded{
+		SubjectToken:     api.NewOptString(assertSubject("support-staff")),
+		SubjectTokenType: api.NewOptString(jwtutil.TokenTypeJwt),
+		ActorToken:       api.NewOptString(staffToken),
+		ActorTokenType:   api.NewOptString(jwtutil.TokenTypeAccessToken),
+		Scope:            api.NewOptString("openid"),
+	}), oautherror.UnauthorizedClient)
+
+	// exchanging a delegated token keeps the prior actors
+	claims = exchangedClaims(exchange(supportTool, &api.TokenPostApplicationXWwwFormUrlencoded{
+		SubjectToken:     api.NewOptString(impersonation.(*api.LoginTokensHeaders).Response.AccessToken),
+		SubjectTokenType: api.NewOptString(jwtutil.TokenTypeAccessToken),
+		ActorToken:       api.NewOptString(accessTokenFor("service", supportTool)),
+		ActorTokenType:   api.NewOptString(jwtutil.TokenTypeAccessToken),
+	}))
+	if claims.Sub != "user" || claims.Act == nil || claims.Act.Sub != "service" || claims.Act.Act == nil || claims.Act.Act.Sub != "support-staff" {
+		t.Fatalf("expected a nested actor chain: %+v", claims)
+	}
+
+	events, err := daoSource.GetEventStore(ctx).ListEvents(ctx)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	eventTypes := map[string]int{}
+	for _, event := range events {
+		eventTypes[event.EventType]++
+	}
+	if eventTypes[audit.EventTypeTokenExchange] != 2 || eventTypes[audit.EventTypeImpersonation] != 1 {
+		t.Fatalf("expected every exchange to be audited: %+v", eventTypes)
+	}
+}
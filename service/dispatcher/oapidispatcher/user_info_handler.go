@@ -3,11 +3,16 @@ package oapidispatcher
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/kncept-oauth/simple-oidc/service/dao"
 	"github.com/kncept-oauth/simple-oidc/service/dispatcherauth"
 	"github.com/kncept-oauth/simple-oidc/service/gen/api"
 	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/scopes"
+	"github.com/kncept-oauth/simple-oidc/service/tokens"
 )
 
 type userInfoHandler struct {
@@ -16,17 +21,149 @@ type userInfoHandler struct {
 }
 
 // UserinfoGet implements [api.UserInfoHandler].
-func (obj *userInfoHandler) UserinfoGet(ctx context.Context) (*api.UserInfo, error) {
-	jwt := dispatcherauth.GetAnyAuth(ctx)
-	claims, err := jwtutil.ParseIdToken(ctx, jwt, obj.DaoSource.GetKeyStore(ctx), obj.Issuer)
+func (obj *userInfoHandler) UserinfoGet(ctx context.Context) (api.UserinfoGetRes, error) {
+	userInfo, err := obj.userinfoGet(ctx)
+	// every error is a 401 challenge except insufficient_scope, which is a 403
+	// see https://datatracker.ietf.org/doc/html/rfc6750#section-3.1
+	if oauthErr, ok := oautherror.As(err); ok && oauthErr.Code != oautherror.InsufficientScope {
+		return obj.dpopErrorResponse(ctx, oauthErr)
+	}
 	if err != nil {
 		return nil, err
 	}
+	return userInfo, nil
+}
+
+// see https://datatracker.ietf.org/doc/html/rfc9449#section-7.1
+func (obj *userInfoHandler) dpopErrorResponse(ctx context.Context, oauthErr *oautherror.OAuthError) (api.UserinfoGetRes, error) {
+	res := oauthErrorHeaders(ctx, oauthErr)
+	// a bearer token keeps the Bearer challenge from oauthErrorHeaders
+	if dispatcherauth.GetDPoPAuth(ctx) != "" || oauthErr.Code != oautherror.InvalidToken {
+		res.WWWAuthenticate = api.NewOptString(fmt.Sprintf(
+			"DPoP error=%q, algs=%q",
+			oauthErr.Code,
+			strings.Join(jwtutil.SupportedClientAssertionSigningAlgs, " "),
+		))
+	}
+	err := setDPoPNonce(ctx, &tokens.TokenService{
+		DaoSource: obj.DaoSource,
+		Issuer:    obj.Issuer,
+	}, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (obj *userInfoHandler) userinfoGet(ctx context.Context) (*api.UserInfo, error) {
+	// DPoP bound tokens are sent with the DPoP scheme instead of Bearer
+	jwt := dispatcherauth.GetDPoPAuth(ctx)
+	if jwt == "" {
+		jwt = dispatcherauth.GetBearerAuth(ctx)
+	}
+	claims, err := jwtutil.ParseAccessToken(ctx, jwt, obj.DaoSource.GetKeyStore(ctx), obj.Issuer, obj.Issuer)
+	if err != nil {
+		return nil, oautherror.Wrap(oautherror.InvalidToken, err)
+	}
 	if claims == nil {
-		return nil, fmt.Errorf("Not Logged In")
+		return nil, oautherror.New(oautherror.InvalidToken, "access token required")
+	}
+	tokenService := &tokens.TokenService{
+		DaoSource: obj.DaoSource,
+		Issuer:    obj.Issuer,
+	}
+	err = obj.verifyTokenBinding(ctx, tokenService, jwt, claims)
+	if err != nil {
+		return nil, err
+	}
+	revoked, err := tokenService.IsAccessTokenRevoked(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, oautherror.New(oautherror.InvalidToken, "access token revoked")
+	}
+	// the session may have been revoked since the access token was issued
+	if claims.Sid != "" {
+		ses, err := obj.DaoSource.GetSessionStore(ctx).LoadSession(ctx, claims.Sid, claims.Sub)
+		if err != nil {
+			return nil, err
+		}
+		if ses == nil || !ses.IsActive() {
+			return nil, oautherror.New(oautherror.InvalidToken, "session revoked")
+		}
 	}
 
-	return &api.UserInfo{
+	// see https://openid.net/specs/openid-connect-core-1_0.html#UserInfoRequest
+	if !scopes.Includes(claims.Scope, scopes.OpenId) {
+		return nil, oautherror.New(oautherror.InsufficientScope, "openid scope required")
+	}
+	user, err := obj.DaoSource.GetUserStore(ctx).GetUser(ctx, claims.Sub)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, oautherror.New(oautherror.InvalidToken, "no such user: %v", claims.Sub)
+	}
+
+	userInfo := &api.UserInfo{
 		Sub: claims.Sub,
-	}, nil
+	}
+	// profile claims are released according to the granted scopes
+	if standardClaims := user.StandardClaims(claims.Scope); standardClaims != nil {
+		setOptString(&userInfo.Name, standardClaims.Name)
+		setOptString(&userInfo.GivenName, standardClaims.GivenName)
+		setOptString(&userInfo.FamilyName, standardClaims.FamilyName)
+		setOptString(&userInfo.PreferredUsername, standardClaims.PreferredUsername)
+		setOptString(&userInfo.Picture, standardClaims.Picture)
+		setOptString(&userInfo.Locale, standardClaims.Locale)
+		setOptString(&userInfo.Zoneinfo, standardClaims.Zoneinfo)
+		setOptString(&userInfo.Email, standardClaims.Email)
+		setOptBool(&userInfo.EmailVerified, standardClaims.EmailVerified)
+		setOptString(&userInfo.PhoneNumber, standardClaims.PhoneNumber)
+		setOptBool(&userInfo.PhoneNumberVerified, standardClaims.PhoneNumberVerified)
+		if standardClaims.UpdatedAt != 0 {
+			userInfo.UpdatedAt.SetTo(standardClaims.UpdatedAt)
+		}
+	}
+	return userInfo, nil
+}
+
+// a DPoP bound token must be presented with a proof from the same key, and never as a bearer token
+// see https://datatracker.ietf.org/doc/html/rfc9449#section-7
+func (obj *userInfoHandler) verifyTokenBinding(ctx context.Context, tokenService *tokens.TokenService, jwt string, claims *jwtutil.AccessToken) error {
+	dpopScheme := dispatcherauth.GetDPoPAuth(ctx) != ""
+	if claims.BoundJkt() == "" {
+		if dpopScheme {
+			return oautherror.New(oautherror.InvalidToken, "access token is not DPoP bound")
+		}
+		return nil
+	}
+	if !dpopScheme {
+		return oautherror.New(oautherror.InvalidToken, "DPoP bound access token presented as a bearer token")
+	}
+	proof := dispatcherauth.GetDPoPProof(ctx)
+	if proof == "" {
+		return oautherror.New(oautherror.InvalidDPoPProof, "DPoP proof required")
+	}
+	dpopProof, err := tokenService.VerifyDPoPProof(ctx, proof, http.MethodGet, "/userinfo", jwt)
+	if err != nil {
+		return err
+	}
+	if dpopProof.Jkt != claims.BoundJkt() {
+		return oautherror.New(oautherror.InvalidDPoPProof, "DPoP proof key does not match the access token")
+	}
+	return nil
+}
+
+func setOptString(opt *api.OptString, value string) {
+	if value != "" {
+		opt.SetTo(value)
+	}
+}
+
+func setOptBool(opt *api.OptBool, value *bool) {
+	if value != nil {
+		opt.SetTo(*value)
+	}
 }
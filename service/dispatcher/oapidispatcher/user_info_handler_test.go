@@ -1,5 +1,133 @@
 package oapidispatcher
 
-import "github.com/kncept-oauth/simple-oidc/service/gen/api"
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dispatcherauth"
+	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+	"github.com/kncept-oauth/simple-oidc/service/tokens"
+	"github.com/kncept-oauth/simple-oidc/service/users"
+)
 
 var _ api.UserInfoHandler = (*userInfoHandler)(nil)
+
+func TestUserinfoReleasesClaimsByScope(t *testing.T) {
+	ctx := t.Context()
+	daoSource, _ := newSigningDaoSource(t)
+	user := &users.OidcUser{
+		Id: "username",
+	}
+	daoSource.GetUserStore(ctx).SaveUser(ctx, user)
+	oidcClient := &client.Client{
+		ClientId: uuid.NewString(),
+	}
+	handler := &userInfoHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+
+	userinfo := func(scope string) (*api.UserInfo, error) {
+		ses, err := session.NewSession(user.Id, oidcClient.ClientId)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		ses.Scope = scope
+		issued, err := (&tokens.TokenService{
+			DaoSource: daoSource,
+			Issuer:    testIssuer,
+		}).IssueTokens(ctx, ses, oidcClient, tokens.IssueOptions{})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		bearerCtx, err := (&dispatcherauth.Handler{}).HandleBearerAuth(ctx, api.UserinfoGetOperation, api.BearerAuth{
+			Token: issued.AccessToken,
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return handler.userinfoGet(bearerCtx)
+	}
+
+	info, err := userinfo("openid")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if info.Sub != user.Id || info.PreferredUsername.Set {
+		t.Fatalf("only sub should be released for the openid scope: %+v", info)
+	}
+
+	info, err = userinfo("openid profile")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if info.PreferredUsername.Or("") != user.Id {
+		t.Fatalf("preferred_username should be released for the profile scope: %+v", info)
+	}
+
+	if _, err = userinfo("profile"); err == nil {
+		t.Fatalf("userinfo requires the openid scope")
+	}
+}
+
+func TestUserinfoRejectsInvalidTokens(t *testing.T) {
+	ctx := t.Context()
+	daoSource, _ := newSigningDaoSource(t)
+	user := &users.OidcUser{
+		Id: "username",
+	}
+	daoSource.GetUserStore(ctx).SaveUser(ctx, user)
+	oidcClient := &client.Client{
+		ClientId: uuid.NewString(),
+	}
+	handler := &userInfoHandler{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}
+	ses, err := session.NewSession(user.Id, oidcClient.ClientId)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	ses.Scope = "openid"
+	issued, err := (&tokens.TokenService{
+		DaoSource: daoSource,
+		Issuer:    testIssuer,
+	}).IssueTokens(ctx, ses, oidcClient, tokens.IssueOptions{})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	userinfo := func(token string) api.UserinfoGetRes {
+		bearerCtx, err := (&dispatcherauth.Handler{}).HandleBearerAuth(ctx, api.UserinfoGetOperation, api.BearerAuth{
+			Token: token,
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		res, err := handler.UserinfoGet(bearerCtx)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return res
+	}
+	expectInvalidToken := func(res api.UserinfoGetRes) {
+		t.Helper()
+		unauthorized, ok := res.(*api.OAuthErrorHeaders)
+		if !ok || unauthorized.Response.Error != oautherror.InvalidToken || unauthorized.WWWAuthenticate.Or("") != `Bearer error="invalid_token"` {
+			t.Fatalf("expected an invalid_token challenge but got %+v", res)
+		}
+	}
+
+	if _, ok := userinfo(issued.AccessToken).(*api.UserInfo); !ok {
+		t.Fatalf("expected userinfo for a valid token")
+	}
+	expectInvalidToken(userinfo(""))
+	expectInvalidToken(userinfo("not-a-token"))
+	err = session.RevokeSession(ctx, daoSource.GetSessionStore(ctx), ses.SessionId, user.Id)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	expectInvalidToken(userinfo(issued.AccessToken))
+}
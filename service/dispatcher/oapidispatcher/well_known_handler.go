@@ -3,14 +3,19 @@ package oapidispatcher
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	"github.com/kncept-oauth/simple-oidc/service/client"
 	"github.com/kncept-oauth/simple-oidc/service/dao"
 	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/scopes"
 )
 
 type wellKnownHandler struct {
-	DaoSource dao.DaoSource
-	Issuer    string
+	DaoSource    dao.DaoSource
+	Issuer       string
+	Registration *client.RegistrationSettings
 }
 
 func (obj *wellKnownHandler) Jwks(ctx context.Context) (*api.JWKSetResponse, error) {
@@ -50,16 +55,59 @@ func (obj *wellKnownHandler) Jwks(ctx context.Context) (*api.JWKSetResponse, err
 // https://accounts.google.com/.well-known/openid-configuration
 // func (obj *wellKnownHandler) OpenIdConfiguration(ctx context.Context) (*api.OpenIDProviderMetadataResponse, error) {
 func (obj *wellKnownHandler) OpenIdConfiguration(ctx context.Context) (*api.OpenIDProviderMetadataResponseHeaders, error) {
-	return &api.OpenIDProviderMetadataResponseHeaders{
+	res := &api.OpenIDProviderMetadataResponseHeaders{
 		AccessControlAllowOrigin: api.NewOptString("*"),
 		Response: api.OpenIDProviderMetadataResponse{
-			Issuer:                obj.Issuer,
-			AuthorizationEndpoint: fmt.Sprintf("%v/authorize", obj.Issuer),
-			TokenEndpoint:         fmt.Sprintf("%v/token", obj.Issuer),
-			JwksURI:               fmt.Sprintf("%v/.well-known/jwks.json", obj.Issuer),
-			UserinfoEndpoint:      fmt.Sprintf("%v/userinfo", obj.Issuer),
+			Issuer:                      obj.Issuer,
+			AuthorizationEndpoint:       fmt.Sprintf("%v/authorize", obj.Issuer),
+			TokenEndpoint:               fmt.Sprintf("%v/token", obj.Issuer),
+			JwksURI:                     fmt.Sprintf("%v/.well-known/jwks.json", obj.Issuer),
+			UserinfoEndpoint:            fmt.Sprintf("%v/userinfo", obj.Issuer),
+			RevocationEndpoint:          api.NewOptString(fmt.Sprintf("%v/revoke", obj.Issuer)),
+			IntrospectionEndpoint:       api.NewOptString(fmt.Sprintf("%v/introspect", obj.Issuer)),
+			DeviceAuthorizationEndpoint: api.NewOptString(fmt.Sprintf("%v/device_authorization", obj.Issuer)),
+			EndSessionEndpoint:          api.NewOptString(fmt.Sprintf("%v/logout", obj.Issuer)),
+
+			// pushed authorization requests are optional, unless the client requires them
+			PushedAuthorizationRequestEndpoint: api.NewOptString(fmt.Sprintf("%v/par", obj.Issuer)),
+			RequirePushedAuthorizationRequests: api.NewOptBool(false),
+
+			// everything advertised here must actually be implemented
+			ResponseTypesSupported: client.SupportedResponseTypes,
+			ResponseModesSupported: client.SupportedResponseModes,
+			GrantTypesSupported:    client.SupportedGrantTypes,
+			// the subject is the user id, which is the same for every client
+			SubjectTypesSupported:            []string{"public"},
+			IDTokenSigningAlgValuesSupported: []string{string(jwtutil.SigningAlgorithm)},
+			ScopesSupported:                  scopes.Supported,
+			ClaimsSupported:                  slices.Concat(jwtutil.SupportedIdTokenClaims, jwtutil.SupportedStandardClaims),
+			CodeChallengeMethodsSupported:    client.SupportedCodeChallengeMethods,
+
+			// request objects (RFC 9101) are signed with the same keys as client assertions.
+			// request uris are only fetched if they are registered
+			RequestObjectSigningAlgValuesSupported: jwtutil.SupportedClientAssertionSigningAlgs,
+			RequestParameterSupported:              api.NewOptBool(true),
+			RequestURIParameterSupported:           api.NewOptBool(true),
+			RequireRequestURIRegistration:          api.NewOptBool(true),
+
+			// DPoP proofs (RFC 9449) are signed with a key of the client's choosing
+			DpopSigningAlgValuesSupported: jwtutil.SupportedClientAssertionSigningAlgs,
+
+			TokenEndpointAuthMethodsSupported:          client.SupportedTokenEndpointAuthMethods,
+			TokenEndpointAuthSigningAlgValuesSupported: slices.Concat(jwtutil.SupportedClientAssertionSigningAlgs, jwtutil.SupportedClientSecretJwtSigningAlgs),
+
+			// logout tokens (and front channel logout uris) always include the sid claim
+			BackchannelLogoutSupported:         api.NewOptBool(true),
+			BackchannelLogoutSessionSupported:  api.NewOptBool(true),
+			FrontchannelLogoutSupported:        api.NewOptBool(true),
+			FrontchannelLogoutSessionSupported: api.NewOptBool(true),
 		},
-	}, nil
+	}
+	// only advertised when the deployment allows dynamic registration
+	if obj.Registration.IsEnabled() {
+		res.Response.RegistrationEndpoint.SetTo(fmt.Sprintf("%v/connect/register", obj.Issuer))
+	}
+	return res, nil
 
 	// fmt.Printf("TODO: OpenIdConfiguration\n")
 	// return nil, errors.ErrUnsupported
@@ -1,7 +1,52 @@
 package oapidispatcher
 
 import (
+	"slices"
+	"testing"
+
+	"github.com/kncept-oauth/simple-oidc/service/client"
 	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/scopes"
 )
 
 var _ api.WellKnownHandler = (*wellKnownHandler)(nil)
+
+func TestOpenIdConfigurationOnlyAdvertisesSupportedFeatures(t *testing.T) {
+	handler := &wellKnownHandler{
+		Issuer: testIssuer,
+	}
+	res, err := handler.OpenIdConfiguration(t.Context())
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	metadata := res.Response
+	if len(metadata.ResponseTypesSupported) == 0 || len(metadata.SubjectTypesSupported) == 0 || len(metadata.IDTokenSigningAlgValuesSupported) == 0 {
+		t.Fatalf("required discovery metadata missing: %+v", metadata)
+	}
+
+	allResponseTypesClient := &client.Client{
+		AllowedResponseTypes: metadata.ResponseTypesSupported,
+	}
+	for _, responseType := range metadata.ResponseTypesSupported {
+		if !allResponseTypesClient.IsAllowedResponseType(responseType) {
+			t.Errorf("unsupported response_type advertised: %v", responseType)
+		}
+	}
+	for _, responseMode := range metadata.ResponseModesSupported {
+		if err = client.ValidateResponseMode(client.ResponseTypeCode, responseMode); err != nil {
+			t.Errorf("unsupported response_mode advertised: %v", err)
+		}
+	}
+	for _, method := range metadata.CodeChallengeMethodsSupported {
+		if !client.IsSupportedCodeChallengeMethod(method) {
+			t.Errorf("unsupported code_challenge_method advertised: %v", method)
+		}
+	}
+	if !slices.Contains(metadata.ScopesSupported, scopes.OpenId) {
+		t.Errorf("openid scope must be advertised")
+	}
+	if !slices.Contains(metadata.IDTokenSigningAlgValuesSupported, string(jwtutil.SigningAlgorithm)) {
+		t.Errorf("id token signing algorithm must be advertised")
+	}
+}
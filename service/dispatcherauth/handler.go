@@ -3,13 +3,18 @@ package dispatcherauth
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/kncept-oauth/simple-oidc/service/gen/api"
+	"github.com/ogen-go/ogen/ogenerrors"
 )
 
 type BearerAuthContextKey struct{}
 type LoginCookieContextKey struct{}
 type AnyAuthContextKey struct{}
+type BasicAuthContextKey struct{}
+type DPoPAuthContextKey struct{}
+type DPoPProofContextKey struct{}
 
 type Handler struct {
 }
@@ -21,6 +26,35 @@ func (obj *Handler) HandleBearerAuth(ctx context.Context, operationName api.Oper
 	}
 	return ctx, nil
 }
+
+// http basic auth is client authentication, and is NOT added as 'any auth'
+func (obj *Handler) HandleBasicAuth(ctx context.Context, operationName api.OperationName, t api.BasicAuth) (context.Context, error) {
+	if t.Username != "" {
+		ctx = context.WithValue(ctx, BasicAuthContextKey{}, t)
+	}
+	return ctx, nil
+}
+
+// the full Authorization header is passed through, so only the DPoP scheme is accepted here
+// see https://datatracker.ietf.org/doc/html/rfc9449#section-7.1
+func (obj *Handler) HandleDPoPAuth(ctx context.Context, operationName api.OperationName, t api.DPoPAuth) (context.Context, error) {
+	scheme, token, found := strings.Cut(t.APIKey, " ")
+	if !found || !strings.EqualFold(scheme, "DPoP") || token == "" {
+		return ctx, ogenerrors.ErrSkipServerSecurity
+	}
+	ctx = context.WithValue(ctx, AnyAuthContextKey{}, token)
+	ctx = context.WithValue(ctx, DPoPAuthContextKey{}, token)
+	return ctx, nil
+}
+
+// the DPoP proof is not authentication by itself, and is NOT added as 'any auth'
+func (obj *Handler) HandleDPoPProof(ctx context.Context, operationName api.OperationName, t api.DPoPProof) (context.Context, error) {
+	if t.APIKey != "" {
+		ctx = context.WithValue(ctx, DPoPProofContextKey{}, t.APIKey)
+	}
+	return ctx, nil
+}
+
 func (obj *Handler) HandleLoginCookie(ctx context.Context, operationName api.OperationName, t api.LoginCookie) (context.Context, error) {
 	fmt.Printf("HandleLoginCookie %v\n", t.APIKey)
 	if t.APIKey != "" {
@@ -34,6 +68,24 @@ func GetBearerAuth(ctx context.Context) string {
 	return valueAsString(ctx, BearerAuthContextKey{})
 }
 
+// returns nil if basic auth was not supplied
+func GetBasicAuth(ctx context.Context) *api.BasicAuth {
+	val := ctx.Value(BasicAuthContextKey{})
+	if basicAuth, ok := val.(api.BasicAuth); ok {
+		return &basicAuth
+	}
+	return nil
+}
+
+// the access token from an 'Authorization: DPoP' header
+func GetDPoPAuth(ctx context.Context) string {
+	return valueAsString(ctx, DPoPAuthContextKey{})
+}
+
+func GetDPoPProof(ctx context.Context) string {
+	return valueAsString(ctx, DPoPProofContextKey{})
+}
+
 func GetLoginCookie(ctx context.Context) string {
 	return valueAsString(ctx, LoginCookieContextKey{})
 }
@@ -25,6 +25,11 @@ import (
 // Invoker invokes operations described by OpenAPI v3 specification.
 type Invoker interface {
 	AuthorizationInvoker
+	DeviceAuthorizationInvoker
+	IntrospectionInvoker
+	PushedAuthorizationInvoker
+	RegistrationInvoker
+	RevocationInvoker
 	UserInfoInvoker
 	WellKnownInvoker
 }
@@ -47,6 +52,84 @@ type AuthorizationInvoker interface {
 	TokenPost(ctx context.Context, request TokenPostReq) (TokenPostRes, error)
 }
 
+// DeviceAuthorizationInvoker invokes operations described by OpenAPI v3 specification.
+//
+// x-gen-operation-group: DeviceAuthorization
+type DeviceAuthorizationInvoker interface {
+	// DeviceAuthorizationPost invokes POST /device_authorization operation.
+	//
+	// Device Authorization Endpoint (RFC 8628).
+	//
+	// POST /device_authorization
+	DeviceAuthorizationPost(ctx context.Context, request *DeviceAuthorizationRequestBody) (DeviceAuthorizationPostRes, error)
+}
+
+// IntrospectionInvoker invokes operations described by OpenAPI v3 specification.
+//
+// x-gen-operation-group: Introspection
+type IntrospectionInvoker interface {
+	// IntrospectPost invokes POST /introspect operation.
+	//
+	// Token Introspection Endpoint (RFC 7662).
+	//
+	// POST /introspect
+	IntrospectPost(ctx context.Context, request *IntrospectionRequestBody) (IntrospectPostRes, error)
+}
+
+// PushedAuthorizationInvoker invokes operations described by OpenAPI v3 specification.
+//
+// x-gen-operation-group: PushedAuthorization
+type PushedAuthorizationInvoker interface {
+	// ParPost invokes POST /par operation.
+	//
+	// Pushed Authorization Request Endpoint (RFC 9126).
+	//
+	// POST /par
+	ParPost(ctx context.Context, request *PushedAuthorizationRequestBody) (ParPostRes, error)
+}
+
+// RegistrationInvoker invokes operations described by OpenAPI v3 specification.
+//
+// x-gen-operation-group: Registration
+type RegistrationInvoker interface {
+	// DeleteClientRegistration invokes deleteClientRegistration operation.
+	//
+	// Deregister a client (RFC 7592).
+	//
+	// DELETE /connect/register/{client_id}
+	DeleteClientRegistration(ctx context.Context, params DeleteClientRegistrationParams) (DeleteClientRegistrationRes, error)
+	// GetClientRegistration invokes getClientRegistration operation.
+	//
+	// Read a registered client (RFC 7592).
+	//
+	// GET /connect/register/{client_id}
+	GetClientRegistration(ctx context.Context, params GetClientRegistrationParams) (GetClientRegistrationRes, error)
+	// RegisterClient invokes registerClient operation.
+	//
+	// Dynamic Client Registration Endpoint (RFC 7591).
+	//
+	// POST /connect/register
+	RegisterClient(ctx context.Context, request *ClientMetadata) (RegisterClientRes, error)
+	// UpdateClientRegistration invokes updateClientRegistration operation.
+	//
+	// Update a registered client (RFC 7592).
+	//
+	// PUT /connect/register/{client_id}
+	UpdateClientRegistration(ctx context.Context, request *ClientMetadata, params UpdateClientRegistrationParams) (UpdateClientRegistrationRes, error)
+}
+
+// RevocationInvoker invokes operations described by OpenAPI v3 specification.
+//
+// x-gen-operation-group: Revocation
+type RevocationInvoker interface {
+	// RevokePost invokes POST /revoke operation.
+	//
+	// Token Revocation Endpoint (RFC 7009).
+	//
+	// POST /revoke
+	RevokePost(ctx context.Context, request *RevocationRequestBody) (RevokePostRes, error)
+}
+
 // UserInfoInvoker invokes operations described by OpenAPI v3 specification.
 //
 // x-gen-operation-group: UserInfo
@@ -56,7 +139,7 @@ type UserInfoInvoker interface {
 	// Get user information.
 	//
 	// GET /userinfo
-	UserinfoGet(ctx context.Context) (*UserInfo, error)
+	UserinfoGet(ctx context.Context) (UserinfoGetRes, error)
 }
 
 // WellKnownInvoker invokes operations described by OpenAPI v3 specification.
@@ -84,7 +167,7 @@ type Client struct {
 	baseClient
 }
 type errorHandler interface {
-	NewError(ctx context.Context, err error) *ErrRespStatusCode
+	NewError(ctx context.Context, err error) *ErrorResponseStatusCode
 }
 
 var _ Handler = struct {
@@ -191,7 +274,10 @@ func (c *Client) sendAuthorizeGet(ctx context.Context, params AuthorizeGetParams
 		}
 
 		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
-			return e.EncodeValue(conv.StringToString(params.ResponseType))
+			if val, ok := params.ResponseType.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
 		}); err != nil {
 			return res, errors.Wrap(err, "encode query")
 		}
@@ -219,7 +305,10 @@ func (c *Client) sendAuthorizeGet(ctx context.Context, params AuthorizeGetParams
 		}
 
 		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
-			return e.EncodeValue(conv.StringToString(params.Scope))
+			if val, ok := params.Scope.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
 		}); err != nil {
 			return res, errors.Wrap(err, "encode query")
 		}
@@ -233,7 +322,10 @@ func (c *Client) sendAuthorizeGet(ctx context.Context, params AuthorizeGetParams
 		}
 
 		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
-			return e.EncodeValue(conv.StringToString(params.RedirectURI))
+			if val, ok := params.RedirectURI.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
 		}); err != nil {
 			return res, errors.Wrap(err, "encode query")
 		}
@@ -255,6 +347,23 @@ func (c *Client) sendAuthorizeGet(ctx context.Context, params AuthorizeGetParams
 			return res, errors.Wrap(err, "encode query")
 		}
 	}
+	{
+		// Encode "response_mode" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "response_mode",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.ResponseMode.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
 	{
 		// Encode "nonce" parameter.
 		cfg := uri.QueryParameterEncodingConfig{
@@ -272,6 +381,74 @@ func (c *Client) sendAuthorizeGet(ctx context.Context, params AuthorizeGetParams
 			return res, errors.Wrap(err, "encode query")
 		}
 	}
+	{
+		// Encode "code_challenge" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "code_challenge",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.CodeChallenge.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "code_challenge_method" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "code_challenge_method",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.CodeChallengeMethod.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "request_uri" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "request_uri",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.RequestURI.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "request" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "request",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Request.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
 	u.RawQuery = q.Values().Encode()
 
 	stage = "EncodeRequest"
@@ -330,21 +507,21 @@ func (c *Client) sendAuthorizeGet(ctx context.Context, params AuthorizeGetParams
 	return result, nil
 }
 
-// Jwks invokes jwks operation.
+// DeleteClientRegistration invokes deleteClientRegistration operation.
 //
-// Json Web Keyset.
+// Deregister a client (RFC 7592).
 //
-// GET /.well-known/jwks.json
-func (c *Client) Jwks(ctx context.Context) (*JWKSetResponse, error) {
-	res, err := c.sendJwks(ctx)
+// DELETE /connect/register/{client_id}
+func (c *Client) DeleteClientRegistration(ctx context.Context, params DeleteClientRegistrationParams) (DeleteClientRegistrationRes, error) {
+	res, err := c.sendDeleteClientRegistration(ctx, params)
 	return res, err
 }
 
-func (c *Client) sendJwks(ctx context.Context) (res *JWKSetResponse, err error) {
+func (c *Client) sendDeleteClientRegistration(ctx context.Context, params DeleteClientRegistrationParams) (res DeleteClientRegistrationRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("jwks"),
-		semconv.HTTPRequestMethodKey.String("GET"),
-		semconv.HTTPRouteKey.String("/.well-known/jwks.json"),
+		otelogen.OperationID("deleteClientRegistration"),
+		semconv.HTTPRequestMethodKey.String("DELETE"),
+		semconv.HTTPRouteKey.String("/connect/register/{client_id}"),
 	}
 
 	// Run stopwatch.
@@ -359,7 +536,7 @@ func (c *Client) sendJwks(ctx context.Context) (res *JWKSetResponse, err error)
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, JwksOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, DeleteClientRegistrationOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -376,16 +553,68 @@ func (c *Client) sendJwks(ctx context.Context) (res *JWKSetResponse, err error)
 
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
-	var pathParts [1]string
-	pathParts[0] = "/.well-known/jwks.json"
+	var pathParts [2]string
+	pathParts[0] = "/connect/register/"
+	{
+		// Encode "client_id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "client_id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.StringToString(params.ClientID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "GET", u)
+	r, err := ht.NewRequest(ctx, "DELETE", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
 
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, DeleteClientRegistrationOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{},
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
 	stage = "SendRequest"
 	resp, err := c.cfg.Client.Do(r)
 	if err != nil {
@@ -394,7 +623,7 @@ func (c *Client) sendJwks(ctx context.Context) (res *JWKSetResponse, err error)
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeJwksResponse(resp)
+	result, err := decodeDeleteClientRegistrationResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -402,21 +631,20 @@ func (c *Client) sendJwks(ctx context.Context) (res *JWKSetResponse, err error)
 	return result, nil
 }
 
-// OpenIdConfiguration invokes openIdConfiguration operation.
+// DeviceAuthorizationPost invokes POST /device_authorization operation.
 //
-// (Partial) OIDC config.
+// Device Authorization Endpoint (RFC 8628).
 //
-// GET /.well-known/openid-configuration
-func (c *Client) OpenIdConfiguration(ctx context.Context) (*OpenIDProviderMetadataResponseHeaders, error) {
-	res, err := c.sendOpenIdConfiguration(ctx)
+// POST /device_authorization
+func (c *Client) DeviceAuthorizationPost(ctx context.Context, request *DeviceAuthorizationRequestBody) (DeviceAuthorizationPostRes, error) {
+	res, err := c.sendDeviceAuthorizationPost(ctx, request)
 	return res, err
 }
 
-func (c *Client) sendOpenIdConfiguration(ctx context.Context) (res *OpenIDProviderMetadataResponseHeaders, err error) {
+func (c *Client) sendDeviceAuthorizationPost(ctx context.Context, request *DeviceAuthorizationRequestBody) (res DeviceAuthorizationPostRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("openIdConfiguration"),
-		semconv.HTTPRequestMethodKey.String("GET"),
-		semconv.HTTPRouteKey.String("/.well-known/openid-configuration"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/device_authorization"),
 	}
 
 	// Run stopwatch.
@@ -431,7 +659,7 @@ func (c *Client) sendOpenIdConfiguration(ctx context.Context) (res *OpenIDProvid
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, OpenIdConfigurationOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, DeviceAuthorizationPostOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -449,14 +677,51 @@ func (c *Client) sendOpenIdConfiguration(ctx context.Context) (res *OpenIDProvid
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
-	pathParts[0] = "/.well-known/openid-configuration"
+	pathParts[0] = "/device_authorization"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "GET", u)
+	r, err := ht.NewRequest(ctx, "POST", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
+	if err := encodeDeviceAuthorizationPostRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, DeviceAuthorizationPostOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{},
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
 
 	stage = "SendRequest"
 	resp, err := c.cfg.Client.Do(r)
@@ -466,7 +731,7 @@ func (c *Client) sendOpenIdConfiguration(ctx context.Context) (res *OpenIDProvid
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeOpenIdConfigurationResponse(resp)
+	result, err := decodeDeviceAuthorizationPostResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -474,20 +739,21 @@ func (c *Client) sendOpenIdConfiguration(ctx context.Context) (res *OpenIDProvid
 	return result, nil
 }
 
-// TokenPost invokes POST /token operation.
+// GetClientRegistration invokes getClientRegistration operation.
 //
-// Token Exchange Endpoint.
+// Read a registered client (RFC 7592).
 //
-// POST /token
-func (c *Client) TokenPost(ctx context.Context, request TokenPostReq) (TokenPostRes, error) {
-	res, err := c.sendTokenPost(ctx, request)
+// GET /connect/register/{client_id}
+func (c *Client) GetClientRegistration(ctx context.Context, params GetClientRegistrationParams) (GetClientRegistrationRes, error) {
+	res, err := c.sendGetClientRegistration(ctx, params)
 	return res, err
 }
 
-func (c *Client) sendTokenPost(ctx context.Context, request TokenPostReq) (res TokenPostRes, err error) {
+func (c *Client) sendGetClientRegistration(ctx context.Context, params GetClientRegistrationParams) (res GetClientRegistrationRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		semconv.HTTPRequestMethodKey.String("POST"),
-		semconv.HTTPRouteKey.String("/token"),
+		otelogen.OperationID("getClientRegistration"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/connect/register/{client_id}"),
 	}
 
 	// Run stopwatch.
@@ -502,7 +768,7 @@ func (c *Client) sendTokenPost(ctx context.Context, request TokenPostReq) (res T
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, TokenPostOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, GetClientRegistrationOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -519,27 +785,787 @@ func (c *Client) sendTokenPost(ctx context.Context, request TokenPostReq) (res T
 
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
-	var pathParts [1]string
-	pathParts[0] = "/token"
+	var pathParts [2]string
+	pathParts[0] = "/connect/register/"
+	{
+		// Encode "client_id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "client_id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.StringToString(params.ClientID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "POST", u)
+	r, err := ht.NewRequest(ctx, "GET", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
-	if err := encodeTokenPostRequest(request, r); err != nil {
-		return res, errors.Wrap(err, "encode request")
-	}
 
-	stage = "SendRequest"
-	resp, err := c.cfg.Client.Do(r)
-	if err != nil {
-		return res, errors.Wrap(err, "do request")
-	}
-	defer resp.Body.Close()
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, GetClientRegistrationOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
 
-	stage = "DecodeResponse"
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{},
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGetClientRegistrationResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// IntrospectPost invokes POST /introspect operation.
+//
+// Token Introspection Endpoint (RFC 7662).
+//
+// POST /introspect
+func (c *Client) IntrospectPost(ctx context.Context, request *IntrospectionRequestBody) (IntrospectPostRes, error) {
+	res, err := c.sendIntrospectPost(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendIntrospectPost(ctx context.Context, request *IntrospectionRequestBody) (res IntrospectPostRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/introspect"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, IntrospectPostOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/introspect"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeIntrospectPostRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, IntrospectPostOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+		{
+			stage = "Security:DPoPProof"
+			switch err := c.securityDPoPProof(ctx, IntrospectPostOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 1
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"DPoPProof\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{},
+				{0b00000001},
+				{0b00000010},
+				{0b00000011},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeIntrospectPostResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// Jwks invokes jwks operation.
+//
+// Json Web Keyset.
+//
+// GET /.well-known/jwks.json
+func (c *Client) Jwks(ctx context.Context) (*JWKSetResponse, error) {
+	res, err := c.sendJwks(ctx)
+	return res, err
+}
+
+func (c *Client) sendJwks(ctx context.Context) (res *JWKSetResponse, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("jwks"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/.well-known/jwks.json"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, JwksOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/.well-known/jwks.json"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeJwksResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// OpenIdConfiguration invokes openIdConfiguration operation.
+//
+// (Partial) OIDC config.
+//
+// GET /.well-known/openid-configuration
+func (c *Client) OpenIdConfiguration(ctx context.Context) (*OpenIDProviderMetadataResponseHeaders, error) {
+	res, err := c.sendOpenIdConfiguration(ctx)
+	return res, err
+}
+
+func (c *Client) sendOpenIdConfiguration(ctx context.Context) (res *OpenIDProviderMetadataResponseHeaders, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("openIdConfiguration"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/.well-known/openid-configuration"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, OpenIdConfigurationOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/.well-known/openid-configuration"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeOpenIdConfigurationResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ParPost invokes POST /par operation.
+//
+// Pushed Authorization Request Endpoint (RFC 9126).
+//
+// POST /par
+func (c *Client) ParPost(ctx context.Context, request *PushedAuthorizationRequestBody) (ParPostRes, error) {
+	res, err := c.sendParPost(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendParPost(ctx context.Context, request *PushedAuthorizationRequestBody) (res ParPostRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/par"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ParPostOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/par"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeParPostRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, ParPostOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{},
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeParPostResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// RegisterClient invokes registerClient operation.
+//
+// Dynamic Client Registration Endpoint (RFC 7591).
+//
+// POST /connect/register
+func (c *Client) RegisterClient(ctx context.Context, request *ClientMetadata) (RegisterClientRes, error) {
+	res, err := c.sendRegisterClient(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendRegisterClient(ctx context.Context, request *ClientMetadata) (res RegisterClientRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("registerClient"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/connect/register"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, RegisterClientOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/connect/register"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeRegisterClientRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, RegisterClientOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{},
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeRegisterClientResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// RevokePost invokes POST /revoke operation.
+//
+// Token Revocation Endpoint (RFC 7009).
+//
+// POST /revoke
+func (c *Client) RevokePost(ctx context.Context, request *RevocationRequestBody) (RevokePostRes, error) {
+	res, err := c.sendRevokePost(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendRevokePost(ctx context.Context, request *RevocationRequestBody) (res RevokePostRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/revoke"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, RevokePostOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/revoke"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeRevokePostRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, RevokePostOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{},
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeRevokePostResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// TokenPost invokes POST /token operation.
+//
+// Token Exchange Endpoint.
+//
+// POST /token
+func (c *Client) TokenPost(ctx context.Context, request TokenPostReq) (TokenPostRes, error) {
+	res, err := c.sendTokenPost(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendTokenPost(ctx context.Context, request TokenPostReq) (res TokenPostRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/token"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, TokenPostOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/token"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeTokenPostRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, TokenPostOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+		{
+			stage = "Security:DPoPProof"
+			switch err := c.securityDPoPProof(ctx, TokenPostOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 1
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"DPoPProof\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{},
+				{0b00000001},
+				{0b00000010},
+				{0b00000011},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
 	result, err := decodeTokenPostResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
@@ -548,17 +1574,144 @@ func (c *Client) sendTokenPost(ctx context.Context, request TokenPostReq) (res T
 	return result, nil
 }
 
+// UpdateClientRegistration invokes updateClientRegistration operation.
+//
+// Update a registered client (RFC 7592).
+//
+// PUT /connect/register/{client_id}
+func (c *Client) UpdateClientRegistration(ctx context.Context, request *ClientMetadata, params UpdateClientRegistrationParams) (UpdateClientRegistrationRes, error) {
+	res, err := c.sendUpdateClientRegistration(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendUpdateClientRegistration(ctx context.Context, request *ClientMetadata, params UpdateClientRegistrationParams) (res UpdateClientRegistrationRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("updateClientRegistration"),
+		semconv.HTTPRequestMethodKey.String("PUT"),
+		semconv.HTTPRouteKey.String("/connect/register/{client_id}"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, UpdateClientRegistrationOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/connect/register/"
+	{
+		// Encode "client_id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "client_id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.StringToString(params.ClientID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "PUT", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeUpdateClientRegistrationRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, UpdateClientRegistrationOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{},
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeUpdateClientRegistrationResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
 // UserinfoGet invokes GET /userinfo operation.
 //
 // Get user information.
 //
 // GET /userinfo
-func (c *Client) UserinfoGet(ctx context.Context) (*UserInfo, error) {
+func (c *Client) UserinfoGet(ctx context.Context) (UserinfoGetRes, error) {
 	res, err := c.sendUserinfoGet(ctx)
 	return res, err
 }
 
-func (c *Client) sendUserinfoGet(ctx context.Context) (res *UserInfo, err error) {
+func (c *Client) sendUserinfoGet(ctx context.Context) (res UserinfoGetRes, err error) {
 	otelAttrs := []attribute.KeyValue{
 		semconv.HTTPRequestMethodKey.String("GET"),
 		semconv.HTTPRouteKey.String("/userinfo"),
@@ -617,11 +1770,34 @@ func (c *Client) sendUserinfoGet(ctx context.Context) (res *UserInfo, err error)
 				return res, errors.Wrap(err, "security \"BearerAuth\"")
 			}
 		}
+		{
+			stage = "Security:DPoPAuth"
+			switch err := c.securityDPoPAuth(ctx, UserinfoGetOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 1
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"DPoPAuth\"")
+			}
+		}
+		{
+			stage = "Security:DPoPProof"
+			switch err := c.securityDPoPProof(ctx, UserinfoGetOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 2
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"DPoPProof\"")
+			}
+		}
 
 		if ok := func() bool {
 		nextRequirement:
 			for _, requirement := range []bitset{
 				{0b00000001},
+				{0b00000110},
 			} {
 				for i, mask := range requirement {
 					if satisfied[i]&mask != mask {
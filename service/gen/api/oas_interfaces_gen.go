@@ -5,6 +5,34 @@ type AuthorizeGetRes interface {
 	authorizeGetRes()
 }
 
+type DeleteClientRegistrationRes interface {
+	deleteClientRegistrationRes()
+}
+
+type DeviceAuthorizationPostRes interface {
+	deviceAuthorizationPostRes()
+}
+
+type GetClientRegistrationRes interface {
+	getClientRegistrationRes()
+}
+
+type IntrospectPostRes interface {
+	introspectPostRes()
+}
+
+type ParPostRes interface {
+	parPostRes()
+}
+
+type RegisterClientRes interface {
+	registerClientRes()
+}
+
+type RevokePostRes interface {
+	revokePostRes()
+}
+
 type TokenPostReq interface {
 	tokenPostReq()
 }
@@ -12,3 +40,11 @@ type TokenPostReq interface {
 type TokenPostRes interface {
 	tokenPostRes()
 }
+
+type UpdateClientRegistrationRes interface {
+	updateClientRegistrationRes()
+}
+
+type UserinfoGetRes interface {
+	userinfoGetRes()
+}
@@ -53,285 +53,483 @@ func (s *AuthorizeGetBadRequestApplicationJSON) UnmarshalJSON(data []byte) error
 }
 
 // Encode implements json.Marshaler.
-func (s *JWKResponse) Encode(e *jx.Encoder) {
+func (s *ClientInformation) Encode(e *jx.Encoder) {
 	e.ObjStart()
 	s.encodeFields(e)
 	e.ObjEnd()
 }
 
 // encodeFields encodes fields.
-func (s *JWKResponse) encodeFields(e *jx.Encoder) {
+func (s *ClientInformation) encodeFields(e *jx.Encoder) {
 	{
-		if s.Kty.Set {
-			e.FieldStart("kty")
-			s.Kty.Encode(e)
+		e.FieldStart("client_id")
+		e.Str(s.ClientID)
+	}
+	{
+		if s.ClientSecret.Set {
+			e.FieldStart("client_secret")
+			s.ClientSecret.Encode(e)
 		}
 	}
 	{
-		if s.Use.Set {
-			e.FieldStart("use")
-			s.Use.Encode(e)
+		if s.ClientIDIssuedAt.Set {
+			e.FieldStart("client_id_issued_at")
+			s.ClientIDIssuedAt.Encode(e)
 		}
 	}
 	{
-		if s.KeyOps.Set {
-			e.FieldStart("key_ops")
-			s.KeyOps.Encode(e)
+		if s.ClientSecretExpiresAt.Set {
+			e.FieldStart("client_secret_expires_at")
+			s.ClientSecretExpiresAt.Encode(e)
 		}
 	}
 	{
-		if s.Alg.Set {
-			e.FieldStart("alg")
-			s.Alg.Encode(e)
+		if s.RegistrationAccessToken.Set {
+			e.FieldStart("registration_access_token")
+			s.RegistrationAccessToken.Encode(e)
 		}
 	}
 	{
-		if s.Kid.Set {
-			e.FieldStart("kid")
-			s.Kid.Encode(e)
+		if s.RegistrationClientURI.Set {
+			e.FieldStart("registration_client_uri")
+			s.RegistrationClientURI.Encode(e)
 		}
 	}
 	{
-		if s.X5u.Set {
-			e.FieldStart("x5u")
-			s.X5u.Encode(e)
+		if s.RedirectUris != nil {
+			e.FieldStart("redirect_uris")
+			e.ArrStart()
+			for _, elem := range s.RedirectUris {
+				e.Str(elem)
+			}
+			e.ArrEnd()
 		}
 	}
 	{
-		if s.X5c.Set {
-			e.FieldStart("x5c")
-			s.X5c.Encode(e)
+		if s.TokenEndpointAuthMethod.Set {
+			e.FieldStart("token_endpoint_auth_method")
+			s.TokenEndpointAuthMethod.Encode(e)
 		}
 	}
 	{
-		if s.X5t.Set {
-			e.FieldStart("x5t")
-			s.X5t.Encode(e)
+		if s.GrantTypes != nil {
+			e.FieldStart("grant_types")
+			e.ArrStart()
+			for _, elem := range s.GrantTypes {
+				e.Str(elem)
+			}
+			e.ArrEnd()
 		}
 	}
 	{
-		if s.N.Set {
-			e.FieldStart("n")
-			s.N.Encode(e)
+		if s.ResponseTypes != nil {
+			e.FieldStart("response_types")
+			e.ArrStart()
+			for _, elem := range s.ResponseTypes {
+				e.Str(elem)
+			}
+			e.ArrEnd()
 		}
 	}
 	{
-		if s.E.Set {
-			e.FieldStart("e")
-			s.E.Encode(e)
+		if s.ClientName.Set {
+			e.FieldStart("client_name")
+			s.ClientName.Encode(e)
+		}
+	}
+	{
+		if s.ClientURI.Set {
+			e.FieldStart("client_uri")
+			s.ClientURI.Encode(e)
+		}
+	}
+	{
+		if s.Scope.Set {
+			e.FieldStart("scope")
+			s.Scope.Encode(e)
+		}
+	}
+	{
+		if s.JwksURI.Set {
+			e.FieldStart("jwks_uri")
+			s.JwksURI.Encode(e)
+		}
+	}
+	{
+		if s.PostLogoutRedirectUris != nil {
+			e.FieldStart("post_logout_redirect_uris")
+			e.ArrStart()
+			for _, elem := range s.PostLogoutRedirectUris {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
+	}
+	{
+		if s.BackchannelLogoutURI.Set {
+			e.FieldStart("backchannel_logout_uri")
+			s.BackchannelLogoutURI.Encode(e)
+		}
+	}
+	{
+		if s.FrontchannelLogoutURI.Set {
+			e.FieldStart("frontchannel_logout_uri")
+			s.FrontchannelLogoutURI.Encode(e)
+		}
+	}
+	{
+		if s.FrontchannelLogoutSessionRequired.Set {
+			e.FieldStart("frontchannel_logout_session_required")
+			s.FrontchannelLogoutSessionRequired.Encode(e)
+		}
+	}
+	{
+		if s.RequirePushedAuthorizationRequests.Set {
+			e.FieldStart("require_pushed_authorization_requests")
+			s.RequirePushedAuthorizationRequests.Encode(e)
+		}
+	}
+	{
+		if s.RequireSignedRequestObject.Set {
+			e.FieldStart("require_signed_request_object")
+			s.RequireSignedRequestObject.Encode(e)
+		}
+	}
+	{
+		if s.DpopBoundAccessTokens.Set {
+			e.FieldStart("dpop_bound_access_tokens")
+			s.DpopBoundAccessTokens.Encode(e)
+		}
+	}
+	{
+		if s.RequestUris != nil {
+			e.FieldStart("request_uris")
+			e.ArrStart()
+			for _, elem := range s.RequestUris {
+				e.Str(elem)
+			}
+			e.ArrEnd()
 		}
 	}
 }
 
-var jsonFieldsNameOfJWKResponse = [10]string{
-	0: "kty",
-	1: "use",
-	2: "key_ops",
-	3: "alg",
-	4: "kid",
-	5: "x5u",
-	6: "x5c",
-	7: "x5t",
-	8: "n",
-	9: "e",
+var jsonFieldsNameOfClientInformation = [22]string{
+	0:  "client_id",
+	1:  "client_secret",
+	2:  "client_id_issued_at",
+	3:  "client_secret_expires_at",
+	4:  "registration_access_token",
+	5:  "registration_client_uri",
+	6:  "redirect_uris",
+	7:  "token_endpoint_auth_method",
+	8:  "grant_types",
+	9:  "response_types",
+	10: "client_name",
+	11: "client_uri",
+	12: "scope",
+	13: "jwks_uri",
+	14: "post_logout_redirect_uris",
+	15: "backchannel_logout_uri",
+	16: "frontchannel_logout_uri",
+	17: "frontchannel_logout_session_required",
+	18: "require_pushed_authorization_requests",
+	19: "require_signed_request_object",
+	20: "dpop_bound_access_tokens",
+	21: "request_uris",
 }
 
-// Decode decodes JWKResponse from json.
-func (s *JWKResponse) Decode(d *jx.Decoder) error {
+// Decode decodes ClientInformation from json.
+func (s *ClientInformation) Decode(d *jx.Decoder) error {
 	if s == nil {
-		return errors.New("invalid: unable to decode JWKResponse to nil")
+		return errors.New("invalid: unable to decode ClientInformation to nil")
 	}
+	var requiredBitSet [3]uint8
 
 	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
 		switch string(k) {
-		case "kty":
+		case "client_id":
+			requiredBitSet[0] |= 1 << 0
 			if err := func() error {
-				s.Kty.Reset()
-				if err := s.Kty.Decode(d); err != nil {
+				v, err := d.Str()
+				s.ClientID = string(v)
+				if err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"kty\"")
+				return errors.Wrap(err, "decode field \"client_id\"")
 			}
-		case "use":
+		case "client_secret":
 			if err := func() error {
-				s.Use.Reset()
-				if err := s.Use.Decode(d); err != nil {
+				s.ClientSecret.Reset()
+				if err := s.ClientSecret.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"use\"")
+				return errors.Wrap(err, "decode field \"client_secret\"")
 			}
-		case "key_ops":
+		case "client_id_issued_at":
 			if err := func() error {
-				s.KeyOps.Reset()
-				if err := s.KeyOps.Decode(d); err != nil {
+				s.ClientIDIssuedAt.Reset()
+				if err := s.ClientIDIssuedAt.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"key_ops\"")
+				return errors.Wrap(err, "decode field \"client_id_issued_at\"")
 			}
-		case "alg":
+		case "client_secret_expires_at":
 			if err := func() error {
-				s.Alg.Reset()
-				if err := s.Alg.Decode(d); err != nil {
+				s.ClientSecretExpiresAt.Reset()
+				if err := s.ClientSecretExpiresAt.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"alg\"")
+				return errors.Wrap(err, "decode field \"client_secret_expires_at\"")
 			}
-		case "kid":
+		case "registration_access_token":
 			if err := func() error {
-				s.Kid.Reset()
-				if err := s.Kid.Decode(d); err != nil {
+				s.RegistrationAccessToken.Reset()
+				if err := s.RegistrationAccessToken.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"kid\"")
+				return errors.Wrap(err, "decode field \"registration_access_token\"")
 			}
-		case "x5u":
+		case "registration_client_uri":
 			if err := func() error {
-				s.X5u.Reset()
-				if err := s.X5u.Decode(d); err != nil {
+				s.RegistrationClientURI.Reset()
+				if err := s.RegistrationClientURI.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"x5u\"")
+				return errors.Wrap(err, "decode field \"registration_client_uri\"")
 			}
-		case "x5c":
+		case "redirect_uris":
 			if err := func() error {
-				s.X5c.Reset()
-				if err := s.X5c.Decode(d); err != nil {
+				s.RedirectUris = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.RedirectUris = append(s.RedirectUris, elem)
+					return nil
+				}); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"x5c\"")
+				return errors.Wrap(err, "decode field \"redirect_uris\"")
 			}
-		case "x5t":
+		case "token_endpoint_auth_method":
 			if err := func() error {
-				s.X5t.Reset()
-				if err := s.X5t.Decode(d); err != nil {
+				s.TokenEndpointAuthMethod.Reset()
+				if err := s.TokenEndpointAuthMethod.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"x5t\"")
+				return errors.Wrap(err, "decode field \"token_endpoint_auth_method\"")
 			}
-		case "n":
+		case "grant_types":
 			if err := func() error {
-				s.N.Reset()
-				if err := s.N.Decode(d); err != nil {
+				s.GrantTypes = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.GrantTypes = append(s.GrantTypes, elem)
+					return nil
+				}); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"n\"")
+				return errors.Wrap(err, "decode field \"grant_types\"")
 			}
-		case "e":
+		case "response_types":
 			if err := func() error {
-				s.E.Reset()
-				if err := s.E.Decode(d); err != nil {
+				s.ResponseTypes = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.ResponseTypes = append(s.ResponseTypes, elem)
+					return nil
+				}); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"e\"")
+				return errors.Wrap(err, "decode field \"response_types\"")
 			}
-		default:
-			return d.Skip()
-		}
-		return nil
-	}); err != nil {
-		return errors.Wrap(err, "decode JWKResponse")
-	}
-
-	return nil
-}
-
-// MarshalJSON implements stdjson.Marshaler.
-func (s *JWKResponse) MarshalJSON() ([]byte, error) {
-	e := jx.Encoder{}
-	s.Encode(&e)
-	return e.Bytes(), nil
-}
-
-// UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *JWKResponse) UnmarshalJSON(data []byte) error {
-	d := jx.DecodeBytes(data)
-	return s.Decode(d)
-}
-
-// Encode implements json.Marshaler.
-func (s *JWKSetResponse) Encode(e *jx.Encoder) {
-	e.ObjStart()
-	s.encodeFields(e)
-	e.ObjEnd()
-}
-
-// encodeFields encodes fields.
-func (s *JWKSetResponse) encodeFields(e *jx.Encoder) {
-	{
-		e.FieldStart("keys")
-		e.ArrStart()
-		for _, elem := range s.Keys {
-			elem.Encode(e)
-		}
-		e.ArrEnd()
-	}
-}
-
-var jsonFieldsNameOfJWKSetResponse = [1]string{
-	0: "keys",
-}
-
-// Decode decodes JWKSetResponse from json.
-func (s *JWKSetResponse) Decode(d *jx.Decoder) error {
-	if s == nil {
-		return errors.New("invalid: unable to decode JWKSetResponse to nil")
-	}
-	var requiredBitSet [1]uint8
-
-	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
-		switch string(k) {
-		case "keys":
-			requiredBitSet[0] |= 1 << 0
+		case "client_name":
 			if err := func() error {
-				s.Keys = make([]JWKResponse, 0)
+				s.ClientName.Reset()
+				if err := s.ClientName.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"client_name\"")
+			}
+		case "client_uri":
+			if err := func() error {
+				s.ClientURI.Reset()
+				if err := s.ClientURI.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"client_uri\"")
+			}
+		case "scope":
+			if err := func() error {
+				s.Scope.Reset()
+				if err := s.Scope.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"scope\"")
+			}
+		case "jwks_uri":
+			if err := func() error {
+				s.JwksURI.Reset()
+				if err := s.JwksURI.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"jwks_uri\"")
+			}
+		case "post_logout_redirect_uris":
+			if err := func() error {
+				s.PostLogoutRedirectUris = make([]string, 0)
 				if err := d.Arr(func(d *jx.Decoder) error {
-					var elem JWKResponse
-					if err := elem.Decode(d); err != nil {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
 						return err
 					}
-					s.Keys = append(s.Keys, elem)
+					s.PostLogoutRedirectUris = append(s.PostLogoutRedirectUris, elem)
 					return nil
 				}); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"keys\"")
+				return errors.Wrap(err, "decode field \"post_logout_redirect_uris\"")
 			}
-		default:
-			return d.Skip()
-		}
-		return nil
-	}); err != nil {
-		return errors.Wrap(err, "decode JWKSetResponse")
-	}
-	// Validate required fields.
-	var failures []validate.FieldError
-	for i, mask := range [1]uint8{
-		0b00000001,
-	} {
-		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
-			// Mask only required fields and check equality to mask using XOR.
+		case "backchannel_logout_uri":
+			if err := func() error {
+				s.BackchannelLogoutURI.Reset()
+				if err := s.BackchannelLogoutURI.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"backchannel_logout_uri\"")
+			}
+		case "frontchannel_logout_uri":
+			if err := func() error {
+				s.FrontchannelLogoutURI.Reset()
+				if err := s.FrontchannelLogoutURI.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"frontchannel_logout_uri\"")
+			}
+		case "frontchannel_logout_session_required":
+			if err := func() error {
+				s.FrontchannelLogoutSessionRequired.Reset()
+				if err := s.FrontchannelLogoutSessionRequired.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"frontchannel_logout_session_required\"")
+			}
+		case "require_pushed_authorization_requests":
+			if err := func() error {
+				s.RequirePushedAuthorizationRequests.Reset()
+				if err := s.RequirePushedAuthorizationRequests.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"require_pushed_authorization_requests\"")
+			}
+		case "require_signed_request_object":
+			if err := func() error {
+				s.RequireSignedRequestObject.Reset()
+				if err := s.RequireSignedRequestObject.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"require_signed_request_object\"")
+			}
+		case "dpop_bound_access_tokens":
+			if err := func() error {
+				s.DpopBoundAccessTokens.Reset()
+				if err := s.DpopBoundAccessTokens.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"dpop_bound_access_tokens\"")
+			}
+		case "request_uris":
+			if err := func() error {
+				s.RequestUris = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.RequestUris = append(s.RequestUris, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"request_uris\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode ClientInformation")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [3]uint8{
+		0b00000001,
+		0b00000000,
+		0b00000000,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
 			//
 			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
 			// Bits of fields which would be set are actually bits of missed fields.
@@ -340,8 +538,8 @@ func (s *JWKSetResponse) Decode(d *jx.Decoder) error {
 				bitIdx := bits.TrailingZeros8(result)
 				fieldIdx := i*8 + bitIdx
 				var name string
-				if fieldIdx < len(jsonFieldsNameOfJWKSetResponse) {
-					name = jsonFieldsNameOfJWKSetResponse[fieldIdx]
+				if fieldIdx < len(jsonFieldsNameOfClientInformation) {
+					name = jsonFieldsNameOfClientInformation[fieldIdx]
 				} else {
 					name = strconv.Itoa(fieldIdx)
 				}
@@ -362,289 +560,2394 @@ func (s *JWKSetResponse) Decode(d *jx.Decoder) error {
 }
 
 // MarshalJSON implements stdjson.Marshaler.
-func (s *JWKSetResponse) MarshalJSON() ([]byte, error) {
+func (s *ClientInformation) MarshalJSON() ([]byte, error) {
 	e := jx.Encoder{}
 	s.Encode(&e)
 	return e.Bytes(), nil
 }
 
 // UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *JWKSetResponse) UnmarshalJSON(data []byte) error {
+func (s *ClientInformation) UnmarshalJSON(data []byte) error {
 	d := jx.DecodeBytes(data)
 	return s.Decode(d)
 }
 
 // Encode implements json.Marshaler.
-func (s *LoginTokens) Encode(e *jx.Encoder) {
+func (s *ClientMetadata) Encode(e *jx.Encoder) {
 	e.ObjStart()
 	s.encodeFields(e)
 	e.ObjEnd()
 }
 
 // encodeFields encodes fields.
-func (s *LoginTokens) encodeFields(e *jx.Encoder) {
+func (s *ClientMetadata) encodeFields(e *jx.Encoder) {
 	{
-		e.FieldStart("access_token")
-		e.Str(s.AccessToken)
+		if s.ClientID.Set {
+			e.FieldStart("client_id")
+			s.ClientID.Encode(e)
+		}
 	}
 	{
-		e.FieldStart("token_type")
-		e.Str(s.TokenType)
+		if s.ClientSecret.Set {
+			e.FieldStart("client_secret")
+			s.ClientSecret.Encode(e)
+		}
 	}
 	{
-		e.FieldStart("expires_in")
-		e.Float64(s.ExpiresIn)
+		if s.RedirectUris != nil {
+			e.FieldStart("redirect_uris")
+			e.ArrStart()
+			for _, elem := range s.RedirectUris {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
+	}
+	{
+		if s.TokenEndpointAuthMethod.Set {
+			e.FieldStart("token_endpoint_auth_method")
+			s.TokenEndpointAuthMethod.Encode(e)
+		}
+	}
+	{
+		if s.GrantTypes != nil {
+			e.FieldStart("grant_types")
+			e.ArrStart()
+			for _, elem := range s.GrantTypes {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
+	}
+	{
+		if s.ResponseTypes != nil {
+			e.FieldStart("response_types")
+			e.ArrStart()
+			for _, elem := range s.ResponseTypes {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
+	}
+	{
+		if s.ClientName.Set {
+			e.FieldStart("client_name")
+			s.ClientName.Encode(e)
+		}
+	}
+	{
+		if s.ClientURI.Set {
+			e.FieldStart("client_uri")
+			s.ClientURI.Encode(e)
+		}
+	}
+	{
+		if s.Scope.Set {
+			e.FieldStart("scope")
+			s.Scope.Encode(e)
+		}
+	}
+	{
+		if s.JwksURI.Set {
+			e.FieldStart("jwks_uri")
+			s.JwksURI.Encode(e)
+		}
+	}
+	{
+		if s.PostLogoutRedirectUris != nil {
+			e.FieldStart("post_logout_redirect_uris")
+			e.ArrStart()
+			for _, elem := range s.PostLogoutRedirectUris {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
+	}
+	{
+		if s.BackchannelLogoutURI.Set {
+			e.FieldStart("backchannel_logout_uri")
+			s.BackchannelLogoutURI.Encode(e)
+		}
+	}
+	{
+		if s.FrontchannelLogoutURI.Set {
+			e.FieldStart("frontchannel_logout_uri")
+			s.FrontchannelLogoutURI.Encode(e)
+		}
+	}
+	{
+		if s.FrontchannelLogoutSessionRequired.Set {
+			e.FieldStart("frontchannel_logout_session_required")
+			s.FrontchannelLogoutSessionRequired.Encode(e)
+		}
+	}
+	{
+		if s.RequirePushedAuthorizationRequests.Set {
+			e.FieldStart("require_pushed_authorization_requests")
+			s.RequirePushedAuthorizationRequests.Encode(e)
+		}
 	}
 	{
-		e.FieldStart("id_token")
-		e.Str(s.IDToken)
+		if s.RequireSignedRequestObject.Set {
+			e.FieldStart("require_signed_request_object")
+			s.RequireSignedRequestObject.Encode(e)
+		}
+	}
+	{
+		if s.DpopBoundAccessTokens.Set {
+			e.FieldStart("dpop_bound_access_tokens")
+			s.DpopBoundAccessTokens.Encode(e)
+		}
 	}
 	{
-		e.FieldStart("refresh_token")
-		e.Str(s.RefreshToken)
+		if s.RequestUris != nil {
+			e.FieldStart("request_uris")
+			e.ArrStart()
+			for _, elem := range s.RequestUris {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
 	}
 }
 
-var jsonFieldsNameOfLoginTokens = [5]string{
-	0: "access_token",
-	1: "token_type",
-	2: "expires_in",
-	3: "id_token",
-	4: "refresh_token",
+var jsonFieldsNameOfClientMetadata = [18]string{
+	0:  "client_id",
+	1:  "client_secret",
+	2:  "redirect_uris",
+	3:  "token_endpoint_auth_method",
+	4:  "grant_types",
+	5:  "response_types",
+	6:  "client_name",
+	7:  "client_uri",
+	8:  "scope",
+	9:  "jwks_uri",
+	10: "post_logout_redirect_uris",
+	11: "backchannel_logout_uri",
+	12: "frontchannel_logout_uri",
+	13: "frontchannel_logout_session_required",
+	14: "require_pushed_authorization_requests",
+	15: "require_signed_request_object",
+	16: "dpop_bound_access_tokens",
+	17: "request_uris",
 }
 
-// Decode decodes LoginTokens from json.
-func (s *LoginTokens) Decode(d *jx.Decoder) error {
+// Decode decodes ClientMetadata from json.
+func (s *ClientMetadata) Decode(d *jx.Decoder) error {
 	if s == nil {
-		return errors.New("invalid: unable to decode LoginTokens to nil")
+		return errors.New("invalid: unable to decode ClientMetadata to nil")
 	}
-	var requiredBitSet [1]uint8
 
 	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
 		switch string(k) {
-		case "access_token":
-			requiredBitSet[0] |= 1 << 0
+		case "client_id":
 			if err := func() error {
-				v, err := d.Str()
-				s.AccessToken = string(v)
-				if err != nil {
+				s.ClientID.Reset()
+				if err := s.ClientID.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"access_token\"")
+				return errors.Wrap(err, "decode field \"client_id\"")
 			}
-		case "token_type":
-			requiredBitSet[0] |= 1 << 1
+		case "client_secret":
 			if err := func() error {
-				v, err := d.Str()
-				s.TokenType = string(v)
-				if err != nil {
+				s.ClientSecret.Reset()
+				if err := s.ClientSecret.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"token_type\"")
+				return errors.Wrap(err, "decode field \"client_secret\"")
 			}
-		case "expires_in":
-			requiredBitSet[0] |= 1 << 2
+		case "redirect_uris":
 			if err := func() error {
-				v, err := d.Float64()
-				s.ExpiresIn = float64(v)
-				if err != nil {
+				s.RedirectUris = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.RedirectUris = append(s.RedirectUris, elem)
+					return nil
+				}); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"expires_in\"")
+				return errors.Wrap(err, "decode field \"redirect_uris\"")
 			}
-		case "id_token":
-			requiredBitSet[0] |= 1 << 3
+		case "token_endpoint_auth_method":
+			if err := func() error {
+				s.TokenEndpointAuthMethod.Reset()
+				if err := s.TokenEndpointAuthMethod.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"token_endpoint_auth_method\"")
+			}
+		case "grant_types":
+			if err := func() error {
+				s.GrantTypes = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.GrantTypes = append(s.GrantTypes, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"grant_types\"")
+			}
+		case "response_types":
+			if err := func() error {
+				s.ResponseTypes = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.ResponseTypes = append(s.ResponseTypes, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"response_types\"")
+			}
+		case "client_name":
+			if err := func() error {
+				s.ClientName.Reset()
+				if err := s.ClientName.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"client_name\"")
+			}
+		case "client_uri":
+			if err := func() error {
+				s.ClientURI.Reset()
+				if err := s.ClientURI.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"client_uri\"")
+			}
+		case "scope":
+			if err := func() error {
+				s.Scope.Reset()
+				if err := s.Scope.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"scope\"")
+			}
+		case "jwks_uri":
+			if err := func() error {
+				s.JwksURI.Reset()
+				if err := s.JwksURI.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"jwks_uri\"")
+			}
+		case "post_logout_redirect_uris":
+			if err := func() error {
+				s.PostLogoutRedirectUris = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.PostLogoutRedirectUris = append(s.PostLogoutRedirectUris, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"post_logout_redirect_uris\"")
+			}
+		case "backchannel_logout_uri":
+			if err := func() error {
+				s.BackchannelLogoutURI.Reset()
+				if err := s.BackchannelLogoutURI.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"backchannel_logout_uri\"")
+			}
+		case "frontchannel_logout_uri":
+			if err := func() error {
+				s.FrontchannelLogoutURI.Reset()
+				if err := s.FrontchannelLogoutURI.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"frontchannel_logout_uri\"")
+			}
+		case "frontchannel_logout_session_required":
+			if err := func() error {
+				s.FrontchannelLogoutSessionRequired.Reset()
+				if err := s.FrontchannelLogoutSessionRequired.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"frontchannel_logout_session_required\"")
+			}
+		case "require_pushed_authorization_requests":
+			if err := func() error {
+				s.RequirePushedAuthorizationRequests.Reset()
+				if err := s.RequirePushedAuthorizationRequests.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"require_pushed_authorization_requests\"")
+			}
+		case "require_signed_request_object":
+			if err := func() error {
+				s.RequireSignedRequestObject.Reset()
+				if err := s.RequireSignedRequestObject.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"require_signed_request_object\"")
+			}
+		case "dpop_bound_access_tokens":
+			if err := func() error {
+				s.DpopBoundAccessTokens.Reset()
+				if err := s.DpopBoundAccessTokens.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"dpop_bound_access_tokens\"")
+			}
+		case "request_uris":
+			if err := func() error {
+				s.RequestUris = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.RequestUris = append(s.RequestUris, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"request_uris\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode ClientMetadata")
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *ClientMetadata) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *ClientMetadata) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *Confirmation) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *Confirmation) encodeFields(e *jx.Encoder) {
+	{
+		if s.Jkt.Set {
+			e.FieldStart("jkt")
+			s.Jkt.Encode(e)
+		}
+	}
+}
+
+var jsonFieldsNameOfConfirmation = [1]string{
+	0: "jkt",
+}
+
+// Decode decodes Confirmation from json.
+func (s *Confirmation) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode Confirmation to nil")
+	}
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "jkt":
+			if err := func() error {
+				s.Jkt.Reset()
+				if err := s.Jkt.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"jkt\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode Confirmation")
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *Confirmation) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *Confirmation) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *DeviceAuthorizationResponse) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *DeviceAuthorizationResponse) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("device_code")
+		e.Str(s.DeviceCode)
+	}
+	{
+		e.FieldStart("user_code")
+		e.Str(s.UserCode)
+	}
+	{
+		e.FieldStart("verification_uri")
+		e.Str(s.VerificationURI)
+	}
+	{
+		if s.VerificationURIComplete.Set {
+			e.FieldStart("verification_uri_complete")
+			s.VerificationURIComplete.Encode(e)
+		}
+	}
+	{
+		e.FieldStart("expires_in")
+		e.Int64(s.ExpiresIn)
+	}
+	{
+		if s.Interval.Set {
+			e.FieldStart("interval")
+			s.Interval.Encode(e)
+		}
+	}
+}
+
+var jsonFieldsNameOfDeviceAuthorizationResponse = [6]string{
+	0: "device_code",
+	1: "user_code",
+	2: "verification_uri",
+	3: "verification_uri_complete",
+	4: "expires_in",
+	5: "interval",
+}
+
+// Decode decodes DeviceAuthorizationResponse from json.
+func (s *DeviceAuthorizationResponse) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode DeviceAuthorizationResponse to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "device_code":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Str()
+				s.DeviceCode = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"device_code\"")
+			}
+		case "user_code":
+			requiredBitSet[0] |= 1 << 1
+			if err := func() error {
+				v, err := d.Str()
+				s.UserCode = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"user_code\"")
+			}
+		case "verification_uri":
+			requiredBitSet[0] |= 1 << 2
+			if err := func() error {
+				v, err := d.Str()
+				s.VerificationURI = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"verification_uri\"")
+			}
+		case "verification_uri_complete":
+			if err := func() error {
+				s.VerificationURIComplete.Reset()
+				if err := s.VerificationURIComplete.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"verification_uri_complete\"")
+			}
+		case "expires_in":
+			requiredBitSet[0] |= 1 << 4
+			if err := func() error {
+				v, err := d.Int64()
+				s.ExpiresIn = int64(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"expires_in\"")
+			}
+		case "interval":
+			if err := func() error {
+				s.Interval.Reset()
+				if err := s.Interval.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"interval\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode DeviceAuthorizationResponse")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00010111,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfDeviceAuthorizationResponse) {
+					name = jsonFieldsNameOfDeviceAuthorizationResponse[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *DeviceAuthorizationResponse) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *DeviceAuthorizationResponse) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *ErrorResponse) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *ErrorResponse) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("error")
+		e.Str(s.Error)
+	}
+	{
+		if s.ErrorDescription.Set {
+			e.FieldStart("error_description")
+			s.ErrorDescription.Encode(e)
+		}
+	}
+}
+
+var jsonFieldsNameOfErrorResponse = [2]string{
+	0: "error",
+	1: "error_description",
+}
+
+// Decode decodes ErrorResponse from json.
+func (s *ErrorResponse) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode ErrorResponse to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "error":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Str()
+				s.Error = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"error\"")
+			}
+		case "error_description":
+			if err := func() error {
+				s.ErrorDescription.Reset()
+				if err := s.ErrorDescription.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"error_description\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode ErrorResponse")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000001,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfErrorResponse) {
+					name = jsonFieldsNameOfErrorResponse[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *ErrorResponse) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *ErrorResponse) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *IntrospectionResponse) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *IntrospectionResponse) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("active")
+		e.Bool(s.Active)
+	}
+	{
+		if s.Scope.Set {
+			e.FieldStart("scope")
+			s.Scope.Encode(e)
+		}
+	}
+	{
+		if s.ClientID.Set {
+			e.FieldStart("client_id")
+			s.ClientID.Encode(e)
+		}
+	}
+	{
+		if s.Sub.Set {
+			e.FieldStart("sub")
+			s.Sub.Encode(e)
+		}
+	}
+	{
+		if s.TokenType.Set {
+			e.FieldStart("token_type")
+			s.TokenType.Encode(e)
+		}
+	}
+	{
+		if s.Iss.Set {
+			e.FieldStart("iss")
+			s.Iss.Encode(e)
+		}
+	}
+	{
+		if s.Exp.Set {
+			e.FieldStart("exp")
+			s.Exp.Encode(e)
+		}
+	}
+	{
+		if s.Iat.Set {
+			e.FieldStart("iat")
+			s.Iat.Encode(e)
+		}
+	}
+	{
+		if s.Nbf.Set {
+			e.FieldStart("nbf")
+			s.Nbf.Encode(e)
+		}
+	}
+	{
+		if s.Sid.Set {
+			e.FieldStart("sid")
+			s.Sid.Encode(e)
+		}
+	}
+	{
+		if s.Cnf.Set {
+			e.FieldStart("cnf")
+			s.Cnf.Encode(e)
+		}
+	}
+}
+
+var jsonFieldsNameOfIntrospectionResponse = [11]string{
+	0:  "active",
+	1:  "scope",
+	2:  "client_id",
+	3:  "sub",
+	4:  "token_type",
+	5:  "iss",
+	6:  "exp",
+	7:  "iat",
+	8:  "nbf",
+	9:  "sid",
+	10: "cnf",
+}
+
+// Decode decodes IntrospectionResponse from json.
+func (s *IntrospectionResponse) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode IntrospectionResponse to nil")
+	}
+	var requiredBitSet [2]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "active":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Bool()
+				s.Active = bool(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"active\"")
+			}
+		case "scope":
+			if err := func() error {
+				s.Scope.Reset()
+				if err := s.Scope.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"scope\"")
+			}
+		case "client_id":
+			if err := func() error {
+				s.ClientID.Reset()
+				if err := s.ClientID.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"client_id\"")
+			}
+		case "sub":
+			if err := func() error {
+				s.Sub.Reset()
+				if err := s.Sub.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"sub\"")
+			}
+		case "token_type":
+			if err := func() error {
+				s.TokenType.Reset()
+				if err := s.TokenType.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"token_type\"")
+			}
+		case "iss":
+			if err := func() error {
+				s.Iss.Reset()
+				if err := s.Iss.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"iss\"")
+			}
+		case "exp":
+			if err := func() error {
+				s.Exp.Reset()
+				if err := s.Exp.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"exp\"")
+			}
+		case "iat":
+			if err := func() error {
+				s.Iat.Reset()
+				if err := s.Iat.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"iat\"")
+			}
+		case "nbf":
+			if err := func() error {
+				s.Nbf.Reset()
+				if err := s.Nbf.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"nbf\"")
+			}
+		case "sid":
+			if err := func() error {
+				s.Sid.Reset()
+				if err := s.Sid.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"sid\"")
+			}
+		case "cnf":
+			if err := func() error {
+				s.Cnf.Reset()
+				if err := s.Cnf.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"cnf\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode IntrospectionResponse")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [2]uint8{
+		0b00000001,
+		0b00000000,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfIntrospectionResponse) {
+					name = jsonFieldsNameOfIntrospectionResponse[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *IntrospectionResponse) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *IntrospectionResponse) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *JWKResponse) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *JWKResponse) encodeFields(e *jx.Encoder) {
+	{
+		if s.Kty.Set {
+			e.FieldStart("kty")
+			s.Kty.Encode(e)
+		}
+	}
+	{
+		if s.Use.Set {
+			e.FieldStart("use")
+			s.Use.Encode(e)
+		}
+	}
+	{
+		if s.KeyOps.Set {
+			e.FieldStart("key_ops")
+			s.KeyOps.Encode(e)
+		}
+	}
+	{
+		if s.Alg.Set {
+			e.FieldStart("alg")
+			s.Alg.Encode(e)
+		}
+	}
+	{
+		if s.Kid.Set {
+			e.FieldStart("kid")
+			s.Kid.Encode(e)
+		}
+	}
+	{
+		if s.X5u.Set {
+			e.FieldStart("x5u")
+			s.X5u.Encode(e)
+		}
+	}
+	{
+		if s.X5c.Set {
+			e.FieldStart("x5c")
+			s.X5c.Encode(e)
+		}
+	}
+	{
+		if s.X5t.Set {
+			e.FieldStart("x5t")
+			s.X5t.Encode(e)
+		}
+	}
+	{
+		if s.N.Set {
+			e.FieldStart("n")
+			s.N.Encode(e)
+		}
+	}
+	{
+		if s.E.Set {
+			e.FieldStart("e")
+			s.E.Encode(e)
+		}
+	}
+}
+
+var jsonFieldsNameOfJWKResponse = [10]string{
+	0: "kty",
+	1: "use",
+	2: "key_ops",
+	3: "alg",
+	4: "kid",
+	5: "x5u",
+	6: "x5c",
+	7: "x5t",
+	8: "n",
+	9: "e",
+}
+
+// Decode decodes JWKResponse from json.
+func (s *JWKResponse) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode JWKResponse to nil")
+	}
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "kty":
+			if err := func() error {
+				s.Kty.Reset()
+				if err := s.Kty.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"kty\"")
+			}
+		case "use":
+			if err := func() error {
+				s.Use.Reset()
+				if err := s.Use.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"use\"")
+			}
+		case "key_ops":
+			if err := func() error {
+				s.KeyOps.Reset()
+				if err := s.KeyOps.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"key_ops\"")
+			}
+		case "alg":
+			if err := func() error {
+				s.Alg.Reset()
+				if err := s.Alg.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"alg\"")
+			}
+		case "kid":
+			if err := func() error {
+				s.Kid.Reset()
+				if err := s.Kid.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"kid\"")
+			}
+		case "x5u":
+			if err := func() error {
+				s.X5u.Reset()
+				if err := s.X5u.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"x5u\"")
+			}
+		case "x5c":
+			if err := func() error {
+				s.X5c.Reset()
+				if err := s.X5c.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"x5c\"")
+			}
+		case "x5t":
+			if err := func() error {
+				s.X5t.Reset()
+				if err := s.X5t.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"x5t\"")
+			}
+		case "n":
+			if err := func() error {
+				s.N.Reset()
+				if err := s.N.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"n\"")
+			}
+		case "e":
+			if err := func() error {
+				s.E.Reset()
+				if err := s.E.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"e\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode JWKResponse")
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *JWKResponse) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *JWKResponse) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *JWKSetResponse) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *JWKSetResponse) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("keys")
+		e.ArrStart()
+		for _, elem := range s.Keys {
+			elem.Encode(e)
+		}
+		e.ArrEnd()
+	}
+}
+
+var jsonFieldsNameOfJWKSetResponse = [1]string{
+	0: "keys",
+}
+
+// Decode decodes JWKSetResponse from json.
+func (s *JWKSetResponse) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode JWKSetResponse to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "keys":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				s.Keys = make([]JWKResponse, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem JWKResponse
+					if err := elem.Decode(d); err != nil {
+						return err
+					}
+					s.Keys = append(s.Keys, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"keys\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode JWKSetResponse")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000001,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfJWKSetResponse) {
+					name = jsonFieldsNameOfJWKSetResponse[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *JWKSetResponse) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *JWKSetResponse) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *LoginTokens) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *LoginTokens) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("access_token")
+		e.Str(s.AccessToken)
+	}
+	{
+		e.FieldStart("token_type")
+		e.Str(s.TokenType)
+	}
+	{
+		e.FieldStart("expires_in")
+		e.Float64(s.ExpiresIn)
+	}
+	{
+		if s.IDToken.Set {
+			e.FieldStart("id_token")
+			s.IDToken.Encode(e)
+		}
+	}
+	{
+		if s.RefreshToken.Set {
+			e.FieldStart("refresh_token")
+			s.RefreshToken.Encode(e)
+		}
+	}
+	{
+		if s.Scope.Set {
+			e.FieldStart("scope")
+			s.Scope.Encode(e)
+		}
+	}
+	{
+		if s.IssuedTokenType.Set {
+			e.FieldStart("issued_token_type")
+			s.IssuedTokenType.Encode(e)
+		}
+	}
+}
+
+var jsonFieldsNameOfLoginTokens = [7]string{
+	0: "access_token",
+	1: "token_type",
+	2: "expires_in",
+	3: "id_token",
+	4: "refresh_token",
+	5: "scope",
+	6: "issued_token_type",
+}
+
+// Decode decodes LoginTokens from json.
+func (s *LoginTokens) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode LoginTokens to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "access_token":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Str()
+				s.AccessToken = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"access_token\"")
+			}
+		case "token_type":
+			requiredBitSet[0] |= 1 << 1
+			if err := func() error {
+				v, err := d.Str()
+				s.TokenType = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"token_type\"")
+			}
+		case "expires_in":
+			requiredBitSet[0] |= 1 << 2
+			if err := func() error {
+				v, err := d.Float64()
+				s.ExpiresIn = float64(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"expires_in\"")
+			}
+		case "id_token":
+			if err := func() error {
+				s.IDToken.Reset()
+				if err := s.IDToken.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"id_token\"")
+			}
+		case "refresh_token":
+			if err := func() error {
+				s.RefreshToken.Reset()
+				if err := s.RefreshToken.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"refresh_token\"")
+			}
+		case "scope":
+			if err := func() error {
+				s.Scope.Reset()
+				if err := s.Scope.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"scope\"")
+			}
+		case "issued_token_type":
+			if err := func() error {
+				s.IssuedTokenType.Reset()
+				if err := s.IssuedTokenType.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"issued_token_type\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode LoginTokens")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000111,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfLoginTokens) {
+					name = jsonFieldsNameOfLoginTokens[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *LoginTokens) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *LoginTokens) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *OAuthError) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *OAuthError) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("error")
+		e.Str(s.Error)
+	}
+	{
+		if s.ErrorDescription.Set {
+			e.FieldStart("error_description")
+			s.ErrorDescription.Encode(e)
+		}
+	}
+}
+
+var jsonFieldsNameOfOAuthError = [2]string{
+	0: "error",
+	1: "error_description",
+}
+
+// Decode decodes OAuthError from json.
+func (s *OAuthError) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode OAuthError to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "error":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Str()
+				s.Error = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"error\"")
+			}
+		case "error_description":
+			if err := func() error {
+				s.ErrorDescription.Reset()
+				if err := s.ErrorDescription.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"error_description\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode OAuthError")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000001,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfOAuthError) {
+					name = jsonFieldsNameOfOAuthError[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *OAuthError) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *OAuthError) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *OpenIDProviderMetadataResponse) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *OpenIDProviderMetadataResponse) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("issuer")
+		e.Str(s.Issuer)
+	}
+	{
+		e.FieldStart("authorization_endpoint")
+		e.Str(s.AuthorizationEndpoint)
+	}
+	{
+		e.FieldStart("token_endpoint")
+		e.Str(s.TokenEndpoint)
+	}
+	{
+		e.FieldStart("jwks_uri")
+		e.Str(s.JwksURI)
+	}
+	{
+		e.FieldStart("userinfo_endpoint")
+		e.Str(s.UserinfoEndpoint)
+	}
+	{
+		if s.TokenEndpointAuthMethodsSupported != nil {
+			e.FieldStart("token_endpoint_auth_methods_supported")
+			e.ArrStart()
+			for _, elem := range s.TokenEndpointAuthMethodsSupported {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
+	}
+	{
+		if s.TokenEndpointAuthSigningAlgValuesSupported != nil {
+			e.FieldStart("token_endpoint_auth_signing_alg_values_supported")
+			e.ArrStart()
+			for _, elem := range s.TokenEndpointAuthSigningAlgValuesSupported {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
+	}
+	{
+		e.FieldStart("response_types_supported")
+		e.ArrStart()
+		for _, elem := range s.ResponseTypesSupported {
+			e.Str(elem)
+		}
+		e.ArrEnd()
+	}
+	{
+		if s.ResponseModesSupported != nil {
+			e.FieldStart("response_modes_supported")
+			e.ArrStart()
+			for _, elem := range s.ResponseModesSupported {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
+	}
+	{
+		e.FieldStart("subject_types_supported")
+		e.ArrStart()
+		for _, elem := range s.SubjectTypesSupported {
+			e.Str(elem)
+		}
+		e.ArrEnd()
+	}
+	{
+		e.FieldStart("id_token_signing_alg_values_supported")
+		e.ArrStart()
+		for _, elem := range s.IDTokenSigningAlgValuesSupported {
+			e.Str(elem)
+		}
+		e.ArrEnd()
+	}
+	{
+		if s.ScopesSupported != nil {
+			e.FieldStart("scopes_supported")
+			e.ArrStart()
+			for _, elem := range s.ScopesSupported {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
+	}
+	{
+		if s.ClaimsSupported != nil {
+			e.FieldStart("claims_supported")
+			e.ArrStart()
+			for _, elem := range s.ClaimsSupported {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
+	}
+	{
+		if s.GrantTypesSupported != nil {
+			e.FieldStart("grant_types_supported")
+			e.ArrStart()
+			for _, elem := range s.GrantTypesSupported {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
+	}
+	{
+		if s.CodeChallengeMethodsSupported != nil {
+			e.FieldStart("code_challenge_methods_supported")
+			e.ArrStart()
+			for _, elem := range s.CodeChallengeMethodsSupported {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
+	}
+	{
+		if s.RequestObjectSigningAlgValuesSupported != nil {
+			e.FieldStart("request_object_signing_alg_values_supported")
+			e.ArrStart()
+			for _, elem := range s.RequestObjectSigningAlgValuesSupported {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
+	}
+	{
+		if s.RequestParameterSupported.Set {
+			e.FieldStart("request_parameter_supported")
+			s.RequestParameterSupported.Encode(e)
+		}
+	}
+	{
+		if s.RequestURIParameterSupported.Set {
+			e.FieldStart("request_uri_parameter_supported")
+			s.RequestURIParameterSupported.Encode(e)
+		}
+	}
+	{
+		if s.RequireRequestURIRegistration.Set {
+			e.FieldStart("require_request_uri_registration")
+			s.RequireRequestURIRegistration.Encode(e)
+		}
+	}
+	{
+		if s.DpopSigningAlgValuesSupported != nil {
+			e.FieldStart("dpop_signing_alg_values_supported")
+			e.ArrStart()
+			for _, elem := range s.DpopSigningAlgValuesSupported {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
+	}
+	{
+		if s.EndSessionEndpoint.Set {
+			e.FieldStart("end_session_endpoint")
+			s.EndSessionEndpoint.Encode(e)
+		}
+	}
+	{
+		if s.RevocationEndpoint.Set {
+			e.FieldStart("revocation_endpoint")
+			s.RevocationEndpoint.Encode(e)
+		}
+	}
+	{
+		if s.IntrospectionEndpoint.Set {
+			e.FieldStart("introspection_endpoint")
+			s.IntrospectionEndpoint.Encode(e)
+		}
+	}
+	{
+		if s.DeviceAuthorizationEndpoint.Set {
+			e.FieldStart("device_authorization_endpoint")
+			s.DeviceAuthorizationEndpoint.Encode(e)
+		}
+	}
+	{
+		if s.RegistrationEndpoint.Set {
+			e.FieldStart("registration_endpoint")
+			s.RegistrationEndpoint.Encode(e)
+		}
+	}
+	{
+		if s.PushedAuthorizationRequestEndpoint.Set {
+			e.FieldStart("pushed_authorization_request_endpoint")
+			s.PushedAuthorizationRequestEndpoint.Encode(e)
+		}
+	}
+	{
+		if s.RequirePushedAuthorizationRequests.Set {
+			e.FieldStart("require_pushed_authorization_requests")
+			s.RequirePushedAuthorizationRequests.Encode(e)
+		}
+	}
+	{
+		if s.BackchannelLogoutSupported.Set {
+			e.FieldStart("backchannel_logout_supported")
+			s.BackchannelLogoutSupported.Encode(e)
+		}
+	}
+	{
+		if s.BackchannelLogoutSessionSupported.Set {
+			e.FieldStart("backchannel_logout_session_supported")
+			s.BackchannelLogoutSessionSupported.Encode(e)
+		}
+	}
+	{
+		if s.FrontchannelLogoutSupported.Set {
+			e.FieldStart("frontchannel_logout_supported")
+			s.FrontchannelLogoutSupported.Encode(e)
+		}
+	}
+	{
+		if s.FrontchannelLogoutSessionSupported.Set {
+			e.FieldStart("frontchannel_logout_session_supported")
+			s.FrontchannelLogoutSessionSupported.Encode(e)
+		}
+	}
+}
+
+var jsonFieldsNameOfOpenIDProviderMetadataResponse = [31]string{
+	0:  "issuer",
+	1:  "authorization_endpoint",
+	2:  "token_endpoint",
+	3:  "jwks_uri",
+	4:  "userinfo_endpoint",
+	5:  "token_endpoint_auth_methods_supported",
+	6:  "token_endpoint_auth_signing_alg_values_supported",
+	7:  "response_types_supported",
+	8:  "response_modes_supported",
+	9:  "subject_types_supported",
+	10: "id_token_signing_alg_values_supported",
+	11: "scopes_supported",
+	12: "claims_supported",
+	13: "grant_types_supported",
+	14: "code_challenge_methods_supported",
+	15: "request_object_signing_alg_values_supported",
+	16: "request_parameter_supported",
+	17: "request_uri_parameter_supported",
+	18: "require_request_uri_registration",
+	19: "dpop_signing_alg_values_supported",
+	20: "end_session_endpoint",
+	21: "revocation_endpoint",
+	22: "introspection_endpoint",
+	23: "device_authorization_endpoint",
+	24: "registration_endpoint",
+	25: "pushed_authorization_request_endpoint",
+	26: "require_pushed_authorization_requests",
+	27: "backchannel_logout_supported",
+	28: "backchannel_logout_session_supported",
+	29: "frontchannel_logout_supported",
+	30: "frontchannel_logout_session_supported",
+}
+
+// Decode decodes OpenIDProviderMetadataResponse from json.
+func (s *OpenIDProviderMetadataResponse) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode OpenIDProviderMetadataResponse to nil")
+	}
+	var requiredBitSet [4]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "issuer":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Str()
+				s.Issuer = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"issuer\"")
+			}
+		case "authorization_endpoint":
+			requiredBitSet[0] |= 1 << 1
+			if err := func() error {
+				v, err := d.Str()
+				s.AuthorizationEndpoint = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"authorization_endpoint\"")
+			}
+		case "token_endpoint":
+			requiredBitSet[0] |= 1 << 2
+			if err := func() error {
+				v, err := d.Str()
+				s.TokenEndpoint = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"token_endpoint\"")
+			}
+		case "jwks_uri":
+			requiredBitSet[0] |= 1 << 3
+			if err := func() error {
+				v, err := d.Str()
+				s.JwksURI = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"jwks_uri\"")
+			}
+		case "userinfo_endpoint":
+			requiredBitSet[0] |= 1 << 4
+			if err := func() error {
+				v, err := d.Str()
+				s.UserinfoEndpoint = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"userinfo_endpoint\"")
+			}
+		case "token_endpoint_auth_methods_supported":
+			if err := func() error {
+				s.TokenEndpointAuthMethodsSupported = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.TokenEndpointAuthMethodsSupported = append(s.TokenEndpointAuthMethodsSupported, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"token_endpoint_auth_methods_supported\"")
+			}
+		case "token_endpoint_auth_signing_alg_values_supported":
+			if err := func() error {
+				s.TokenEndpointAuthSigningAlgValuesSupported = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.TokenEndpointAuthSigningAlgValuesSupported = append(s.TokenEndpointAuthSigningAlgValuesSupported, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"token_endpoint_auth_signing_alg_values_supported\"")
+			}
+		case "response_types_supported":
+			requiredBitSet[0] |= 1 << 7
+			if err := func() error {
+				s.ResponseTypesSupported = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.ResponseTypesSupported = append(s.ResponseTypesSupported, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"response_types_supported\"")
+			}
+		case "response_modes_supported":
+			if err := func() error {
+				s.ResponseModesSupported = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.ResponseModesSupported = append(s.ResponseModesSupported, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"response_modes_supported\"")
+			}
+		case "subject_types_supported":
+			requiredBitSet[1] |= 1 << 1
+			if err := func() error {
+				s.SubjectTypesSupported = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.SubjectTypesSupported = append(s.SubjectTypesSupported, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"subject_types_supported\"")
+			}
+		case "id_token_signing_alg_values_supported":
+			requiredBitSet[1] |= 1 << 2
+			if err := func() error {
+				s.IDTokenSigningAlgValuesSupported = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.IDTokenSigningAlgValuesSupported = append(s.IDTokenSigningAlgValuesSupported, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"id_token_signing_alg_values_supported\"")
+			}
+		case "scopes_supported":
+			if err := func() error {
+				s.ScopesSupported = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.ScopesSupported = append(s.ScopesSupported, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"scopes_supported\"")
+			}
+		case "claims_supported":
+			if err := func() error {
+				s.ClaimsSupported = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.ClaimsSupported = append(s.ClaimsSupported, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"claims_supported\"")
+			}
+		case "grant_types_supported":
+			if err := func() error {
+				s.GrantTypesSupported = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.GrantTypesSupported = append(s.GrantTypesSupported, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"grant_types_supported\"")
+			}
+		case "code_challenge_methods_supported":
+			if err := func() error {
+				s.CodeChallengeMethodsSupported = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.CodeChallengeMethodsSupported = append(s.CodeChallengeMethodsSupported, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"code_challenge_methods_supported\"")
+			}
+		case "request_object_signing_alg_values_supported":
+			if err := func() error {
+				s.RequestObjectSigningAlgValuesSupported = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.RequestObjectSigningAlgValuesSupported = append(s.RequestObjectSigningAlgValuesSupported, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"request_object_signing_alg_values_supported\"")
+			}
+		case "request_parameter_supported":
+			if err := func() error {
+				s.RequestParameterSupported.Reset()
+				if err := s.RequestParameterSupported.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"request_parameter_supported\"")
+			}
+		case "request_uri_parameter_supported":
+			if err := func() error {
+				s.RequestURIParameterSupported.Reset()
+				if err := s.RequestURIParameterSupported.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"request_uri_parameter_supported\"")
+			}
+		case "require_request_uri_registration":
+			if err := func() error {
+				s.RequireRequestURIRegistration.Reset()
+				if err := s.RequireRequestURIRegistration.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"require_request_uri_registration\"")
+			}
+		case "dpop_signing_alg_values_supported":
+			if err := func() error {
+				s.DpopSigningAlgValuesSupported = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.DpopSigningAlgValuesSupported = append(s.DpopSigningAlgValuesSupported, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"dpop_signing_alg_values_supported\"")
+			}
+		case "end_session_endpoint":
+			if err := func() error {
+				s.EndSessionEndpoint.Reset()
+				if err := s.EndSessionEndpoint.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"end_session_endpoint\"")
+			}
+		case "revocation_endpoint":
+			if err := func() error {
+				s.RevocationEndpoint.Reset()
+				if err := s.RevocationEndpoint.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"revocation_endpoint\"")
+			}
+		case "introspection_endpoint":
+			if err := func() error {
+				s.IntrospectionEndpoint.Reset()
+				if err := s.IntrospectionEndpoint.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"introspection_endpoint\"")
+			}
+		case "device_authorization_endpoint":
 			if err := func() error {
-				v, err := d.Str()
-				s.IDToken = string(v)
-				if err != nil {
+				s.DeviceAuthorizationEndpoint.Reset()
+				if err := s.DeviceAuthorizationEndpoint.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"id_token\"")
+				return errors.Wrap(err, "decode field \"device_authorization_endpoint\"")
 			}
-		case "refresh_token":
-			requiredBitSet[0] |= 1 << 4
+		case "registration_endpoint":
 			if err := func() error {
-				v, err := d.Str()
-				s.RefreshToken = string(v)
-				if err != nil {
+				s.RegistrationEndpoint.Reset()
+				if err := s.RegistrationEndpoint.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"refresh_token\"")
+				return errors.Wrap(err, "decode field \"registration_endpoint\"")
 			}
-		default:
-			return d.Skip()
-		}
-		return nil
-	}); err != nil {
-		return errors.Wrap(err, "decode LoginTokens")
-	}
-	// Validate required fields.
-	var failures []validate.FieldError
-	for i, mask := range [1]uint8{
-		0b00011111,
-	} {
-		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
-			// Mask only required fields and check equality to mask using XOR.
-			//
-			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
-			// Bits of fields which would be set are actually bits of missed fields.
-			missed := bits.OnesCount8(result)
-			for bitN := 0; bitN < missed; bitN++ {
-				bitIdx := bits.TrailingZeros8(result)
-				fieldIdx := i*8 + bitIdx
-				var name string
-				if fieldIdx < len(jsonFieldsNameOfLoginTokens) {
-					name = jsonFieldsNameOfLoginTokens[fieldIdx]
-				} else {
-					name = strconv.Itoa(fieldIdx)
+		case "pushed_authorization_request_endpoint":
+			if err := func() error {
+				s.PushedAuthorizationRequestEndpoint.Reset()
+				if err := s.PushedAuthorizationRequestEndpoint.Decode(d); err != nil {
+					return err
 				}
-				failures = append(failures, validate.FieldError{
-					Name:  name,
-					Error: validate.ErrFieldRequired,
-				})
-				// Reset bit.
-				result &^= 1 << bitIdx
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"pushed_authorization_request_endpoint\"")
 			}
-		}
-	}
-	if len(failures) > 0 {
-		return &validate.Error{Fields: failures}
-	}
-
-	return nil
-}
-
-// MarshalJSON implements stdjson.Marshaler.
-func (s *LoginTokens) MarshalJSON() ([]byte, error) {
-	e := jx.Encoder{}
-	s.Encode(&e)
-	return e.Bytes(), nil
-}
-
-// UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *LoginTokens) UnmarshalJSON(data []byte) error {
-	d := jx.DecodeBytes(data)
-	return s.Decode(d)
-}
-
-// Encode implements json.Marshaler.
-func (s *OpenIDProviderMetadataResponse) Encode(e *jx.Encoder) {
-	e.ObjStart()
-	s.encodeFields(e)
-	e.ObjEnd()
-}
-
-// encodeFields encodes fields.
-func (s *OpenIDProviderMetadataResponse) encodeFields(e *jx.Encoder) {
-	{
-		e.FieldStart("issuer")
-		e.Str(s.Issuer)
-	}
-	{
-		e.FieldStart("authorization_endpoint")
-		e.Str(s.AuthorizationEndpoint)
-	}
-	{
-		e.FieldStart("token_endpoint")
-		e.Str(s.TokenEndpoint)
-	}
-	{
-		e.FieldStart("jwks_uri")
-		e.Str(s.JwksURI)
-	}
-	{
-		e.FieldStart("userinfo_endpoint")
-		e.Str(s.UserinfoEndpoint)
-	}
-}
-
-var jsonFieldsNameOfOpenIDProviderMetadataResponse = [5]string{
-	0: "issuer",
-	1: "authorization_endpoint",
-	2: "token_endpoint",
-	3: "jwks_uri",
-	4: "userinfo_endpoint",
-}
-
-// Decode decodes OpenIDProviderMetadataResponse from json.
-func (s *OpenIDProviderMetadataResponse) Decode(d *jx.Decoder) error {
-	if s == nil {
-		return errors.New("invalid: unable to decode OpenIDProviderMetadataResponse to nil")
-	}
-	var requiredBitSet [1]uint8
-
-	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
-		switch string(k) {
-		case "issuer":
-			requiredBitSet[0] |= 1 << 0
+		case "require_pushed_authorization_requests":
 			if err := func() error {
-				v, err := d.Str()
-				s.Issuer = string(v)
-				if err != nil {
+				s.RequirePushedAuthorizationRequests.Reset()
+				if err := s.RequirePushedAuthorizationRequests.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"issuer\"")
+				return errors.Wrap(err, "decode field \"require_pushed_authorization_requests\"")
 			}
-		case "authorization_endpoint":
-			requiredBitSet[0] |= 1 << 1
+		case "backchannel_logout_supported":
 			if err := func() error {
-				v, err := d.Str()
-				s.AuthorizationEndpoint = string(v)
-				if err != nil {
+				s.BackchannelLogoutSupported.Reset()
+				if err := s.BackchannelLogoutSupported.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"authorization_endpoint\"")
+				return errors.Wrap(err, "decode field \"backchannel_logout_supported\"")
 			}
-		case "token_endpoint":
-			requiredBitSet[0] |= 1 << 2
+		case "backchannel_logout_session_supported":
 			if err := func() error {
-				v, err := d.Str()
-				s.TokenEndpoint = string(v)
-				if err != nil {
+				s.BackchannelLogoutSessionSupported.Reset()
+				if err := s.BackchannelLogoutSessionSupported.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"token_endpoint\"")
+				return errors.Wrap(err, "decode field \"backchannel_logout_session_supported\"")
 			}
-		case "jwks_uri":
-			requiredBitSet[0] |= 1 << 3
+		case "frontchannel_logout_supported":
 			if err := func() error {
-				v, err := d.Str()
-				s.JwksURI = string(v)
-				if err != nil {
+				s.FrontchannelLogoutSupported.Reset()
+				if err := s.FrontchannelLogoutSupported.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"jwks_uri\"")
+				return errors.Wrap(err, "decode field \"frontchannel_logout_supported\"")
 			}
-		case "userinfo_endpoint":
-			requiredBitSet[0] |= 1 << 4
+		case "frontchannel_logout_session_supported":
 			if err := func() error {
-				v, err := d.Str()
-				s.UserinfoEndpoint = string(v)
-				if err != nil {
+				s.FrontchannelLogoutSessionSupported.Reset()
+				if err := s.FrontchannelLogoutSessionSupported.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"userinfo_endpoint\"")
+				return errors.Wrap(err, "decode field \"frontchannel_logout_session_supported\"")
 			}
 		default:
 			return d.Skip()
@@ -655,8 +2958,11 @@ func (s *OpenIDProviderMetadataResponse) Decode(d *jx.Decoder) error {
 	}
 	// Validate required fields.
 	var failures []validate.FieldError
-	for i, mask := range [1]uint8{
-		0b00011111,
+	for i, mask := range [4]uint8{
+		0b10011111,
+		0b00000110,
+		0b00000000,
+		0b00000000,
 	} {
 		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
 			// Mask only required fields and check equality to mask using XOR.
@@ -737,37 +3043,218 @@ func (s *OptBool) UnmarshalJSON(data []byte) error {
 	return s.Decode(d)
 }
 
+// Encode encodes Confirmation as json.
+func (o OptConfirmation) Encode(e *jx.Encoder) {
+	if !o.Set {
+		return
+	}
+	o.Value.Encode(e)
+}
+
+// Decode decodes Confirmation from json.
+func (o *OptConfirmation) Decode(d *jx.Decoder) error {
+	if o == nil {
+		return errors.New("invalid: unable to decode OptConfirmation to nil")
+	}
+	o.Set = true
+	if err := o.Value.Decode(d); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s OptConfirmation) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *OptConfirmation) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode encodes int64 as json.
+func (o OptInt64) Encode(e *jx.Encoder) {
+	if !o.Set {
+		return
+	}
+	e.Int64(int64(o.Value))
+}
+
+// Decode decodes int64 from json.
+func (o *OptInt64) Decode(d *jx.Decoder) error {
+	if o == nil {
+		return errors.New("invalid: unable to decode OptInt64 to nil")
+	}
+	o.Set = true
+	v, err := d.Int64()
+	if err != nil {
+		return err
+	}
+	o.Value = int64(v)
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s OptInt64) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *OptInt64) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
 // Encode encodes string as json.
 func (o OptString) Encode(e *jx.Encoder) {
 	if !o.Set {
 		return
 	}
-	e.Str(string(o.Value))
-}
-
-// Decode decodes string from json.
-func (o *OptString) Decode(d *jx.Decoder) error {
-	if o == nil {
-		return errors.New("invalid: unable to decode OptString to nil")
+	e.Str(string(o.Value))
+}
+
+// Decode decodes string from json.
+func (o *OptString) Decode(d *jx.Decoder) error {
+	if o == nil {
+		return errors.New("invalid: unable to decode OptString to nil")
+	}
+	o.Set = true
+	v, err := d.Str()
+	if err != nil {
+		return err
+	}
+	o.Value = string(v)
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s OptString) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *OptString) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *PushedAuthorizationResponse) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *PushedAuthorizationResponse) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("request_uri")
+		e.Str(s.RequestURI)
+	}
+	{
+		e.FieldStart("expires_in")
+		e.Int64(s.ExpiresIn)
+	}
+}
+
+var jsonFieldsNameOfPushedAuthorizationResponse = [2]string{
+	0: "request_uri",
+	1: "expires_in",
+}
+
+// Decode decodes PushedAuthorizationResponse from json.
+func (s *PushedAuthorizationResponse) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode PushedAuthorizationResponse to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "request_uri":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Str()
+				s.RequestURI = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"request_uri\"")
+			}
+		case "expires_in":
+			requiredBitSet[0] |= 1 << 1
+			if err := func() error {
+				v, err := d.Int64()
+				s.ExpiresIn = int64(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"expires_in\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode PushedAuthorizationResponse")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000011,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfPushedAuthorizationResponse) {
+					name = jsonFieldsNameOfPushedAuthorizationResponse[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
 	}
-	o.Set = true
-	v, err := d.Str()
-	if err != nil {
-		return err
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
 	}
-	o.Value = string(v)
+
 	return nil
 }
 
 // MarshalJSON implements stdjson.Marshaler.
-func (s OptString) MarshalJSON() ([]byte, error) {
+func (s *PushedAuthorizationResponse) MarshalJSON() ([]byte, error) {
 	e := jx.Encoder{}
 	s.Encode(&e)
 	return e.Bytes(), nil
 }
 
 // UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *OptString) UnmarshalJSON(data []byte) error {
+func (s *PushedAuthorizationResponse) UnmarshalJSON(data []byte) error {
 	d := jx.DecodeBytes(data)
 	return s.Decode(d)
 }
@@ -848,46 +3335,6 @@ func (s *TokenPostApplicationXWwwFormUrlencoded) UnmarshalJSON(data []byte) erro
 	return s.Decode(d)
 }
 
-// Encode encodes TokenPostBadRequestApplicationJSON as json.
-func (s TokenPostBadRequestApplicationJSON) Encode(e *jx.Encoder) {
-	unwrapped := string(s)
-
-	e.Str(unwrapped)
-}
-
-// Decode decodes TokenPostBadRequestApplicationJSON from json.
-func (s *TokenPostBadRequestApplicationJSON) Decode(d *jx.Decoder) error {
-	if s == nil {
-		return errors.New("invalid: unable to decode TokenPostBadRequestApplicationJSON to nil")
-	}
-	var unwrapped string
-	if err := func() error {
-		v, err := d.Str()
-		unwrapped = string(v)
-		if err != nil {
-			return err
-		}
-		return nil
-	}(); err != nil {
-		return errors.Wrap(err, "alias")
-	}
-	*s = TokenPostBadRequestApplicationJSON(unwrapped)
-	return nil
-}
-
-// MarshalJSON implements stdjson.Marshaler.
-func (s TokenPostBadRequestApplicationJSON) MarshalJSON() ([]byte, error) {
-	e := jx.Encoder{}
-	s.Encode(&e)
-	return e.Bytes(), nil
-}
-
-// UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *TokenPostBadRequestApplicationJSON) UnmarshalJSON(data []byte) error {
-	d := jx.DecodeBytes(data)
-	return s.Decode(d)
-}
-
 // Encode implements json.Marshaler.
 func (s *TokenRequestBody) Encode(e *jx.Encoder) {
 	e.ObjStart()
@@ -921,6 +3368,12 @@ func (s *TokenRequestBody) encodeFields(e *jx.Encoder) {
 			s.ClientID.Encode(e)
 		}
 	}
+	{
+		if s.ClientSecret.Set {
+			e.FieldStart("client_secret")
+			s.ClientSecret.Encode(e)
+		}
+	}
 	{
 		if s.RedirectURI.Set {
 			e.FieldStart("redirect_uri")
@@ -933,15 +3386,86 @@ func (s *TokenRequestBody) encodeFields(e *jx.Encoder) {
 			s.Scope.Encode(e)
 		}
 	}
+	{
+		if s.CodeVerifier.Set {
+			e.FieldStart("code_verifier")
+			s.CodeVerifier.Encode(e)
+		}
+	}
+	{
+		if s.DeviceCode.Set {
+			e.FieldStart("device_code")
+			s.DeviceCode.Encode(e)
+		}
+	}
+	{
+		if s.ClientAssertionType.Set {
+			e.FieldStart("client_assertion_type")
+			s.ClientAssertionType.Encode(e)
+		}
+	}
+	{
+		if s.ClientAssertion.Set {
+			e.FieldStart("client_assertion")
+			s.ClientAssertion.Encode(e)
+		}
+	}
+	{
+		if s.SubjectToken.Set {
+			e.FieldStart("subject_token")
+			s.SubjectToken.Encode(e)
+		}
+	}
+	{
+		if s.SubjectTokenType.Set {
+			e.FieldStart("subject_token_type")
+			s.SubjectTokenType.Encode(e)
+		}
+	}
+	{
+		if s.ActorToken.Set {
+			e.FieldStart("actor_token")
+			s.ActorToken.Encode(e)
+		}
+	}
+	{
+		if s.ActorTokenType.Set {
+			e.FieldStart("actor_token_type")
+			s.ActorTokenType.Encode(e)
+		}
+	}
+	{
+		if s.RequestedTokenType.Set {
+			e.FieldStart("requested_token_type")
+			s.RequestedTokenType.Encode(e)
+		}
+	}
+	{
+		if s.Audience.Set {
+			e.FieldStart("audience")
+			s.Audience.Encode(e)
+		}
+	}
 }
 
-var jsonFieldsNameOfTokenRequestBody = [6]string{
-	0: "code",
-	1: "refresh_token",
-	2: "grant_type",
-	3: "client_id",
-	4: "redirect_uri",
-	5: "scope",
+var jsonFieldsNameOfTokenRequestBody = [17]string{
+	0:  "code",
+	1:  "refresh_token",
+	2:  "grant_type",
+	3:  "client_id",
+	4:  "client_secret",
+	5:  "redirect_uri",
+	6:  "scope",
+	7:  "code_verifier",
+	8:  "device_code",
+	9:  "client_assertion_type",
+	10: "client_assertion",
+	11: "subject_token",
+	12: "subject_token_type",
+	13: "actor_token",
+	14: "actor_token_type",
+	15: "requested_token_type",
+	16: "audience",
 }
 
 // Decode decodes TokenRequestBody from json.
@@ -992,6 +3516,16 @@ func (s *TokenRequestBody) Decode(d *jx.Decoder) error {
 			}(); err != nil {
 				return errors.Wrap(err, "decode field \"client_id\"")
 			}
+		case "client_secret":
+			if err := func() error {
+				s.ClientSecret.Reset()
+				if err := s.ClientSecret.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"client_secret\"")
+			}
 		case "redirect_uri":
 			if err := func() error {
 				s.RedirectURI.Reset()
@@ -1012,6 +3546,106 @@ func (s *TokenRequestBody) Decode(d *jx.Decoder) error {
 			}(); err != nil {
 				return errors.Wrap(err, "decode field \"scope\"")
 			}
+		case "code_verifier":
+			if err := func() error {
+				s.CodeVerifier.Reset()
+				if err := s.CodeVerifier.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"code_verifier\"")
+			}
+		case "device_code":
+			if err := func() error {
+				s.DeviceCode.Reset()
+				if err := s.DeviceCode.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"device_code\"")
+			}
+		case "client_assertion_type":
+			if err := func() error {
+				s.ClientAssertionType.Reset()
+				if err := s.ClientAssertionType.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"client_assertion_type\"")
+			}
+		case "client_assertion":
+			if err := func() error {
+				s.ClientAssertion.Reset()
+				if err := s.ClientAssertion.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"client_assertion\"")
+			}
+		case "subject_token":
+			if err := func() error {
+				s.SubjectToken.Reset()
+				if err := s.SubjectToken.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"subject_token\"")
+			}
+		case "subject_token_type":
+			if err := func() error {
+				s.SubjectTokenType.Reset()
+				if err := s.SubjectTokenType.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"subject_token_type\"")
+			}
+		case "actor_token":
+			if err := func() error {
+				s.ActorToken.Reset()
+				if err := s.ActorToken.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"actor_token\"")
+			}
+		case "actor_token_type":
+			if err := func() error {
+				s.ActorTokenType.Reset()
+				if err := s.ActorTokenType.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"actor_token_type\"")
+			}
+		case "requested_token_type":
+			if err := func() error {
+				s.RequestedTokenType.Reset()
+				if err := s.RequestedTokenType.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"requested_token_type\"")
+			}
+		case "audience":
+			if err := func() error {
+				s.Audience.Reset()
+				if err := s.Audience.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"audience\"")
+			}
 		default:
 			return d.Skip()
 		}
@@ -1079,15 +3713,71 @@ func (s *UserInfo) encodeFields(e *jx.Encoder) {
 			s.Username.Encode(e)
 		}
 	}
+	{
+		if s.PreferredUsername.Set {
+			e.FieldStart("preferred_username")
+			s.PreferredUsername.Encode(e)
+		}
+	}
+	{
+		if s.Name.Set {
+			e.FieldStart("name")
+			s.Name.Encode(e)
+		}
+	}
+	{
+		if s.GivenName.Set {
+			e.FieldStart("given_name")
+			s.GivenName.Encode(e)
+		}
+	}
+	{
+		if s.FamilyName.Set {
+			e.FieldStart("family_name")
+			s.FamilyName.Encode(e)
+		}
+	}
+	{
+		if s.Picture.Set {
+			e.FieldStart("picture")
+			s.Picture.Encode(e)
+		}
+	}
+	{
+		if s.Locale.Set {
+			e.FieldStart("locale")
+			s.Locale.Encode(e)
+		}
+	}
+	{
+		if s.Zoneinfo.Set {
+			e.FieldStart("zoneinfo")
+			s.Zoneinfo.Encode(e)
+		}
+	}
+	{
+		if s.UpdatedAt.Set {
+			e.FieldStart("updated_at")
+			s.UpdatedAt.Encode(e)
+		}
+	}
 }
 
-var jsonFieldsNameOfUserInfo = [6]string{
-	0: "sub",
-	1: "email",
-	2: "email_verified",
-	3: "phone_number",
-	4: "phone_number_verified",
-	5: "username",
+var jsonFieldsNameOfUserInfo = [14]string{
+	0:  "sub",
+	1:  "email",
+	2:  "email_verified",
+	3:  "phone_number",
+	4:  "phone_number_verified",
+	5:  "username",
+	6:  "preferred_username",
+	7:  "name",
+	8:  "given_name",
+	9:  "family_name",
+	10: "picture",
+	11: "locale",
+	12: "zoneinfo",
+	13: "updated_at",
 }
 
 // Decode decodes UserInfo from json.
@@ -1095,7 +3785,7 @@ func (s *UserInfo) Decode(d *jx.Decoder) error {
 	if s == nil {
 		return errors.New("invalid: unable to decode UserInfo to nil")
 	}
-	var requiredBitSet [1]uint8
+	var requiredBitSet [2]uint8
 
 	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
 		switch string(k) {
@@ -1161,6 +3851,86 @@ func (s *UserInfo) Decode(d *jx.Decoder) error {
 			}(); err != nil {
 				return errors.Wrap(err, "decode field \"username\"")
 			}
+		case "preferred_username":
+			if err := func() error {
+				s.PreferredUsername.Reset()
+				if err := s.PreferredUsername.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"preferred_username\"")
+			}
+		case "name":
+			if err := func() error {
+				s.Name.Reset()
+				if err := s.Name.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"name\"")
+			}
+		case "given_name":
+			if err := func() error {
+				s.GivenName.Reset()
+				if err := s.GivenName.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"given_name\"")
+			}
+		case "family_name":
+			if err := func() error {
+				s.FamilyName.Reset()
+				if err := s.FamilyName.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"family_name\"")
+			}
+		case "picture":
+			if err := func() error {
+				s.Picture.Reset()
+				if err := s.Picture.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"picture\"")
+			}
+		case "locale":
+			if err := func() error {
+				s.Locale.Reset()
+				if err := s.Locale.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"locale\"")
+			}
+		case "zoneinfo":
+			if err := func() error {
+				s.Zoneinfo.Reset()
+				if err := s.Zoneinfo.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"zoneinfo\"")
+			}
+		case "updated_at":
+			if err := func() error {
+				s.UpdatedAt.Reset()
+				if err := s.UpdatedAt.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"updated_at\"")
+			}
 		default:
 			return d.Skip()
 		}
@@ -1170,8 +3940,9 @@ func (s *UserInfo) Decode(d *jx.Decoder) error {
 	}
 	// Validate required fields.
 	var failures []validate.FieldError
-	for i, mask := range [1]uint8{
+	for i, mask := range [2]uint8{
 		0b00000001,
+		0b00000000,
 	} {
 		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
 			// Mask only required fields and check equality to mask using XOR.
@@ -6,9 +6,17 @@ package api
 type OperationName = string
 
 const (
-	AuthorizeGetOperation        OperationName = "AuthorizeGet"
-	JwksOperation                OperationName = "Jwks"
-	OpenIdConfigurationOperation OperationName = "OpenIdConfiguration"
-	TokenPostOperation           OperationName = "TokenPost"
-	UserinfoGetOperation         OperationName = "UserinfoGet"
+	AuthorizeGetOperation             OperationName = "AuthorizeGet"
+	DeleteClientRegistrationOperation OperationName = "DeleteClientRegistration"
+	DeviceAuthorizationPostOperation  OperationName = "DeviceAuthorizationPost"
+	GetClientRegistrationOperation    OperationName = "GetClientRegistration"
+	IntrospectPostOperation           OperationName = "IntrospectPost"
+	JwksOperation                     OperationName = "Jwks"
+	OpenIdConfigurationOperation      OperationName = "OpenIdConfiguration"
+	ParPostOperation                  OperationName = "ParPost"
+	RegisterClientOperation           OperationName = "RegisterClient"
+	RevokePostOperation               OperationName = "RevokePost"
+	TokenPostOperation                OperationName = "TokenPost"
+	UpdateClientRegistrationOperation OperationName = "UpdateClientRegistration"
+	UserinfoGetOperation              OperationName = "UserinfoGet"
 )
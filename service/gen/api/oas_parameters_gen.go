@@ -4,6 +4,9 @@ package api
 
 import (
 	"net/http"
+	"net/url"
+
+	"github.com/go-faster/errors"
 
 	"github.com/ogen-go/ogen/conv"
 	"github.com/ogen-go/ogen/middleware"
@@ -14,12 +17,17 @@ import (
 
 // AuthorizeGetParams is parameters of GET /authorize operation.
 type AuthorizeGetParams struct {
-	ResponseType string
-	ClientID     string
-	Scope        string
-	RedirectURI  string
-	State        OptString
-	Nonce        OptString
+	ResponseType        OptString
+	ClientID            string
+	Scope               OptString
+	RedirectURI         OptString
+	State               OptString
+	ResponseMode        OptString
+	Nonce               OptString
+	CodeChallenge       OptString
+	CodeChallengeMethod OptString
+	RequestURI          OptString
+	Request             OptString
 }
 
 func unpackAuthorizeGetParams(packed middleware.Parameters) (params AuthorizeGetParams) {
@@ -28,7 +36,9 @@ func unpackAuthorizeGetParams(packed middleware.Parameters) (params AuthorizeGet
 			Name: "response_type",
 			In:   "query",
 		}
-		params.ResponseType = packed[key].(string)
+		if v, ok := packed[key]; ok {
+			params.ResponseType = v.(OptString)
+		}
 	}
 	{
 		key := middleware.ParameterKey{
@@ -42,14 +52,18 @@ func unpackAuthorizeGetParams(packed middleware.Parameters) (params AuthorizeGet
 			Name: "scope",
 			In:   "query",
 		}
-		params.Scope = packed[key].(string)
+		if v, ok := packed[key]; ok {
+			params.Scope = v.(OptString)
+		}
 	}
 	{
 		key := middleware.ParameterKey{
 			Name: "redirect_uri",
 			In:   "query",
 		}
-		params.RedirectURI = packed[key].(string)
+		if v, ok := packed[key]; ok {
+			params.RedirectURI = v.(OptString)
+		}
 	}
 	{
 		key := middleware.ParameterKey{
@@ -60,6 +74,15 @@ func unpackAuthorizeGetParams(packed middleware.Parameters) (params AuthorizeGet
 			params.State = v.(OptString)
 		}
 	}
+	{
+		key := middleware.ParameterKey{
+			Name: "response_mode",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.ResponseMode = v.(OptString)
+		}
+	}
 	{
 		key := middleware.ParameterKey{
 			Name: "nonce",
@@ -69,6 +92,42 @@ func unpackAuthorizeGetParams(packed middleware.Parameters) (params AuthorizeGet
 			params.Nonce = v.(OptString)
 		}
 	}
+	{
+		key := middleware.ParameterKey{
+			Name: "code_challenge",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.CodeChallenge = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "code_challenge_method",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.CodeChallengeMethod = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "request_uri",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.RequestURI = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "request",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Request = v.(OptString)
+		}
+	}
 	return params
 }
 
@@ -84,23 +143,28 @@ func decodeAuthorizeGetParams(args [0]string, argsEscaped bool, r *http.Request)
 
 		if err := q.HasParam(cfg); err == nil {
 			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
-				val, err := d.DecodeValue()
-				if err != nil {
-					return err
-				}
+				var paramsDotResponseTypeVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
 
-				c, err := conv.ToString(val)
-				if err != nil {
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotResponseTypeVal = c
+					return nil
+				}(); err != nil {
 					return err
 				}
-
-				params.ResponseType = c
+				params.ResponseType.SetTo(paramsDotResponseTypeVal)
 				return nil
 			}); err != nil {
 				return err
 			}
-		} else {
-			return validate.ErrFieldRequired
 		}
 		return nil
 	}(); err != nil {
@@ -156,23 +220,28 @@ func decodeAuthorizeGetParams(args [0]string, argsEscaped bool, r *http.Request)
 
 		if err := q.HasParam(cfg); err == nil {
 			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
-				val, err := d.DecodeValue()
-				if err != nil {
-					return err
-				}
+				var paramsDotScopeVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
 
-				c, err := conv.ToString(val)
-				if err != nil {
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotScopeVal = c
+					return nil
+				}(); err != nil {
 					return err
 				}
-
-				params.Scope = c
+				params.Scope.SetTo(paramsDotScopeVal)
 				return nil
 			}); err != nil {
 				return err
 			}
-		} else {
-			return validate.ErrFieldRequired
 		}
 		return nil
 	}(); err != nil {
@@ -192,23 +261,28 @@ func decodeAuthorizeGetParams(args [0]string, argsEscaped bool, r *http.Request)
 
 		if err := q.HasParam(cfg); err == nil {
 			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
-				val, err := d.DecodeValue()
-				if err != nil {
-					return err
-				}
+				var paramsDotRedirectURIVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
 
-				c, err := conv.ToString(val)
-				if err != nil {
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotRedirectURIVal = c
+					return nil
+				}(); err != nil {
 					return err
 				}
-
-				params.RedirectURI = c
+				params.RedirectURI.SetTo(paramsDotRedirectURIVal)
 				return nil
 			}); err != nil {
 				return err
 			}
-		} else {
-			return validate.ErrFieldRequired
 		}
 		return nil
 	}(); err != nil {
@@ -259,6 +333,47 @@ func decodeAuthorizeGetParams(args [0]string, argsEscaped bool, r *http.Request)
 			Err:  err,
 		}
 	}
+	// Decode query: response_mode.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "response_mode",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotResponseModeVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotResponseModeVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.ResponseMode.SetTo(paramsDotResponseModeVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "response_mode",
+			In:   "query",
+			Err:  err,
+		}
+	}
 	// Decode query: nonce.
 	if err := func() error {
 		cfg := uri.QueryParameterDecodingConfig{
@@ -300,5 +415,364 @@ func decodeAuthorizeGetParams(args [0]string, argsEscaped bool, r *http.Request)
 			Err:  err,
 		}
 	}
+	// Decode query: code_challenge.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "code_challenge",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotCodeChallengeVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotCodeChallengeVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.CodeChallenge.SetTo(paramsDotCodeChallengeVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "code_challenge",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: code_challenge_method.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "code_challenge_method",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotCodeChallengeMethodVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotCodeChallengeMethodVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.CodeChallengeMethod.SetTo(paramsDotCodeChallengeMethodVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "code_challenge_method",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: request_uri.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "request_uri",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotRequestURIVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotRequestURIVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.RequestURI.SetTo(paramsDotRequestURIVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "request_uri",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: request.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "request",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotRequestVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotRequestVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Request.SetTo(paramsDotRequestVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "request",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// DeleteClientRegistrationParams is parameters of deleteClientRegistration operation.
+type DeleteClientRegistrationParams struct {
+	ClientID string
+}
+
+func unpackDeleteClientRegistrationParams(packed middleware.Parameters) (params DeleteClientRegistrationParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "client_id",
+			In:   "path",
+		}
+		params.ClientID = packed[key].(string)
+	}
+	return params
+}
+
+func decodeDeleteClientRegistrationParams(args [1]string, argsEscaped bool, r *http.Request) (params DeleteClientRegistrationParams, _ error) {
+	// Decode path: client_id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "client_id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToString(val)
+				if err != nil {
+					return err
+				}
+
+				params.ClientID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "client_id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GetClientRegistrationParams is parameters of getClientRegistration operation.
+type GetClientRegistrationParams struct {
+	ClientID string
+}
+
+func unpackGetClientRegistrationParams(packed middleware.Parameters) (params GetClientRegistrationParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "client_id",
+			In:   "path",
+		}
+		params.ClientID = packed[key].(string)
+	}
+	return params
+}
+
+func decodeGetClientRegistrationParams(args [1]string, argsEscaped bool, r *http.Request) (params GetClientRegistrationParams, _ error) {
+	// Decode path: client_id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "client_id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToString(val)
+				if err != nil {
+					return err
+				}
+
+				params.ClientID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "client_id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// UpdateClientRegistrationParams is parameters of updateClientRegistration operation.
+type UpdateClientRegistrationParams struct {
+	ClientID string
+}
+
+func unpackUpdateClientRegistrationParams(packed middleware.Parameters) (params UpdateClientRegistrationParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "client_id",
+			In:   "path",
+		}
+		params.ClientID = packed[key].(string)
+	}
+	return params
+}
+
+func decodeUpdateClientRegistrationParams(args [1]string, argsEscaped bool, r *http.Request) (params UpdateClientRegistrationParams, _ error) {
+	// Decode path: client_id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "client_id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToString(val)
+				if err != nil {
+					return err
+				}
+
+				params.ClientID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "client_id",
+			In:   "path",
+			Err:  err,
+		}
+	}
 	return params, nil
 }
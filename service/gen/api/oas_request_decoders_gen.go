@@ -18,6 +18,1251 @@ import (
 	"github.com/ogen-go/ogen/validate"
 )
 
+func (s *Server) decodeDeviceAuthorizationPostRequest(r *http.Request) (
+	req *DeviceAuthorizationRequestBody,
+	close func() error,
+	rerr error,
+) {
+	var closers []func() error
+	close = func() error {
+		var merr error
+		// Close in reverse order, to match defer behavior.
+		for i := len(closers) - 1; i >= 0; i-- {
+			c := closers[i]
+			merr = multierr.Append(merr, c())
+		}
+		return merr
+	}
+	defer func() {
+		if rerr != nil {
+			rerr = multierr.Append(rerr, close())
+		}
+	}()
+	ct, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return req, close, errors.Wrap(err, "parse media type")
+	}
+	switch {
+	case ct == "application/x-www-form-urlencoded":
+		if r.ContentLength == 0 {
+			return req, close, validate.ErrBodyRequired
+		}
+		form, err := ht.ParseForm(r)
+		if err != nil {
+			return req, close, errors.Wrap(err, "parse form")
+		}
+
+		var request DeviceAuthorizationRequestBody
+		q := uri.NewQueryDecoder(form)
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_id",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientIDVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientIDVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientID.SetTo(requestDotClientIDVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_id\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "scope",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotScopeVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotScopeVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.Scope.SetTo(requestDotScopeVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"scope\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_secret",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientSecretVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientSecretVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientSecret.SetTo(requestDotClientSecretVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_secret\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_assertion_type",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientAssertionTypeVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientAssertionTypeVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientAssertionType.SetTo(requestDotClientAssertionTypeVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_assertion_type\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_assertion",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientAssertionVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientAssertionVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientAssertion.SetTo(requestDotClientAssertionVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_assertion\"")
+				}
+			}
+		}
+		return &request, close, nil
+	default:
+		return req, close, validate.InvalidContentType(ct)
+	}
+}
+
+func (s *Server) decodeIntrospectPostRequest(r *http.Request) (
+	req *IntrospectionRequestBody,
+	close func() error,
+	rerr error,
+) {
+	var closers []func() error
+	close = func() error {
+		var merr error
+		// Close in reverse order, to match defer behavior.
+		for i := len(closers) - 1; i >= 0; i-- {
+			c := closers[i]
+			merr = multierr.Append(merr, c())
+		}
+		return merr
+	}
+	defer func() {
+		if rerr != nil {
+			rerr = multierr.Append(rerr, close())
+		}
+	}()
+	ct, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return req, close, errors.Wrap(err, "parse media type")
+	}
+	switch {
+	case ct == "application/x-www-form-urlencoded":
+		if r.ContentLength == 0 {
+			return req, close, validate.ErrBodyRequired
+		}
+		form, err := ht.ParseForm(r)
+		if err != nil {
+			return req, close, errors.Wrap(err, "parse form")
+		}
+
+		var request IntrospectionRequestBody
+		q := uri.NewQueryDecoder(form)
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "token",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					request.Token = c
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"token\"")
+				}
+			} else {
+				return req, close, errors.Wrap(err, "query")
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "token_type_hint",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotTokenTypeHintVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotTokenTypeHintVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.TokenTypeHint.SetTo(requestDotTokenTypeHintVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"token_type_hint\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_id",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientIDVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientIDVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientID.SetTo(requestDotClientIDVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_id\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_secret",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientSecretVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientSecretVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientSecret.SetTo(requestDotClientSecretVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_secret\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_assertion_type",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientAssertionTypeVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientAssertionTypeVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientAssertionType.SetTo(requestDotClientAssertionTypeVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_assertion_type\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_assertion",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientAssertionVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientAssertionVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientAssertion.SetTo(requestDotClientAssertionVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_assertion\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "dpop_htm",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotDpopHtmVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotDpopHtmVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.DpopHtm.SetTo(requestDotDpopHtmVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"dpop_htm\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "dpop_htu",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotDpopHtuVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotDpopHtuVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.DpopHtu.SetTo(requestDotDpopHtuVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"dpop_htu\"")
+				}
+			}
+		}
+		return &request, close, nil
+	default:
+		return req, close, validate.InvalidContentType(ct)
+	}
+}
+
+func (s *Server) decodeParPostRequest(r *http.Request) (
+	req *PushedAuthorizationRequestBody,
+	close func() error,
+	rerr error,
+) {
+	var closers []func() error
+	close = func() error {
+		var merr error
+		// Close in reverse order, to match defer behavior.
+		for i := len(closers) - 1; i >= 0; i-- {
+			c := closers[i]
+			merr = multierr.Append(merr, c())
+		}
+		return merr
+	}
+	defer func() {
+		if rerr != nil {
+			rerr = multierr.Append(rerr, close())
+		}
+	}()
+	ct, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return req, close, errors.Wrap(err, "parse media type")
+	}
+	switch {
+	case ct == "application/x-www-form-urlencoded":
+		if r.ContentLength == 0 {
+			return req, close, validate.ErrBodyRequired
+		}
+		form, err := ht.ParseForm(r)
+		if err != nil {
+			return req, close, errors.Wrap(err, "parse form")
+		}
+
+		var request PushedAuthorizationRequestBody
+		q := uri.NewQueryDecoder(form)
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "response_type",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotResponseTypeVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotResponseTypeVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ResponseType.SetTo(requestDotResponseTypeVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"response_type\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_id",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientIDVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientIDVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientID.SetTo(requestDotClientIDVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_id\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "scope",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotScopeVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotScopeVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.Scope.SetTo(requestDotScopeVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"scope\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "redirect_uri",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotRedirectURIVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotRedirectURIVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.RedirectURI.SetTo(requestDotRedirectURIVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"redirect_uri\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "state",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotStateVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotStateVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.State.SetTo(requestDotStateVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"state\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "response_mode",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotResponseModeVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotResponseModeVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ResponseMode.SetTo(requestDotResponseModeVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"response_mode\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "nonce",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotNonceVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotNonceVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.Nonce.SetTo(requestDotNonceVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"nonce\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "code_challenge",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotCodeChallengeVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotCodeChallengeVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.CodeChallenge.SetTo(requestDotCodeChallengeVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"code_challenge\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "code_challenge_method",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotCodeChallengeMethodVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotCodeChallengeMethodVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.CodeChallengeMethod.SetTo(requestDotCodeChallengeMethodVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"code_challenge_method\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "request",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotRequestVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotRequestVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.Request.SetTo(requestDotRequestVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"request\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_secret",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientSecretVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientSecretVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientSecret.SetTo(requestDotClientSecretVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_secret\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_assertion_type",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientAssertionTypeVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientAssertionTypeVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientAssertionType.SetTo(requestDotClientAssertionTypeVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_assertion_type\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_assertion",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientAssertionVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientAssertionVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientAssertion.SetTo(requestDotClientAssertionVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_assertion\"")
+				}
+			}
+		}
+		return &request, close, nil
+	default:
+		return req, close, validate.InvalidContentType(ct)
+	}
+}
+
+func (s *Server) decodeRegisterClientRequest(r *http.Request) (
+	req *ClientMetadata,
+	close func() error,
+	rerr error,
+) {
+	var closers []func() error
+	close = func() error {
+		var merr error
+		// Close in reverse order, to match defer behavior.
+		for i := len(closers) - 1; i >= 0; i-- {
+			c := closers[i]
+			merr = multierr.Append(merr, c())
+		}
+		return merr
+	}
+	defer func() {
+		if rerr != nil {
+			rerr = multierr.Append(rerr, close())
+		}
+	}()
+	ct, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return req, close, errors.Wrap(err, "parse media type")
+	}
+	switch {
+	case ct == "application/json":
+		if r.ContentLength == 0 {
+			return req, close, validate.ErrBodyRequired
+		}
+		buf, err := io.ReadAll(r.Body)
+		if err != nil {
+			return req, close, err
+		}
+
+		if len(buf) == 0 {
+			return req, close, validate.ErrBodyRequired
+		}
+
+		d := jx.DecodeBytes(buf)
+
+		var request ClientMetadata
+		if err := func() error {
+			if err := request.Decode(d); err != nil {
+				return err
+			}
+			if err := d.Skip(); err != io.EOF {
+				return errors.New("unexpected trailing data")
+			}
+			return nil
+		}(); err != nil {
+			err = &ogenerrors.DecodeBodyError{
+				ContentType: ct,
+				Body:        buf,
+				Err:         err,
+			}
+			return req, close, err
+		}
+		return &request, close, nil
+	default:
+		return req, close, validate.InvalidContentType(ct)
+	}
+}
+
+func (s *Server) decodeRevokePostRequest(r *http.Request) (
+	req *RevocationRequestBody,
+	close func() error,
+	rerr error,
+) {
+	var closers []func() error
+	close = func() error {
+		var merr error
+		// Close in reverse order, to match defer behavior.
+		for i := len(closers) - 1; i >= 0; i-- {
+			c := closers[i]
+			merr = multierr.Append(merr, c())
+		}
+		return merr
+	}
+	defer func() {
+		if rerr != nil {
+			rerr = multierr.Append(rerr, close())
+		}
+	}()
+	ct, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return req, close, errors.Wrap(err, "parse media type")
+	}
+	switch {
+	case ct == "application/x-www-form-urlencoded":
+		if r.ContentLength == 0 {
+			return req, close, validate.ErrBodyRequired
+		}
+		form, err := ht.ParseForm(r)
+		if err != nil {
+			return req, close, errors.Wrap(err, "parse form")
+		}
+
+		var request RevocationRequestBody
+		q := uri.NewQueryDecoder(form)
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "token",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					request.Token = c
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"token\"")
+				}
+			} else {
+				return req, close, errors.Wrap(err, "query")
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "token_type_hint",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotTokenTypeHintVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotTokenTypeHintVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.TokenTypeHint.SetTo(requestDotTokenTypeHintVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"token_type_hint\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_id",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientIDVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientIDVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientID.SetTo(requestDotClientIDVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_id\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_secret",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientSecretVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientSecretVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientSecret.SetTo(requestDotClientSecretVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_secret\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_assertion_type",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientAssertionTypeVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientAssertionTypeVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientAssertionType.SetTo(requestDotClientAssertionTypeVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_assertion_type\"")
+				}
+			}
+		}
+		{
+			cfg := uri.QueryParameterDecodingConfig{
+				Name:    "client_assertion",
+				Style:   uri.QueryStyleForm,
+				Explode: true,
+			}
+			if err := q.HasParam(cfg); err == nil {
+				if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+					var requestDotClientAssertionVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						requestDotClientAssertionVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					request.ClientAssertion.SetTo(requestDotClientAssertionVal)
+					return nil
+				}); err != nil {
+					return req, close, errors.Wrap(err, "decode \"client_assertion\"")
+				}
+			}
+		}
+		return &request, close, nil
+	default:
+		return req, close, validate.InvalidContentType(ct)
+	}
+}
+
 func (s *Server) decodeTokenPostRequest(r *http.Request) (
 	req TokenPostReq,
 	close func() error,
@@ -52,52 +1297,404 @@ func (s *Server) decodeTokenPostRequest(r *http.Request) (
 			return req, close, err
 		}
 
-		if len(buf) == 0 {
-			return req, close, validate.ErrBodyRequired
-		}
+		if len(buf) == 0 {
+			return req, close, validate.ErrBodyRequired
+		}
+
+		d := jx.DecodeBytes(buf)
+
+		var request TokenPostApplicationJSON
+		if err := func() error {
+			if err := request.Decode(d); err != nil {
+				return err
+			}
+			if err := d.Skip(); err != io.EOF {
+				return errors.New("unexpected trailing data")
+			}
+			return nil
+		}(); err != nil {
+			err = &ogenerrors.DecodeBodyError{
+				ContentType: ct,
+				Body:        buf,
+				Err:         err,
+			}
+			return req, close, err
+		}
+		return &request, close, nil
+	case ct == "application/x-www-form-urlencoded":
+		if r.ContentLength == 0 {
+			return req, close, validate.ErrBodyRequired
+		}
+		form, err := ht.ParseForm(r)
+		if err != nil {
+			return req, close, errors.Wrap(err, "parse form")
+		}
+
+		var request TokenPostApplicationXWwwFormUrlencoded
+		{
+			var unwrapped TokenRequestBody
+			q := uri.NewQueryDecoder(form)
+			{
+				cfg := uri.QueryParameterDecodingConfig{
+					Name:    "code",
+					Style:   uri.QueryStyleForm,
+					Explode: true,
+				}
+				if err := q.HasParam(cfg); err == nil {
+					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+						var unwrappedDotCodeVal string
+						if err := func() error {
+							val, err := d.DecodeValue()
+							if err != nil {
+								return err
+							}
+
+							c, err := conv.ToString(val)
+							if err != nil {
+								return err
+							}
+
+							unwrappedDotCodeVal = c
+							return nil
+						}(); err != nil {
+							return err
+						}
+						unwrapped.Code.SetTo(unwrappedDotCodeVal)
+						return nil
+					}); err != nil {
+						return req, close, errors.Wrap(err, "decode \"code\"")
+					}
+				}
+			}
+			{
+				cfg := uri.QueryParameterDecodingConfig{
+					Name:    "refresh_token",
+					Style:   uri.QueryStyleForm,
+					Explode: true,
+				}
+				if err := q.HasParam(cfg); err == nil {
+					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+						var unwrappedDotRefreshTokenVal string
+						if err := func() error {
+							val, err := d.DecodeValue()
+							if err != nil {
+								return err
+							}
+
+							c, err := conv.ToString(val)
+							if err != nil {
+								return err
+							}
+
+							unwrappedDotRefreshTokenVal = c
+							return nil
+						}(); err != nil {
+							return err
+						}
+						unwrapped.RefreshToken.SetTo(unwrappedDotRefreshTokenVal)
+						return nil
+					}); err != nil {
+						return req, close, errors.Wrap(err, "decode \"refresh_token\"")
+					}
+				}
+			}
+			{
+				cfg := uri.QueryParameterDecodingConfig{
+					Name:    "grant_type",
+					Style:   uri.QueryStyleForm,
+					Explode: true,
+				}
+				if err := q.HasParam(cfg); err == nil {
+					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+						var unwrappedDotGrantTypeVal string
+						if err := func() error {
+							val, err := d.DecodeValue()
+							if err != nil {
+								return err
+							}
+
+							c, err := conv.ToString(val)
+							if err != nil {
+								return err
+							}
+
+							unwrappedDotGrantTypeVal = c
+							return nil
+						}(); err != nil {
+							return err
+						}
+						unwrapped.GrantType.SetTo(unwrappedDotGrantTypeVal)
+						return nil
+					}); err != nil {
+						return req, close, errors.Wrap(err, "decode \"grant_type\"")
+					}
+				}
+			}
+			{
+				cfg := uri.QueryParameterDecodingConfig{
+					Name:    "client_id",
+					Style:   uri.QueryStyleForm,
+					Explode: true,
+				}
+				if err := q.HasParam(cfg); err == nil {
+					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+						var unwrappedDotClientIDVal string
+						if err := func() error {
+							val, err := d.DecodeValue()
+							if err != nil {
+								return err
+							}
+
+							c, err := conv.ToString(val)
+							if err != nil {
+								return err
+							}
+
+							unwrappedDotClientIDVal = c
+							return nil
+						}(); err != nil {
+							return err
+						}
+						unwrapped.ClientID.SetTo(unwrappedDotClientIDVal)
+						return nil
+					}); err != nil {
+						return req, close, errors.Wrap(err, "decode \"client_id\"")
+					}
+				}
+			}
+			{
+				cfg := uri.QueryParameterDecodingConfig{
+					Name:    "client_secret",
+					Style:   uri.QueryStyleForm,
+					Explode: true,
+				}
+				if err := q.HasParam(cfg); err == nil {
+					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+						var unwrappedDotClientSecretVal string
+						if err := func() error {
+							val, err := d.DecodeValue()
+							if err != nil {
+								return err
+							}
+
+							c, err := conv.ToString(val)
+							if err != nil {
+								return err
+							}
+
+							unwrappedDotClientSecretVal = c
+							return nil
+						}(); err != nil {
+							return err
+						}
+						unwrapped.ClientSecret.SetTo(unwrappedDotClientSecretVal)
+						return nil
+					}); err != nil {
+						return req, close, errors.Wrap(err, "decode \"client_secret\"")
+					}
+				}
+			}
+			{
+				cfg := uri.QueryParameterDecodingConfig{
+					Name:    "redirect_uri",
+					Style:   uri.QueryStyleForm,
+					Explode: true,
+				}
+				if err := q.HasParam(cfg); err == nil {
+					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+						var unwrappedDotRedirectURIVal string
+						if err := func() error {
+							val, err := d.DecodeValue()
+							if err != nil {
+								return err
+							}
+
+							c, err := conv.ToString(val)
+							if err != nil {
+								return err
+							}
+
+							unwrappedDotRedirectURIVal = c
+							return nil
+						}(); err != nil {
+							return err
+						}
+						unwrapped.RedirectURI.SetTo(unwrappedDotRedirectURIVal)
+						return nil
+					}); err != nil {
+						return req, close, errors.Wrap(err, "decode \"redirect_uri\"")
+					}
+				}
+			}
+			{
+				cfg := uri.QueryParameterDecodingConfig{
+					Name:    "scope",
+					Style:   uri.QueryStyleForm,
+					Explode: true,
+				}
+				if err := q.HasParam(cfg); err == nil {
+					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+						var unwrappedDotScopeVal string
+						if err := func() error {
+							val, err := d.DecodeValue()
+							if err != nil {
+								return err
+							}
+
+							c, err := conv.ToString(val)
+							if err != nil {
+								return err
+							}
 
-		d := jx.DecodeBytes(buf)
+							unwrappedDotScopeVal = c
+							return nil
+						}(); err != nil {
+							return err
+						}
+						unwrapped.Scope.SetTo(unwrappedDotScopeVal)
+						return nil
+					}); err != nil {
+						return req, close, errors.Wrap(err, "decode \"scope\"")
+					}
+				}
+			}
+			{
+				cfg := uri.QueryParameterDecodingConfig{
+					Name:    "code_verifier",
+					Style:   uri.QueryStyleForm,
+					Explode: true,
+				}
+				if err := q.HasParam(cfg); err == nil {
+					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+						var unwrappedDotCodeVerifierVal string
+						if err := func() error {
+							val, err := d.DecodeValue()
+							if err != nil {
+								return err
+							}
 
-		var request TokenPostApplicationJSON
-		if err := func() error {
-			if err := request.Decode(d); err != nil {
-				return err
+							c, err := conv.ToString(val)
+							if err != nil {
+								return err
+							}
+
+							unwrappedDotCodeVerifierVal = c
+							return nil
+						}(); err != nil {
+							return err
+						}
+						unwrapped.CodeVerifier.SetTo(unwrappedDotCodeVerifierVal)
+						return nil
+					}); err != nil {
+						return req, close, errors.Wrap(err, "decode \"code_verifier\"")
+					}
+				}
 			}
-			if err := d.Skip(); err != io.EOF {
-				return errors.New("unexpected trailing data")
+			{
+				cfg := uri.QueryParameterDecodingConfig{
+					Name:    "device_code",
+					Style:   uri.QueryStyleForm,
+					Explode: true,
+				}
+				if err := q.HasParam(cfg); err == nil {
+					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+						var unwrappedDotDeviceCodeVal string
+						if err := func() error {
+							val, err := d.DecodeValue()
+							if err != nil {
+								return err
+							}
+
+							c, err := conv.ToString(val)
+							if err != nil {
+								return err
+							}
+
+							unwrappedDotDeviceCodeVal = c
+							return nil
+						}(); err != nil {
+							return err
+						}
+						unwrapped.DeviceCode.SetTo(unwrappedDotDeviceCodeVal)
+						return nil
+					}); err != nil {
+						return req, close, errors.Wrap(err, "decode \"device_code\"")
+					}
+				}
 			}
-			return nil
-		}(); err != nil {
-			err = &ogenerrors.DecodeBodyError{
-				ContentType: ct,
-				Body:        buf,
-				Err:         err,
+			{
+				cfg := uri.QueryParameterDecodingConfig{
+					Name:    "client_assertion_type",
+					Style:   uri.QueryStyleForm,
+					Explode: true,
+				}
+				if err := q.HasParam(cfg); err == nil {
+					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+						var unwrappedDotClientAssertionTypeVal string
+						if err := func() error {
+							val, err := d.DecodeValue()
+							if err != nil {
+								return err
+							}
+
+							c, err := conv.ToString(val)
+							if err != nil {
+								return err
+							}
+
+							unwrappedDotClientAssertionTypeVal = c
+							return nil
+						}(); err != nil {
+							return err
+						}
+						unwrapped.ClientAssertionType.SetTo(unwrappedDotClientAssertionTypeVal)
+						return nil
+					}); err != nil {
+						return req, close, errors.Wrap(err, "decode \"client_assertion_type\"")
+					}
+				}
 			}
-			return req, close, err
-		}
-		return &request, close, nil
-	case ct == "application/x-www-form-urlencoded":
-		if r.ContentLength == 0 {
-			return req, close, validate.ErrBodyRequired
-		}
-		form, err := ht.ParseForm(r)
-		if err != nil {
-			return req, close, errors.Wrap(err, "parse form")
-		}
+			{
+				cfg := uri.QueryParameterDecodingConfig{
+					Name:    "client_assertion",
+					Style:   uri.QueryStyleForm,
+					Explode: true,
+				}
+				if err := q.HasParam(cfg); err == nil {
+					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+						var unwrappedDotClientAssertionVal string
+						if err := func() error {
+							val, err := d.DecodeValue()
+							if err != nil {
+								return err
+							}
 
-		var request TokenPostApplicationXWwwFormUrlencoded
-		{
-			var unwrapped TokenRequestBody
-			q := uri.NewQueryDecoder(form)
+							c, err := conv.ToString(val)
+							if err != nil {
+								return err
+							}
+
+							unwrappedDotClientAssertionVal = c
+							return nil
+						}(); err != nil {
+							return err
+						}
+						unwrapped.ClientAssertion.SetTo(unwrappedDotClientAssertionVal)
+						return nil
+					}); err != nil {
+						return req, close, errors.Wrap(err, "decode \"client_assertion\"")
+					}
+				}
+			}
 			{
 				cfg := uri.QueryParameterDecodingConfig{
-					Name:    "code",
+					Name:    "subject_token",
 					Style:   uri.QueryStyleForm,
 					Explode: true,
 				}
 				if err := q.HasParam(cfg); err == nil {
 					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
-						var unwrappedDotCodeVal string
+						var unwrappedDotSubjectTokenVal string
 						if err := func() error {
 							val, err := d.DecodeValue()
 							if err != nil {
@@ -109,27 +1706,27 @@ func (s *Server) decodeTokenPostRequest(r *http.Request) (
 								return err
 							}
 
-							unwrappedDotCodeVal = c
+							unwrappedDotSubjectTokenVal = c
 							return nil
 						}(); err != nil {
 							return err
 						}
-						unwrapped.Code.SetTo(unwrappedDotCodeVal)
+						unwrapped.SubjectToken.SetTo(unwrappedDotSubjectTokenVal)
 						return nil
 					}); err != nil {
-						return req, close, errors.Wrap(err, "decode \"code\"")
+						return req, close, errors.Wrap(err, "decode \"subject_token\"")
 					}
 				}
 			}
 			{
 				cfg := uri.QueryParameterDecodingConfig{
-					Name:    "refresh_token",
+					Name:    "subject_token_type",
 					Style:   uri.QueryStyleForm,
 					Explode: true,
 				}
 				if err := q.HasParam(cfg); err == nil {
 					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
-						var unwrappedDotRefreshTokenVal string
+						var unwrappedDotSubjectTokenTypeVal string
 						if err := func() error {
 							val, err := d.DecodeValue()
 							if err != nil {
@@ -141,27 +1738,27 @@ func (s *Server) decodeTokenPostRequest(r *http.Request) (
 								return err
 							}
 
-							unwrappedDotRefreshTokenVal = c
+							unwrappedDotSubjectTokenTypeVal = c
 							return nil
 						}(); err != nil {
 							return err
 						}
-						unwrapped.RefreshToken.SetTo(unwrappedDotRefreshTokenVal)
+						unwrapped.SubjectTokenType.SetTo(unwrappedDotSubjectTokenTypeVal)
 						return nil
 					}); err != nil {
-						return req, close, errors.Wrap(err, "decode \"refresh_token\"")
+						return req, close, errors.Wrap(err, "decode \"subject_token_type\"")
 					}
 				}
 			}
 			{
 				cfg := uri.QueryParameterDecodingConfig{
-					Name:    "grant_type",
+					Name:    "actor_token",
 					Style:   uri.QueryStyleForm,
 					Explode: true,
 				}
 				if err := q.HasParam(cfg); err == nil {
 					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
-						var unwrappedDotGrantTypeVal string
+						var unwrappedDotActorTokenVal string
 						if err := func() error {
 							val, err := d.DecodeValue()
 							if err != nil {
@@ -173,27 +1770,27 @@ func (s *Server) decodeTokenPostRequest(r *http.Request) (
 								return err
 							}
 
-							unwrappedDotGrantTypeVal = c
+							unwrappedDotActorTokenVal = c
 							return nil
 						}(); err != nil {
 							return err
 						}
-						unwrapped.GrantType.SetTo(unwrappedDotGrantTypeVal)
+						unwrapped.ActorToken.SetTo(unwrappedDotActorTokenVal)
 						return nil
 					}); err != nil {
-						return req, close, errors.Wrap(err, "decode \"grant_type\"")
+						return req, close, errors.Wrap(err, "decode \"actor_token\"")
 					}
 				}
 			}
 			{
 				cfg := uri.QueryParameterDecodingConfig{
-					Name:    "client_id",
+					Name:    "actor_token_type",
 					Style:   uri.QueryStyleForm,
 					Explode: true,
 				}
 				if err := q.HasParam(cfg); err == nil {
 					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
-						var unwrappedDotClientIDVal string
+						var unwrappedDotActorTokenTypeVal string
 						if err := func() error {
 							val, err := d.DecodeValue()
 							if err != nil {
@@ -205,27 +1802,27 @@ func (s *Server) decodeTokenPostRequest(r *http.Request) (
 								return err
 							}
 
-							unwrappedDotClientIDVal = c
+							unwrappedDotActorTokenTypeVal = c
 							return nil
 						}(); err != nil {
 							return err
 						}
-						unwrapped.ClientID.SetTo(unwrappedDotClientIDVal)
+						unwrapped.ActorTokenType.SetTo(unwrappedDotActorTokenTypeVal)
 						return nil
 					}); err != nil {
-						return req, close, errors.Wrap(err, "decode \"client_id\"")
+						return req, close, errors.Wrap(err, "decode \"actor_token_type\"")
 					}
 				}
 			}
 			{
 				cfg := uri.QueryParameterDecodingConfig{
-					Name:    "redirect_uri",
+					Name:    "requested_token_type",
 					Style:   uri.QueryStyleForm,
 					Explode: true,
 				}
 				if err := q.HasParam(cfg); err == nil {
 					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
-						var unwrappedDotRedirectURIVal string
+						var unwrappedDotRequestedTokenTypeVal string
 						if err := func() error {
 							val, err := d.DecodeValue()
 							if err != nil {
@@ -237,27 +1834,27 @@ func (s *Server) decodeTokenPostRequest(r *http.Request) (
 								return err
 							}
 
-							unwrappedDotRedirectURIVal = c
+							unwrappedDotRequestedTokenTypeVal = c
 							return nil
 						}(); err != nil {
 							return err
 						}
-						unwrapped.RedirectURI.SetTo(unwrappedDotRedirectURIVal)
+						unwrapped.RequestedTokenType.SetTo(unwrappedDotRequestedTokenTypeVal)
 						return nil
 					}); err != nil {
-						return req, close, errors.Wrap(err, "decode \"redirect_uri\"")
+						return req, close, errors.Wrap(err, "decode \"requested_token_type\"")
 					}
 				}
 			}
 			{
 				cfg := uri.QueryParameterDecodingConfig{
-					Name:    "scope",
+					Name:    "audience",
 					Style:   uri.QueryStyleForm,
 					Explode: true,
 				}
 				if err := q.HasParam(cfg); err == nil {
 					if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
-						var unwrappedDotScopeVal string
+						var unwrappedDotAudienceVal string
 						if err := func() error {
 							val, err := d.DecodeValue()
 							if err != nil {
@@ -269,15 +1866,15 @@ func (s *Server) decodeTokenPostRequest(r *http.Request) (
 								return err
 							}
 
-							unwrappedDotScopeVal = c
+							unwrappedDotAudienceVal = c
 							return nil
 						}(); err != nil {
 							return err
 						}
-						unwrapped.Scope.SetTo(unwrappedDotScopeVal)
+						unwrapped.Audience.SetTo(unwrappedDotAudienceVal)
 						return nil
 					}); err != nil {
-						return req, close, errors.Wrap(err, "decode \"scope\"")
+						return req, close, errors.Wrap(err, "decode \"audience\"")
 					}
 				}
 			}
@@ -288,3 +1885,66 @@ func (s *Server) decodeTokenPostRequest(r *http.Request) (
 		return req, close, validate.InvalidContentType(ct)
 	}
 }
+
+func (s *Server) decodeUpdateClientRegistrationRequest(r *http.Request) (
+	req *ClientMetadata,
+	close func() error,
+	rerr error,
+) {
+	var closers []func() error
+	close = func() error {
+		var merr error
+		// Close in reverse order, to match defer behavior.
+		for i := len(closers) - 1; i >= 0; i-- {
+			c := closers[i]
+			merr = multierr.Append(merr, c())
+		}
+		return merr
+	}
+	defer func() {
+		if rerr != nil {
+			rerr = multierr.Append(rerr, close())
+		}
+	}()
+	ct, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return req, close, errors.Wrap(err, "parse media type")
+	}
+	switch {
+	case ct == "application/json":
+		if r.ContentLength == 0 {
+			return req, close, validate.ErrBodyRequired
+		}
+		buf, err := io.ReadAll(r.Body)
+		if err != nil {
+			return req, close, err
+		}
+
+		if len(buf) == 0 {
+			return req, close, validate.ErrBodyRequired
+		}
+
+		d := jx.DecodeBytes(buf)
+
+		var request ClientMetadata
+		if err := func() error {
+			if err := request.Decode(d); err != nil {
+				return err
+			}
+			if err := d.Skip(); err != io.EOF {
+				return errors.New("unexpected trailing data")
+			}
+			return nil
+		}(); err != nil {
+			err = &ogenerrors.DecodeBodyError{
+				ContentType: ct,
+				Body:        buf,
+				Err:         err,
+			}
+			return req, close, err
+		}
+		return &request, close, nil
+	default:
+		return req, close, validate.InvalidContentType(ct)
+	}
+}
@@ -40,6 +40,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		s.notFound(w, r)
 		return
 	}
+	args := [1]string{}
 
 	// Static code generated router with unwrapped path search.
 	switch {
@@ -137,6 +138,148 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 
+				elem = origElem
+			case 'c': // Prefix: "connect/register"
+				origElem := elem
+				if l := len("connect/register"); len(elem) >= l && elem[0:l] == "connect/register" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch r.Method {
+					case "POST":
+						s.handleRegisterClientRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "POST")
+					}
+
+					return
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+					origElem := elem
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "client_id"
+					// Leaf parameter
+					args[0] = elem
+					elem = ""
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch r.Method {
+						case "DELETE":
+							s.handleDeleteClientRegistrationRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						case "GET":
+							s.handleGetClientRegistrationRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						case "PUT":
+							s.handleUpdateClientRegistrationRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "DELETE,GET,PUT")
+						}
+
+						return
+					}
+
+					elem = origElem
+				}
+
+				elem = origElem
+			case 'd': // Prefix: "device_authorization"
+				origElem := elem
+				if l := len("device_authorization"); len(elem) >= l && elem[0:l] == "device_authorization" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					// Leaf node.
+					switch r.Method {
+					case "POST":
+						s.handleDeviceAuthorizationPostRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "POST")
+					}
+
+					return
+				}
+
+				elem = origElem
+			case 'i': // Prefix: "introspect"
+				origElem := elem
+				if l := len("introspect"); len(elem) >= l && elem[0:l] == "introspect" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					// Leaf node.
+					switch r.Method {
+					case "POST":
+						s.handleIntrospectPostRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "POST")
+					}
+
+					return
+				}
+
+				elem = origElem
+			case 'p': // Prefix: "par"
+				origElem := elem
+				if l := len("par"); len(elem) >= l && elem[0:l] == "par" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					// Leaf node.
+					switch r.Method {
+					case "POST":
+						s.handleParPostRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "POST")
+					}
+
+					return
+				}
+
+				elem = origElem
+			case 'r': // Prefix: "revoke"
+				origElem := elem
+				if l := len("revoke"); len(elem) >= l && elem[0:l] == "revoke" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					// Leaf node.
+					switch r.Method {
+					case "POST":
+						s.handleRevokePostRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "POST")
+					}
+
+					return
+				}
+
 				elem = origElem
 			case 't': // Prefix: "token"
 				origElem := elem
@@ -195,7 +338,7 @@ type Route struct {
 	operationID string
 	pathPattern string
 	count       int
-	args        [0]string
+	args        [1]string
 }
 
 // Name returns ogen operation name.
@@ -364,6 +507,178 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					}
 				}
 
+				elem = origElem
+			case 'c': // Prefix: "connect/register"
+				origElem := elem
+				if l := len("connect/register"); len(elem) >= l && elem[0:l] == "connect/register" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch method {
+					case "POST":
+						r.name = RegisterClientOperation
+						r.summary = ""
+						r.operationID = "registerClient"
+						r.pathPattern = "/connect/register"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+					origElem := elem
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "client_id"
+					// Leaf parameter
+					args[0] = elem
+					elem = ""
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch method {
+						case "DELETE":
+							r.name = DeleteClientRegistrationOperation
+							r.summary = ""
+							r.operationID = "deleteClientRegistration"
+							r.pathPattern = "/connect/register/{client_id}"
+							r.args = args
+							r.count = 1
+							return r, true
+						case "GET":
+							r.name = GetClientRegistrationOperation
+							r.summary = ""
+							r.operationID = "getClientRegistration"
+							r.pathPattern = "/connect/register/{client_id}"
+							r.args = args
+							r.count = 1
+							return r, true
+						case "PUT":
+							r.name = UpdateClientRegistrationOperation
+							r.summary = ""
+							r.operationID = "updateClientRegistration"
+							r.pathPattern = "/connect/register/{client_id}"
+							r.args = args
+							r.count = 1
+							return r, true
+						default:
+							return
+						}
+					}
+
+					elem = origElem
+				}
+
+				elem = origElem
+			case 'd': // Prefix: "device_authorization"
+				origElem := elem
+				if l := len("device_authorization"); len(elem) >= l && elem[0:l] == "device_authorization" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					// Leaf node.
+					switch method {
+					case "POST":
+						r.name = DeviceAuthorizationPostOperation
+						r.summary = ""
+						r.operationID = ""
+						r.pathPattern = "/device_authorization"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+
+				elem = origElem
+			case 'i': // Prefix: "introspect"
+				origElem := elem
+				if l := len("introspect"); len(elem) >= l && elem[0:l] == "introspect" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					// Leaf node.
+					switch method {
+					case "POST":
+						r.name = IntrospectPostOperation
+						r.summary = ""
+						r.operationID = ""
+						r.pathPattern = "/introspect"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+
+				elem = origElem
+			case 'p': // Prefix: "par"
+				origElem := elem
+				if l := len("par"); len(elem) >= l && elem[0:l] == "par" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					// Leaf node.
+					switch method {
+					case "POST":
+						r.name = ParPostOperation
+						r.summary = ""
+						r.operationID = ""
+						r.pathPattern = "/par"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+
+				elem = origElem
+			case 'r': // Prefix: "revoke"
+				origElem := elem
+				if l := len("revoke"); len(elem) >= l && elem[0:l] == "revoke" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					// Leaf node.
+					switch method {
+					case "POST":
+						r.name = RevokePostOperation
+						r.summary = ""
+						r.operationID = ""
+						r.pathPattern = "/revoke"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+
 				elem = origElem
 			case 't': // Prefix: "token"
 				origElem := elem
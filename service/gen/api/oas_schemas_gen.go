@@ -7,7 +7,7 @@ import (
 	"io"
 )
 
-func (s *ErrRespStatusCode) Error() string {
+func (s *ErrorResponseStatusCode) Error() string {
 	return fmt.Sprintf("code %d: %+v", s.StatusCode, s.Response)
 }
 
@@ -48,46 +48,948 @@ func (s AuthorizeGetOK) Read(p []byte) (n int, err error) {
 
 func (*AuthorizeGetOK) authorizeGetRes() {}
 
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// GetUsername returns the value of Username.
+func (s *BasicAuth) GetUsername() string {
+	return s.Username
+}
+
+// GetPassword returns the value of Password.
+func (s *BasicAuth) GetPassword() string {
+	return s.Password
+}
+
+// SetUsername sets the value of Username.
+func (s *BasicAuth) SetUsername(val string) {
+	s.Username = val
+}
+
+// SetPassword sets the value of Password.
+func (s *BasicAuth) SetPassword(val string) {
+	s.Password = val
+}
+
 type BearerAuth struct {
 	Token string
 }
 
-// GetToken returns the value of Token.
-func (s *BearerAuth) GetToken() string {
-	return s.Token
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
+}
+
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// Ref: #/components/schemas/ClientInformation
+type ClientInformation struct {
+	ClientID                           string    `json:"client_id"`
+	ClientSecret                       OptString `json:"client_secret"`
+	ClientIDIssuedAt                   OptInt64  `json:"client_id_issued_at"`
+	ClientSecretExpiresAt              OptInt64  `json:"client_secret_expires_at"`
+	RegistrationAccessToken            OptString `json:"registration_access_token"`
+	RegistrationClientURI              OptString `json:"registration_client_uri"`
+	RedirectUris                       []string  `json:"redirect_uris"`
+	TokenEndpointAuthMethod            OptString `json:"token_endpoint_auth_method"`
+	GrantTypes                         []string  `json:"grant_types"`
+	ResponseTypes                      []string  `json:"response_types"`
+	ClientName                         OptString `json:"client_name"`
+	ClientURI                          OptString `json:"client_uri"`
+	Scope                              OptString `json:"scope"`
+	JwksURI                            OptString `json:"jwks_uri"`
+	PostLogoutRedirectUris             []string  `json:"post_logout_redirect_uris"`
+	BackchannelLogoutURI               OptString `json:"backchannel_logout_uri"`
+	FrontchannelLogoutURI              OptString `json:"frontchannel_logout_uri"`
+	FrontchannelLogoutSessionRequired  OptBool   `json:"frontchannel_logout_session_required"`
+	RequirePushedAuthorizationRequests OptBool   `json:"require_pushed_authorization_requests"`
+	RequireSignedRequestObject         OptBool   `json:"require_signed_request_object"`
+	DpopBoundAccessTokens              OptBool   `json:"dpop_bound_access_tokens"`
+	RequestUris                        []string  `json:"request_uris"`
+}
+
+// GetClientID returns the value of ClientID.
+func (s *ClientInformation) GetClientID() string {
+	return s.ClientID
+}
+
+// GetClientSecret returns the value of ClientSecret.
+func (s *ClientInformation) GetClientSecret() OptString {
+	return s.ClientSecret
+}
+
+// GetClientIDIssuedAt returns the value of ClientIDIssuedAt.
+func (s *ClientInformation) GetClientIDIssuedAt() OptInt64 {
+	return s.ClientIDIssuedAt
+}
+
+// GetClientSecretExpiresAt returns the value of ClientSecretExpiresAt.
+func (s *ClientInformation) GetClientSecretExpiresAt() OptInt64 {
+	return s.ClientSecretExpiresAt
+}
+
+// GetRegistrationAccessToken returns the value of RegistrationAccessToken.
+func (s *ClientInformation) GetRegistrationAccessToken() OptString {
+	return s.RegistrationAccessToken
+}
+
+// GetRegistrationClientURI returns the value of RegistrationClientURI.
+func (s *ClientInformation) GetRegistrationClientURI() OptString {
+	return s.RegistrationClientURI
+}
+
+// GetRedirectUris returns the value of RedirectUris.
+func (s *ClientInformation) GetRedirectUris() []string {
+	return s.RedirectUris
+}
+
+// GetTokenEndpointAuthMethod returns the value of TokenEndpointAuthMethod.
+func (s *ClientInformation) GetTokenEndpointAuthMethod() OptString {
+	return s.TokenEndpointAuthMethod
+}
+
+// GetGrantTypes returns the value of GrantTypes.
+func (s *ClientInformation) GetGrantTypes() []string {
+	return s.GrantTypes
+}
+
+// GetResponseTypes returns the value of ResponseTypes.
+func (s *ClientInformation) GetResponseTypes() []string {
+	return s.ResponseTypes
+}
+
+// GetClientName returns the value of ClientName.
+func (s *ClientInformation) GetClientName() OptString {
+	return s.ClientName
+}
+
+// GetClientURI returns the value of ClientURI.
+func (s *ClientInformation) GetClientURI() OptString {
+	return s.ClientURI
+}
+
+// GetScope returns the value of Scope.
+func (s *ClientInformation) GetScope() OptString {
+	return s.Scope
+}
+
+// GetJwksURI returns the value of JwksURI.
+func (s *ClientInformation) GetJwksURI() OptString {
+	return s.JwksURI
+}
+
+// GetPostLogoutRedirectUris returns the value of PostLogoutRedirectUris.
+func (s *ClientInformation) GetPostLogoutRedirectUris() []string {
+	return s.PostLogoutRedirectUris
+}
+
+// GetBackchannelLogoutURI returns the value of BackchannelLogoutURI.
+func (s *ClientInformation) GetBackchannelLogoutURI() OptString {
+	return s.BackchannelLogoutURI
+}
+
+// GetFrontchannelLogoutURI returns the value of FrontchannelLogoutURI.
+func (s *ClientInformation) GetFrontchannelLogoutURI() OptString {
+	return s.FrontchannelLogoutURI
+}
+
+// GetFrontchannelLogoutSessionRequired returns the value of FrontchannelLogoutSessionRequired.
+func (s *ClientInformation) GetFrontchannelLogoutSessionRequired() OptBool {
+	return s.FrontchannelLogoutSessionRequired
+}
+
+// GetRequirePushedAuthorizationRequests returns the value of RequirePushedAuthorizationRequests.
+func (s *ClientInformation) GetRequirePushedAuthorizationRequests() OptBool {
+	return s.RequirePushedAuthorizationRequests
+}
+
+// GetRequireSignedRequestObject returns the value of RequireSignedRequestObject.
+func (s *ClientInformation) GetRequireSignedRequestObject() OptBool {
+	return s.RequireSignedRequestObject
+}
+
+// GetDpopBoundAccessTokens returns the value of DpopBoundAccessTokens.
+func (s *ClientInformation) GetDpopBoundAccessTokens() OptBool {
+	return s.DpopBoundAccessTokens
+}
+
+// GetRequestUris returns the value of RequestUris.
+func (s *ClientInformation) GetRequestUris() []string {
+	return s.RequestUris
+}
+
+// SetClientID sets the value of ClientID.
+func (s *ClientInformation) SetClientID(val string) {
+	s.ClientID = val
+}
+
+// SetClientSecret sets the value of ClientSecret.
+func (s *ClientInformation) SetClientSecret(val OptString) {
+	s.ClientSecret = val
+}
+
+// SetClientIDIssuedAt sets the value of ClientIDIssuedAt.
+func (s *ClientInformation) SetClientIDIssuedAt(val OptInt64) {
+	s.ClientIDIssuedAt = val
+}
+
+// SetClientSecretExpiresAt sets the value of ClientSecretExpiresAt.
+func (s *ClientInformation) SetClientSecretExpiresAt(val OptInt64) {
+	s.ClientSecretExpiresAt = val
+}
+
+// SetRegistrationAccessToken sets the value of RegistrationAccessToken.
+func (s *ClientInformation) SetRegistrationAccessToken(val OptString) {
+	s.RegistrationAccessToken = val
+}
+
+// SetRegistrationClientURI sets the value of RegistrationClientURI.
+func (s *ClientInformation) SetRegistrationClientURI(val OptString) {
+	s.RegistrationClientURI = val
+}
+
+// SetRedirectUris sets the value of RedirectUris.
+func (s *ClientInformation) SetRedirectUris(val []string) {
+	s.RedirectUris = val
+}
+
+// SetTokenEndpointAuthMethod sets the value of TokenEndpointAuthMethod.
+func (s *ClientInformation) SetTokenEndpointAuthMethod(val OptString) {
+	s.TokenEndpointAuthMethod = val
+}
+
+// SetGrantTypes sets the value of GrantTypes.
+func (s *ClientInformation) SetGrantTypes(val []string) {
+	s.GrantTypes = val
+}
+
+// SetResponseTypes sets the value of ResponseTypes.
+func (s *ClientInformation) SetResponseTypes(val []string) {
+	s.ResponseTypes = val
+}
+
+// SetClientName sets the value of ClientName.
+func (s *ClientInformation) SetClientName(val OptString) {
+	s.ClientName = val
+}
+
+// SetClientURI sets the value of ClientURI.
+func (s *ClientInformation) SetClientURI(val OptString) {
+	s.ClientURI = val
+}
+
+// SetScope sets the value of Scope.
+func (s *ClientInformation) SetScope(val OptString) {
+	s.Scope = val
+}
+
+// SetJwksURI sets the value of JwksURI.
+func (s *ClientInformation) SetJwksURI(val OptString) {
+	s.JwksURI = val
+}
+
+// SetPostLogoutRedirectUris sets the value of PostLogoutRedirectUris.
+func (s *ClientInformation) SetPostLogoutRedirectUris(val []string) {
+	s.PostLogoutRedirectUris = val
+}
+
+// SetBackchannelLogoutURI sets the value of BackchannelLogoutURI.
+func (s *ClientInformation) SetBackchannelLogoutURI(val OptString) {
+	s.BackchannelLogoutURI = val
+}
+
+// SetFrontchannelLogoutURI sets the value of FrontchannelLogoutURI.
+func (s *ClientInformation) SetFrontchannelLogoutURI(val OptString) {
+	s.FrontchannelLogoutURI = val
+}
+
+// SetFrontchannelLogoutSessionRequired sets the value of FrontchannelLogoutSessionRequired.
+func (s *ClientInformation) SetFrontchannelLogoutSessionRequired(val OptBool) {
+	s.FrontchannelLogoutSessionRequired = val
+}
+
+// SetRequirePushedAuthorizationRequests sets the value of RequirePushedAuthorizationRequests.
+func (s *ClientInformation) SetRequirePushedAuthorizationRequests(val OptBool) {
+	s.RequirePushedAuthorizationRequests = val
+}
+
+// SetRequireSignedRequestObject sets the value of RequireSignedRequestObject.
+func (s *ClientInformation) SetRequireSignedRequestObject(val OptBool) {
+	s.RequireSignedRequestObject = val
+}
+
+// SetDpopBoundAccessTokens sets the value of DpopBoundAccessTokens.
+func (s *ClientInformation) SetDpopBoundAccessTokens(val OptBool) {
+	s.DpopBoundAccessTokens = val
+}
+
+// SetRequestUris sets the value of RequestUris.
+func (s *ClientInformation) SetRequestUris(val []string) {
+	s.RequestUris = val
+}
+
+func (*ClientInformation) getClientRegistrationRes()    {}
+func (*ClientInformation) registerClientRes()           {}
+func (*ClientInformation) updateClientRegistrationRes() {}
+
+// Ref: #/components/schemas/ClientMetadata
+type ClientMetadata struct {
+	ClientID                           OptString `json:"client_id"`
+	ClientSecret                       OptString `json:"client_secret"`
+	RedirectUris                       []string  `json:"redirect_uris"`
+	TokenEndpointAuthMethod            OptString `json:"token_endpoint_auth_method"`
+	GrantTypes                         []string  `json:"grant_types"`
+	ResponseTypes                      []string  `json:"response_types"`
+	ClientName                         OptString `json:"client_name"`
+	ClientURI                          OptString `json:"client_uri"`
+	Scope                              OptString `json:"scope"`
+	JwksURI                            OptString `json:"jwks_uri"`
+	PostLogoutRedirectUris             []string  `json:"post_logout_redirect_uris"`
+	BackchannelLogoutURI               OptString `json:"backchannel_logout_uri"`
+	FrontchannelLogoutURI              OptString `json:"frontchannel_logout_uri"`
+	FrontchannelLogoutSessionRequired  OptBool   `json:"frontchannel_logout_session_required"`
+	RequirePushedAuthorizationRequests OptBool   `json:"require_pushed_authorization_requests"`
+	RequireSignedRequestObject         OptBool   `json:"require_signed_request_object"`
+	DpopBoundAccessTokens              OptBool   `json:"dpop_bound_access_tokens"`
+	RequestUris                        []string  `json:"request_uris"`
+}
+
+// GetClientID returns the value of ClientID.
+func (s *ClientMetadata) GetClientID() OptString {
+	return s.ClientID
+}
+
+// GetClientSecret returns the value of ClientSecret.
+func (s *ClientMetadata) GetClientSecret() OptString {
+	return s.ClientSecret
+}
+
+// GetRedirectUris returns the value of RedirectUris.
+func (s *ClientMetadata) GetRedirectUris() []string {
+	return s.RedirectUris
+}
+
+// GetTokenEndpointAuthMethod returns the value of TokenEndpointAuthMethod.
+func (s *ClientMetadata) GetTokenEndpointAuthMethod() OptString {
+	return s.TokenEndpointAuthMethod
+}
+
+// GetGrantTypes returns the value of GrantTypes.
+func (s *ClientMetadata) GetGrantTypes() []string {
+	return s.GrantTypes
+}
+
+// GetResponseTypes returns the value of ResponseTypes.
+func (s *ClientMetadata) GetResponseTypes() []string {
+	return s.ResponseTypes
+}
+
+// GetClientName returns the value of ClientName.
+func (s *ClientMetadata) GetClientName() OptString {
+	return s.ClientName
+}
+
+// GetClientURI returns the value of ClientURI.
+func (s *ClientMetadata) GetClientURI() OptString {
+	return s.ClientURI
+}
+
+// GetScope returns the value of Scope.
+func (s *ClientMetadata) GetScope() OptString {
+	return s.Scope
+}
+
+// GetJwksURI returns the value of JwksURI.
+func (s *ClientMetadata) GetJwksURI() OptString {
+	return s.JwksURI
+}
+
+// GetPostLogoutRedirectUris returns the value of PostLogoutRedirectUris.
+func (s *ClientMetadata) GetPostLogoutRedirectUris() []string {
+	return s.PostLogoutRedirectUris
+}
+
+// GetBackchannelLogoutURI returns the value of BackchannelLogoutURI.
+func (s *ClientMetadata) GetBackchannelLogoutURI() OptString {
+	return s.BackchannelLogoutURI
+}
+
+// GetFrontchannelLogoutURI returns the value of FrontchannelLogoutURI.
+func (s *ClientMetadata) GetFrontchannelLogoutURI() OptString {
+	return s.FrontchannelLogoutURI
+}
+
+// GetFrontchannelLogoutSessionRequired returns the value of FrontchannelLogoutSessionRequired.
+func (s *ClientMetadata) GetFrontchannelLogoutSessionRequired() OptBool {
+	return s.FrontchannelLogoutSessionRequired
+}
+
+// GetRequirePushedAuthorizationRequests returns the value of RequirePushedAuthorizationRequests.
+func (s *ClientMetadata) GetRequirePushedAuthorizationRequests() OptBool {
+	return s.RequirePushedAuthorizationRequests
+}
+
+// GetRequireSignedRequestObject returns the value of RequireSignedRequestObject.
+func (s *ClientMetadata) GetRequireSignedRequestObject() OptBool {
+	return s.RequireSignedRequestObject
+}
+
+// GetDpopBoundAccessTokens returns the value of DpopBoundAccessTokens.
+func (s *ClientMetadata) GetDpopBoundAccessTokens() OptBool {
+	return s.DpopBoundAccessTokens
+}
+
+// GetRequestUris returns the value of RequestUris.
+func (s *ClientMetadata) GetRequestUris() []string {
+	return s.RequestUris
+}
+
+// SetClientID sets the value of ClientID.
+func (s *ClientMetadata) SetClientID(val OptString) {
+	s.ClientID = val
+}
+
+// SetClientSecret sets the value of ClientSecret.
+func (s *ClientMetadata) SetClientSecret(val OptString) {
+	s.ClientSecret = val
+}
+
+// SetRedirectUris sets the value of RedirectUris.
+func (s *ClientMetadata) SetRedirectUris(val []string) {
+	s.RedirectUris = val
+}
+
+// SetTokenEndpointAuthMethod sets the value of TokenEndpointAuthMethod.
+func (s *ClientMetadata) SetTokenEndpointAuthMethod(val OptString) {
+	s.TokenEndpointAuthMethod = val
+}
+
+// SetGrantTypes sets the value of GrantTypes.
+func (s *ClientMetadata) SetGrantTypes(val []string) {
+	s.GrantTypes = val
+}
+
+// SetResponseTypes sets the value of ResponseTypes.
+func (s *ClientMetadata) SetResponseTypes(val []string) {
+	s.ResponseTypes = val
+}
+
+// SetClientName sets the value of ClientName.
+func (s *ClientMetadata) SetClientName(val OptString) {
+	s.ClientName = val
+}
+
+// SetClientURI sets the value of ClientURI.
+func (s *ClientMetadata) SetClientURI(val OptString) {
+	s.ClientURI = val
+}
+
+// SetScope sets the value of Scope.
+func (s *ClientMetadata) SetScope(val OptString) {
+	s.Scope = val
+}
+
+// SetJwksURI sets the value of JwksURI.
+func (s *ClientMetadata) SetJwksURI(val OptString) {
+	s.JwksURI = val
+}
+
+// SetPostLogoutRedirectUris sets the value of PostLogoutRedirectUris.
+func (s *ClientMetadata) SetPostLogoutRedirectUris(val []string) {
+	s.PostLogoutRedirectUris = val
+}
+
+// SetBackchannelLogoutURI sets the value of BackchannelLogoutURI.
+func (s *ClientMetadata) SetBackchannelLogoutURI(val OptString) {
+	s.BackchannelLogoutURI = val
+}
+
+// SetFrontchannelLogoutURI sets the value of FrontchannelLogoutURI.
+func (s *ClientMetadata) SetFrontchannelLogoutURI(val OptString) {
+	s.FrontchannelLogoutURI = val
+}
+
+// SetFrontchannelLogoutSessionRequired sets the value of FrontchannelLogoutSessionRequired.
+func (s *ClientMetadata) SetFrontchannelLogoutSessionRequired(val OptBool) {
+	s.FrontchannelLogoutSessionRequired = val
+}
+
+// SetRequirePushedAuthorizationRequests sets the value of RequirePushedAuthorizationRequests.
+func (s *ClientMetadata) SetRequirePushedAuthorizationRequests(val OptBool) {
+	s.RequirePushedAuthorizationRequests = val
+}
+
+// SetRequireSignedRequestObject sets the value of RequireSignedRequestObject.
+func (s *ClientMetadata) SetRequireSignedRequestObject(val OptBool) {
+	s.RequireSignedRequestObject = val
+}
+
+// SetDpopBoundAccessTokens sets the value of DpopBoundAccessTokens.
+func (s *ClientMetadata) SetDpopBoundAccessTokens(val OptBool) {
+	s.DpopBoundAccessTokens = val
+}
+
+// SetRequestUris sets the value of RequestUris.
+func (s *ClientMetadata) SetRequestUris(val []string) {
+	s.RequestUris = val
+}
+
+// Ref: #/components/schemas/Confirmation
+type Confirmation struct {
+	Jkt OptString `json:"jkt"`
+}
+
+// GetJkt returns the value of Jkt.
+func (s *Confirmation) GetJkt() OptString {
+	return s.Jkt
+}
+
+// SetJkt sets the value of Jkt.
+func (s *Confirmation) SetJkt(val OptString) {
+	s.Jkt = val
+}
+
+type DPoPAuth struct {
+	APIKey string
+}
+
+// GetAPIKey returns the value of APIKey.
+func (s *DPoPAuth) GetAPIKey() string {
+	return s.APIKey
+}
+
+// SetAPIKey sets the value of APIKey.
+func (s *DPoPAuth) SetAPIKey(val string) {
+	s.APIKey = val
+}
+
+type DPoPProof struct {
+	APIKey string
+}
+
+// GetAPIKey returns the value of APIKey.
+func (s *DPoPProof) GetAPIKey() string {
+	return s.APIKey
+}
+
+// SetAPIKey sets the value of APIKey.
+func (s *DPoPProof) SetAPIKey(val string) {
+	s.APIKey = val
+}
+
+// DeleteClientRegistrationNoContent is response for DeleteClientRegistration operation.
+type DeleteClientRegistrationNoContent struct{}
+
+func (*DeleteClientRegistrationNoContent) deleteClientRegistrationRes() {}
+
+// Ref: #/components/schemas/DeviceAuthorizationRequestBody
+type DeviceAuthorizationRequestBody struct {
+	ClientID            OptString `json:"client_id"`
+	Scope               OptString `json:"scope"`
+	ClientSecret        OptString `json:"client_secret"`
+	ClientAssertionType OptString `json:"client_assertion_type"`
+	ClientAssertion     OptString `json:"client_assertion"`
+}
+
+// GetClientID returns the value of ClientID.
+func (s *DeviceAuthorizationRequestBody) GetClientID() OptString {
+	return s.ClientID
+}
+
+// GetScope returns the value of Scope.
+func (s *DeviceAuthorizationRequestBody) GetScope() OptString {
+	return s.Scope
+}
+
+// GetClientSecret returns the value of ClientSecret.
+func (s *DeviceAuthorizationRequestBody) GetClientSecret() OptString {
+	return s.ClientSecret
+}
+
+// GetClientAssertionType returns the value of ClientAssertionType.
+func (s *DeviceAuthorizationRequestBody) GetClientAssertionType() OptString {
+	return s.ClientAssertionType
+}
+
+// GetClientAssertion returns the value of ClientAssertion.
+func (s *DeviceAuthorizationRequestBody) GetClientAssertion() OptString {
+	return s.ClientAssertion
+}
+
+// SetClientID sets the value of ClientID.
+func (s *DeviceAuthorizationRequestBody) SetClientID(val OptString) {
+	s.ClientID = val
+}
+
+// SetScope sets the value of Scope.
+func (s *DeviceAuthorizationRequestBody) SetScope(val OptString) {
+	s.Scope = val
+}
+
+// SetClientSecret sets the value of ClientSecret.
+func (s *DeviceAuthorizationRequestBody) SetClientSecret(val OptString) {
+	s.ClientSecret = val
+}
+
+// SetClientAssertionType sets the value of ClientAssertionType.
+func (s *DeviceAuthorizationRequestBody) SetClientAssertionType(val OptString) {
+	s.ClientAssertionType = val
+}
+
+// SetClientAssertion sets the value of ClientAssertion.
+func (s *DeviceAuthorizationRequestBody) SetClientAssertion(val OptString) {
+	s.ClientAssertion = val
+}
+
+// Ref: #/components/schemas/DeviceAuthorizationResponse
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string    `json:"device_code"`
+	UserCode                string    `json:"user_code"`
+	VerificationURI         string    `json:"verification_uri"`
+	VerificationURIComplete OptString `json:"verification_uri_complete"`
+	ExpiresIn               int64     `json:"expires_in"`
+	Interval                OptInt64  `json:"interval"`
+}
+
+// GetDeviceCode returns the value of DeviceCode.
+func (s *DeviceAuthorizationResponse) GetDeviceCode() string {
+	return s.DeviceCode
+}
+
+// GetUserCode returns the value of UserCode.
+func (s *DeviceAuthorizationResponse) GetUserCode() string {
+	return s.UserCode
+}
+
+// GetVerificationURI returns the value of VerificationURI.
+func (s *DeviceAuthorizationResponse) GetVerificationURI() string {
+	return s.VerificationURI
+}
+
+// GetVerificationURIComplete returns the value of VerificationURIComplete.
+func (s *DeviceAuthorizationResponse) GetVerificationURIComplete() OptString {
+	return s.VerificationURIComplete
+}
+
+// GetExpiresIn returns the value of ExpiresIn.
+func (s *DeviceAuthorizationResponse) GetExpiresIn() int64 {
+	return s.ExpiresIn
+}
+
+// GetInterval returns the value of Interval.
+func (s *DeviceAuthorizationResponse) GetInterval() OptInt64 {
+	return s.Interval
+}
+
+// SetDeviceCode sets the value of DeviceCode.
+func (s *DeviceAuthorizationResponse) SetDeviceCode(val string) {
+	s.DeviceCode = val
+}
+
+// SetUserCode sets the value of UserCode.
+func (s *DeviceAuthorizationResponse) SetUserCode(val string) {
+	s.UserCode = val
+}
+
+// SetVerificationURI sets the value of VerificationURI.
+func (s *DeviceAuthorizationResponse) SetVerificationURI(val string) {
+	s.VerificationURI = val
+}
+
+// SetVerificationURIComplete sets the value of VerificationURIComplete.
+func (s *DeviceAuthorizationResponse) SetVerificationURIComplete(val OptString) {
+	s.VerificationURIComplete = val
+}
+
+// SetExpiresIn sets the value of ExpiresIn.
+func (s *DeviceAuthorizationResponse) SetExpiresIn(val int64) {
+	s.ExpiresIn = val
+}
+
+// SetInterval sets the value of Interval.
+func (s *DeviceAuthorizationResponse) SetInterval(val OptInt64) {
+	s.Interval = val
+}
+
+func (*DeviceAuthorizationResponse) deviceAuthorizationPostRes() {}
+
+// An OAuth error, for any error without a specific response.
+// Ref: #/components/schemas/ErrorResponse
+type ErrorResponse struct {
+	Error            string    `json:"error"`
+	ErrorDescription OptString `json:"error_description"`
+}
+
+// GetError returns the value of Error.
+func (s *ErrorResponse) GetError() string {
+	return s.Error
+}
+
+// GetErrorDescription returns the value of ErrorDescription.
+func (s *ErrorResponse) GetErrorDescription() OptString {
+	return s.ErrorDescription
+}
+
+// SetError sets the value of Error.
+func (s *ErrorResponse) SetError(val string) {
+	s.Error = val
+}
+
+// SetErrorDescription sets the value of ErrorDescription.
+func (s *ErrorResponse) SetErrorDescription(val OptString) {
+	s.ErrorDescription = val
+}
+
+// ErrorResponseStatusCode wraps ErrorResponse with StatusCode.
+type ErrorResponseStatusCode struct {
+	StatusCode int
+	Response   ErrorResponse
+}
+
+// GetStatusCode returns the value of StatusCode.
+func (s *ErrorResponseStatusCode) GetStatusCode() int {
+	return s.StatusCode
+}
+
+// GetResponse returns the value of Response.
+func (s *ErrorResponseStatusCode) GetResponse() ErrorResponse {
+	return s.Response
+}
+
+// SetStatusCode sets the value of StatusCode.
+func (s *ErrorResponseStatusCode) SetStatusCode(val int) {
+	s.StatusCode = val
+}
+
+// SetResponse sets the value of Response.
+func (s *ErrorResponseStatusCode) SetResponse(val ErrorResponse) {
+	s.Response = val
+}
+
+// Ref: #/components/schemas/IntrospectionRequestBody
+type IntrospectionRequestBody struct {
+	Token               string    `json:"token"`
+	TokenTypeHint       OptString `json:"token_type_hint"`
+	ClientID            OptString `json:"client_id"`
+	ClientSecret        OptString `json:"client_secret"`
+	ClientAssertionType OptString `json:"client_assertion_type"`
+	ClientAssertion     OptString `json:"client_assertion"`
+	DpopHtm             OptString `json:"dpop_htm"`
+	DpopHtu             OptString `json:"dpop_htu"`
+}
+
+// GetToken returns the value of Token.
+func (s *IntrospectionRequestBody) GetToken() string {
+	return s.Token
+}
+
+// GetTokenTypeHint returns the value of TokenTypeHint.
+func (s *IntrospectionRequestBody) GetTokenTypeHint() OptString {
+	return s.TokenTypeHint
+}
+
+// GetClientID returns the value of ClientID.
+func (s *IntrospectionRequestBody) GetClientID() OptString {
+	return s.ClientID
+}
+
+// GetClientSecret returns the value of ClientSecret.
+func (s *IntrospectionRequestBody) GetClientSecret() OptString {
+	return s.ClientSecret
+}
+
+// GetClientAssertionType returns the value of ClientAssertionType.
+func (s *IntrospectionRequestBody) GetClientAssertionType() OptString {
+	return s.ClientAssertionType
+}
+
+// GetClientAssertion returns the value of ClientAssertion.
+func (s *IntrospectionRequestBody) GetClientAssertion() OptString {
+	return s.ClientAssertion
+}
+
+// GetDpopHtm returns the value of DpopHtm.
+func (s *IntrospectionRequestBody) GetDpopHtm() OptString {
+	return s.DpopHtm
+}
+
+// GetDpopHtu returns the value of DpopHtu.
+func (s *IntrospectionRequestBody) GetDpopHtu() OptString {
+	return s.DpopHtu
+}
+
+// SetToken sets the value of Token.
+func (s *IntrospectionRequestBody) SetToken(val string) {
+	s.Token = val
+}
+
+// SetTokenTypeHint sets the value of TokenTypeHint.
+func (s *IntrospectionRequestBody) SetTokenTypeHint(val OptString) {
+	s.TokenTypeHint = val
+}
+
+// SetClientID sets the value of ClientID.
+func (s *IntrospectionRequestBody) SetClientID(val OptString) {
+	s.ClientID = val
+}
+
+// SetClientSecret sets the value of ClientSecret.
+func (s *IntrospectionRequestBody) SetClientSecret(val OptString) {
+	s.ClientSecret = val
+}
+
+// SetClientAssertionType sets the value of ClientAssertionType.
+func (s *IntrospectionRequestBody) SetClientAssertionType(val OptString) {
+	s.ClientAssertionType = val
+}
+
+// SetClientAssertion sets the value of ClientAssertion.
+func (s *IntrospectionRequestBody) SetClientAssertion(val OptString) {
+	s.ClientAssertion = val
+}
+
+// SetDpopHtm sets the value of DpopHtm.
+func (s *IntrospectionRequestBody) SetDpopHtm(val OptString) {
+	s.DpopHtm = val
+}
+
+// SetDpopHtu sets the value of DpopHtu.
+func (s *IntrospectionRequestBody) SetDpopHtu(val OptString) {
+	s.DpopHtu = val
+}
+
+// Ref: #/components/schemas/IntrospectionResponse
+type IntrospectionResponse struct {
+	Active    bool            `json:"active"`
+	Scope     OptString       `json:"scope"`
+	ClientID  OptString       `json:"client_id"`
+	Sub       OptString       `json:"sub"`
+	TokenType OptString       `json:"token_type"`
+	Iss       OptString       `json:"iss"`
+	Exp       OptInt64        `json:"exp"`
+	Iat       OptInt64        `json:"iat"`
+	Nbf       OptInt64        `json:"nbf"`
+	Sid       OptString       `json:"sid"`
+	Cnf       OptConfirmation `json:"cnf"`
+}
+
+// GetActive returns the value of Active.
+func (s *IntrospectionResponse) GetActive() bool {
+	return s.Active
+}
+
+// GetScope returns the value of Scope.
+func (s *IntrospectionResponse) GetScope() OptString {
+	return s.Scope
+}
+
+// GetClientID returns the value of ClientID.
+func (s *IntrospectionResponse) GetClientID() OptString {
+	return s.ClientID
+}
+
+// GetSub returns the value of Sub.
+func (s *IntrospectionResponse) GetSub() OptString {
+	return s.Sub
+}
+
+// GetTokenType returns the value of TokenType.
+func (s *IntrospectionResponse) GetTokenType() OptString {
+	return s.TokenType
+}
+
+// GetIss returns the value of Iss.
+func (s *IntrospectionResponse) GetIss() OptString {
+	return s.Iss
+}
+
+// GetExp returns the value of Exp.
+func (s *IntrospectionResponse) GetExp() OptInt64 {
+	return s.Exp
+}
+
+// GetIat returns the value of Iat.
+func (s *IntrospectionResponse) GetIat() OptInt64 {
+	return s.Iat
+}
+
+// GetNbf returns the value of Nbf.
+func (s *IntrospectionResponse) GetNbf() OptInt64 {
+	return s.Nbf
+}
+
+// GetSid returns the value of Sid.
+func (s *IntrospectionResponse) GetSid() OptString {
+	return s.Sid
+}
+
+// GetCnf returns the value of Cnf.
+func (s *IntrospectionResponse) GetCnf() OptConfirmation {
+	return s.Cnf
+}
+
+// SetActive sets the value of Active.
+func (s *IntrospectionResponse) SetActive(val bool) {
+	s.Active = val
 }
 
-// SetToken sets the value of Token.
-func (s *BearerAuth) SetToken(val string) {
-	s.Token = val
+// SetScope sets the value of Scope.
+func (s *IntrospectionResponse) SetScope(val OptString) {
+	s.Scope = val
 }
 
-// ErrRespStatusCode wraps string with StatusCode.
-type ErrRespStatusCode struct {
-	StatusCode int
-	Response   string
+// SetClientID sets the value of ClientID.
+func (s *IntrospectionResponse) SetClientID(val OptString) {
+	s.ClientID = val
 }
 
-// GetStatusCode returns the value of StatusCode.
-func (s *ErrRespStatusCode) GetStatusCode() int {
-	return s.StatusCode
+// SetSub sets the value of Sub.
+func (s *IntrospectionResponse) SetSub(val OptString) {
+	s.Sub = val
 }
 
-// GetResponse returns the value of Response.
-func (s *ErrRespStatusCode) GetResponse() string {
-	return s.Response
+// SetTokenType sets the value of TokenType.
+func (s *IntrospectionResponse) SetTokenType(val OptString) {
+	s.TokenType = val
 }
 
-// SetStatusCode sets the value of StatusCode.
-func (s *ErrRespStatusCode) SetStatusCode(val int) {
-	s.StatusCode = val
+// SetIss sets the value of Iss.
+func (s *IntrospectionResponse) SetIss(val OptString) {
+	s.Iss = val
 }
 
-// SetResponse sets the value of Response.
-func (s *ErrRespStatusCode) SetResponse(val string) {
-	s.Response = val
+// SetExp sets the value of Exp.
+func (s *IntrospectionResponse) SetExp(val OptInt64) {
+	s.Exp = val
+}
+
+// SetIat sets the value of Iat.
+func (s *IntrospectionResponse) SetIat(val OptInt64) {
+	s.Iat = val
+}
+
+// SetNbf sets the value of Nbf.
+func (s *IntrospectionResponse) SetNbf(val OptInt64) {
+	s.Nbf = val
+}
+
+// SetSid sets the value of Sid.
+func (s *IntrospectionResponse) SetSid(val OptString) {
+	s.Sid = val
+}
+
+// SetCnf sets the value of Cnf.
+func (s *IntrospectionResponse) SetCnf(val OptConfirmation) {
+	s.Cnf = val
 }
 
+func (*IntrospectionResponse) introspectPostRes() {}
+
 // Ref: #/components/schemas/JWKResponse
 type JWKResponse struct {
 	Kty    OptString `json:"kty"`
@@ -233,11 +1135,13 @@ func (s *LoginCookie) SetAPIKey(val string) {
 
 // Ref: #/components/schemas/LoginTokens
 type LoginTokens struct {
-	AccessToken  string  `json:"access_token"`
-	TokenType    string  `json:"token_type"`
-	ExpiresIn    float64 `json:"expires_in"`
-	IDToken      string  `json:"id_token"`
-	RefreshToken string  `json:"refresh_token"`
+	AccessToken     string    `json:"access_token"`
+	TokenType       string    `json:"token_type"`
+	ExpiresIn       float64   `json:"expires_in"`
+	IDToken         OptString `json:"id_token"`
+	RefreshToken    OptString `json:"refresh_token"`
+	Scope           OptString `json:"scope"`
+	IssuedTokenType OptString `json:"issued_token_type"`
 }
 
 // GetAccessToken returns the value of AccessToken.
@@ -256,15 +1160,25 @@ func (s *LoginTokens) GetExpiresIn() float64 {
 }
 
 // GetIDToken returns the value of IDToken.
-func (s *LoginTokens) GetIDToken() string {
+func (s *LoginTokens) GetIDToken() OptString {
 	return s.IDToken
 }
 
 // GetRefreshToken returns the value of RefreshToken.
-func (s *LoginTokens) GetRefreshToken() string {
+func (s *LoginTokens) GetRefreshToken() OptString {
 	return s.RefreshToken
 }
 
+// GetScope returns the value of Scope.
+func (s *LoginTokens) GetScope() OptString {
+	return s.Scope
+}
+
+// GetIssuedTokenType returns the value of IssuedTokenType.
+func (s *LoginTokens) GetIssuedTokenType() OptString {
+	return s.IssuedTokenType
+}
+
 // SetAccessToken sets the value of AccessToken.
 func (s *LoginTokens) SetAccessToken(val string) {
 	s.AccessToken = val
@@ -281,15 +1195,25 @@ func (s *LoginTokens) SetExpiresIn(val float64) {
 }
 
 // SetIDToken sets the value of IDToken.
-func (s *LoginTokens) SetIDToken(val string) {
+func (s *LoginTokens) SetIDToken(val OptString) {
 	s.IDToken = val
 }
 
 // SetRefreshToken sets the value of RefreshToken.
-func (s *LoginTokens) SetRefreshToken(val string) {
+func (s *LoginTokens) SetRefreshToken(val OptString) {
 	s.RefreshToken = val
 }
 
+// SetScope sets the value of Scope.
+func (s *LoginTokens) SetScope(val OptString) {
+	s.Scope = val
+}
+
+// SetIssuedTokenType sets the value of IssuedTokenType.
+func (s *LoginTokens) SetIssuedTokenType(val OptString) {
+	s.IssuedTokenType = val
+}
+
 // LoginTokensHeaders wraps LoginTokens with response headers.
 type LoginTokensHeaders struct {
 	AccessControlAllowOrigin OptString
@@ -318,13 +1242,121 @@ func (s *LoginTokensHeaders) SetResponse(val LoginTokens) {
 
 func (*LoginTokensHeaders) tokenPostRes() {}
 
+// Ref: #/components/schemas/OAuthError
+type OAuthError struct {
+	Error            string    `json:"error"`
+	ErrorDescription OptString `json:"error_description"`
+}
+
+// GetError returns the value of Error.
+func (s *OAuthError) GetError() string {
+	return s.Error
+}
+
+// GetErrorDescription returns the value of ErrorDescription.
+func (s *OAuthError) GetErrorDescription() OptString {
+	return s.ErrorDescription
+}
+
+// SetError sets the value of Error.
+func (s *OAuthError) SetError(val string) {
+	s.Error = val
+}
+
+// SetErrorDescription sets the value of ErrorDescription.
+func (s *OAuthError) SetErrorDescription(val OptString) {
+	s.ErrorDescription = val
+}
+
+func (*OAuthError) deleteClientRegistrationRes() {}
+func (*OAuthError) deviceAuthorizationPostRes()  {}
+func (*OAuthError) getClientRegistrationRes()    {}
+func (*OAuthError) introspectPostRes()           {}
+func (*OAuthError) parPostRes()                  {}
+func (*OAuthError) registerClientRes()           {}
+func (*OAuthError) revokePostRes()               {}
+func (*OAuthError) updateClientRegistrationRes() {}
+
+// OAuthErrorHeaders wraps OAuthError with response headers.
+type OAuthErrorHeaders struct {
+	DPoPNonce       OptString
+	WWWAuthenticate OptString
+	Response        OAuthError
+}
+
+// GetDPoPNonce returns the value of DPoPNonce.
+func (s *OAuthErrorHeaders) GetDPoPNonce() OptString {
+	return s.DPoPNonce
+}
+
+// GetWWWAuthenticate returns the value of WWWAuthenticate.
+func (s *OAuthErrorHeaders) GetWWWAuthenticate() OptString {
+	return s.WWWAuthenticate
+}
+
+// GetResponse returns the value of Response.
+func (s *OAuthErrorHeaders) GetResponse() OAuthError {
+	return s.Response
+}
+
+// SetDPoPNonce sets the value of DPoPNonce.
+func (s *OAuthErrorHeaders) SetDPoPNonce(val OptString) {
+	s.DPoPNonce = val
+}
+
+// SetWWWAuthenticate sets the value of WWWAuthenticate.
+func (s *OAuthErrorHeaders) SetWWWAuthenticate(val OptString) {
+	s.WWWAuthenticate = val
+}
+
+// SetResponse sets the value of Response.
+func (s *OAuthErrorHeaders) SetResponse(val OAuthError) {
+	s.Response = val
+}
+
+func (*OAuthErrorHeaders) deleteClientRegistrationRes() {}
+func (*OAuthErrorHeaders) deviceAuthorizationPostRes()  {}
+func (*OAuthErrorHeaders) getClientRegistrationRes()    {}
+func (*OAuthErrorHeaders) introspectPostRes()           {}
+func (*OAuthErrorHeaders) parPostRes()                  {}
+func (*OAuthErrorHeaders) registerClientRes()           {}
+func (*OAuthErrorHeaders) revokePostRes()               {}
+func (*OAuthErrorHeaders) updateClientRegistrationRes() {}
+func (*OAuthErrorHeaders) userinfoGetRes()              {}
+
 // Ref: #/components/schemas/OpenIDProviderMetadataResponse
 type OpenIDProviderMetadataResponse struct {
-	Issuer                string `json:"issuer"`
-	AuthorizationEndpoint string `json:"authorization_endpoint"`
-	TokenEndpoint         string `json:"token_endpoint"`
-	JwksURI               string `json:"jwks_uri"`
-	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	Issuer                                     string    `json:"issuer"`
+	AuthorizationEndpoint                      string    `json:"authorization_endpoint"`
+	TokenEndpoint                              string    `json:"token_endpoint"`
+	JwksURI                                    string    `json:"jwks_uri"`
+	UserinfoEndpoint                           string    `json:"userinfo_endpoint"`
+	TokenEndpointAuthMethodsSupported          []string  `json:"token_endpoint_auth_methods_supported"`
+	TokenEndpointAuthSigningAlgValuesSupported []string  `json:"token_endpoint_auth_signing_alg_values_supported"`
+	ResponseTypesSupported                     []string  `json:"response_types_supported"`
+	ResponseModesSupported                     []string  `json:"response_modes_supported"`
+	SubjectTypesSupported                      []string  `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported           []string  `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                            []string  `json:"scopes_supported"`
+	ClaimsSupported                            []string  `json:"claims_supported"`
+	GrantTypesSupported                        []string  `json:"grant_types_supported"`
+	CodeChallengeMethodsSupported              []string  `json:"code_challenge_methods_supported"`
+	RequestObjectSigningAlgValuesSupported     []string  `json:"request_object_signing_alg_values_supported"`
+	RequestParameterSupported                  OptBool   `json:"request_parameter_supported"`
+	RequestURIParameterSupported               OptBool   `json:"request_uri_parameter_supported"`
+	RequireRequestURIRegistration              OptBool   `json:"require_request_uri_registration"`
+	DpopSigningAlgValuesSupported              []string  `json:"dpop_signing_alg_values_supported"`
+	EndSessionEndpoint                         OptString `json:"end_session_endpoint"`
+	RevocationEndpoint                         OptString `json:"revocation_endpoint"`
+	IntrospectionEndpoint                      OptString `json:"introspection_endpoint"`
+	DeviceAuthorizationEndpoint                OptString `json:"device_authorization_endpoint"`
+	RegistrationEndpoint                       OptString `json:"registration_endpoint"`
+	PushedAuthorizationRequestEndpoint         OptString `json:"pushed_authorization_request_endpoint"`
+	RequirePushedAuthorizationRequests         OptBool   `json:"require_pushed_authorization_requests"`
+	BackchannelLogoutSupported                 OptBool   `json:"backchannel_logout_supported"`
+	BackchannelLogoutSessionSupported          OptBool   `json:"backchannel_logout_session_supported"`
+	FrontchannelLogoutSupported                OptBool   `json:"frontchannel_logout_supported"`
+	FrontchannelLogoutSessionSupported         OptBool   `json:"frontchannel_logout_session_supported"`
 }
 
 // GetIssuer returns the value of Issuer.
@@ -352,6 +1384,136 @@ func (s *OpenIDProviderMetadataResponse) GetUserinfoEndpoint() string {
 	return s.UserinfoEndpoint
 }
 
+// GetTokenEndpointAuthMethodsSupported returns the value of TokenEndpointAuthMethodsSupported.
+func (s *OpenIDProviderMetadataResponse) GetTokenEndpointAuthMethodsSupported() []string {
+	return s.TokenEndpointAuthMethodsSupported
+}
+
+// GetTokenEndpointAuthSigningAlgValuesSupported returns the value of TokenEndpointAuthSigningAlgValuesSupported.
+func (s *OpenIDProviderMetadataResponse) GetTokenEndpointAuthSigningAlgValuesSupported() []string {
+	return s.TokenEndpointAuthSigningAlgValuesSupported
+}
+
+// GetResponseTypesSupported returns the value of ResponseTypesSupported.
+func (s *OpenIDProviderMetadataResponse) GetResponseTypesSupported() []string {
+	return s.ResponseTypesSupported
+}
+
+// GetResponseModesSupported returns the value of ResponseModesSupported.
+func (s *OpenIDProviderMetadataResponse) GetResponseModesSupported() []string {
+	return s.ResponseModesSupported
+}
+
+// GetSubjectTypesSupported returns the value of SubjectTypesSupported.
+func (s *OpenIDProviderMetadataResponse) GetSubjectTypesSupported() []string {
+	return s.SubjectTypesSupported
+}
+
+// GetIDTokenSigningAlgValuesSupported returns the value of IDTokenSigningAlgValuesSupported.
+func (s *OpenIDProviderMetadataResponse) GetIDTokenSigningAlgValuesSupported() []string {
+	return s.IDTokenSigningAlgValuesSupported
+}
+
+// GetScopesSupported returns the value of ScopesSupported.
+func (s *OpenIDProviderMetadataResponse) GetScopesSupported() []string {
+	return s.ScopesSupported
+}
+
+// GetClaimsSupported returns the value of ClaimsSupported.
+func (s *OpenIDProviderMetadataResponse) GetClaimsSupported() []string {
+	return s.ClaimsSupported
+}
+
+// GetGrantTypesSupported returns the value of GrantTypesSupported.
+func (s *OpenIDProviderMetadataResponse) GetGrantTypesSupported() []string {
+	return s.GrantTypesSupported
+}
+
+// GetCodeChallengeMethodsSupported returns the value of CodeChallengeMethodsSupported.
+func (s *OpenIDProviderMetadataResponse) GetCodeChallengeMethodsSupported() []string {
+	return s.CodeChallengeMethodsSupported
+}
+
+// GetRequestObjectSigningAlgValuesSupported returns the value of RequestObjectSigningAlgValuesSupported.
+func (s *OpenIDProviderMetadataResponse) GetRequestObjectSigningAlgValuesSupported() []string {
+	return s.RequestObjectSigningAlgValuesSupported
+}
+
+// GetRequestParameterSupported returns the value of RequestParameterSupported.
+func (s *OpenIDProviderMetadataResponse) GetRequestParameterSupported() OptBool {
+	return s.RequestParameterSupported
+}
+
+// GetRequestURIParameterSupported returns the value of RequestURIParameterSupported.
+func (s *OpenIDProviderMetadataResponse) GetRequestURIParameterSupported() OptBool {
+	return s.RequestURIParameterSupported
+}
+
+// GetRequireRequestURIRegistration returns the value of RequireRequestURIRegistration.
+func (s *OpenIDProviderMetadataResponse) GetRequireRequestURIRegistration() OptBool {
+	return s.RequireRequestURIRegistration
+}
+
+// GetDpopSigningAlgValuesSupported returns the value of DpopSigningAlgValuesSupported.
+func (s *OpenIDProviderMetadataResponse) GetDpopSigningAlgValuesSupported() []string {
+	return s.DpopSigningAlgValuesSupported
+}
+
+// GetEndSessionEndpoint returns the value of EndSessionEndpoint.
+func (s *OpenIDProviderMetadataResponse) GetEndSessionEndpoint() OptString {
+	return s.EndSessionEndpoint
+}
+
+// GetRevocationEndpoint returns the value of RevocationEndpoint.
+func (s *OpenIDProviderMetadataResponse) GetRevocationEndpoint() OptString {
+	return s.RevocationEndpoint
+}
+
+// GetIntrospectionEndpoint returns the value of IntrospectionEndpoint.
+func (s *OpenIDProviderMetadataResponse) GetIntrospectionEndpoint() OptString {
+	return s.IntrospectionEndpoint
+}
+
+// GetDeviceAuthorizationEndpoint returns the value of DeviceAuthorizationEndpoint.
+func (s *OpenIDProviderMetadataResponse) GetDeviceAuthorizationEndpoint() OptString {
+	return s.DeviceAuthorizationEndpoint
+}
+
+// GetRegistrationEndpoint returns the value of RegistrationEndpoint.
+func (s *OpenIDProviderMetadataResponse) GetRegistrationEndpoint() OptString {
+	return s.RegistrationEndpoint
+}
+
+// GetPushedAuthorizationRequestEndpoint returns the value of PushedAuthorizationRequestEndpoint.
+func (s *OpenIDProviderMetadataResponse) GetPushedAuthorizationRequestEndpoint() OptString {
+	return s.PushedAuthorizationRequestEndpoint
+}
+
+// GetRequirePushedAuthorizationRequests returns the value of RequirePushedAuthorizationRequests.
+func (s *OpenIDProviderMetadataResponse) GetRequirePushedAuthorizationRequests() OptBool {
+	return s.RequirePushedAuthorizationRequests
+}
+
+// GetBackchannelLogoutSupported returns the value of BackchannelLogoutSupported.
+func (s *OpenIDProviderMetadataResponse) GetBackchannelLogoutSupported() OptBool {
+	return s.BackchannelLogoutSupported
+}
+
+// GetBackchannelLogoutSessionSupported returns the value of BackchannelLogoutSessionSupported.
+func (s *OpenIDProviderMetadataResponse) GetBackchannelLogoutSessionSupported() OptBool {
+	return s.BackchannelLogoutSessionSupported
+}
+
+// GetFrontchannelLogoutSupported returns the value of FrontchannelLogoutSupported.
+func (s *OpenIDProviderMetadataResponse) GetFrontchannelLogoutSupported() OptBool {
+	return s.FrontchannelLogoutSupported
+}
+
+// GetFrontchannelLogoutSessionSupported returns the value of FrontchannelLogoutSessionSupported.
+func (s *OpenIDProviderMetadataResponse) GetFrontchannelLogoutSessionSupported() OptBool {
+	return s.FrontchannelLogoutSessionSupported
+}
+
 // SetIssuer sets the value of Issuer.
 func (s *OpenIDProviderMetadataResponse) SetIssuer(val string) {
 	s.Issuer = val
@@ -367,74 +1529,296 @@ func (s *OpenIDProviderMetadataResponse) SetTokenEndpoint(val string) {
 	s.TokenEndpoint = val
 }
 
-// SetJwksURI sets the value of JwksURI.
-func (s *OpenIDProviderMetadataResponse) SetJwksURI(val string) {
-	s.JwksURI = val
+// SetJwksURI sets the value of JwksURI.
+func (s *OpenIDProviderMetadataResponse) SetJwksURI(val string) {
+	s.JwksURI = val
+}
+
+// SetUserinfoEndpoint sets the value of UserinfoEndpoint.
+func (s *OpenIDProviderMetadataResponse) SetUserinfoEndpoint(val string) {
+	s.UserinfoEndpoint = val
+}
+
+// SetTokenEndpointAuthMethodsSupported sets the value of TokenEndpointAuthMethodsSupported.
+func (s *OpenIDProviderMetadataResponse) SetTokenEndpointAuthMethodsSupported(val []string) {
+	s.TokenEndpointAuthMethodsSupported = val
+}
+
+// SetTokenEndpointAuthSigningAlgValuesSupported sets the value of TokenEndpointAuthSigningAlgValuesSupported.
+func (s *OpenIDProviderMetadataResponse) SetTokenEndpointAuthSigningAlgValuesSupported(val []string) {
+	s.TokenEndpointAuthSigningAlgValuesSupported = val
+}
+
+// SetResponseTypesSupported sets the value of ResponseTypesSupported.
+func (s *OpenIDProviderMetadataResponse) SetResponseTypesSupported(val []string) {
+	s.ResponseTypesSupported = val
+}
+
+// SetResponseModesSupported sets the value of ResponseModesSupported.
+func (s *OpenIDProviderMetadataResponse) SetResponseModesSupported(val []string) {
+	s.ResponseModesSupported = val
+}
+
+// SetSubjectTypesSupported sets the value of SubjectTypesSupported.
+func (s *OpenIDProviderMetadataResponse) SetSubjectTypesSupported(val []string) {
+	s.SubjectTypesSupported = val
+}
+
+// SetIDTokenSigningAlgValuesSupported sets the value of IDTokenSigningAlgValuesSupported.
+func (s *OpenIDProviderMetadataResponse) SetIDTokenSigningAlgValuesSupported(val []string) {
+	s.IDTokenSigningAlgValuesSupported = val
+}
+
+// SetScopesSupported sets the value of ScopesSupported.
+func (s *OpenIDProviderMetadataResponse) SetScopesSupported(val []string) {
+	s.ScopesSupported = val
+}
+
+// SetClaimsSupported sets the value of ClaimsSupported.
+func (s *OpenIDProviderMetadataResponse) SetClaimsSupported(val []string) {
+	s.ClaimsSupported = val
+}
+
+// SetGrantTypesSupported sets the value of GrantTypesSupported.
+func (s *OpenIDProviderMetadataResponse) SetGrantTypesSupported(val []string) {
+	s.GrantTypesSupported = val
+}
+
+// SetCodeChallengeMethodsSupported sets the value of CodeChallengeMethodsSupported.
+func (s *OpenIDProviderMetadataResponse) SetCodeChallengeMethodsSupported(val []string) {
+	s.CodeChallengeMethodsSupported = val
+}
+
+// SetRequestObjectSigningAlgValuesSupported sets the value of RequestObjectSigningAlgValuesSupported.
+func (s *OpenIDProviderMetadataResponse) SetRequestObjectSigningAlgValuesSupported(val []string) {
+	s.RequestObjectSigningAlgValuesSupported = val
+}
+
+// SetRequestParameterSupported sets the value of RequestParameterSupported.
+func (s *OpenIDProviderMetadataResponse) SetRequestParameterSupported(val OptBool) {
+	s.RequestParameterSupported = val
+}
+
+// SetRequestURIParameterSupported sets the value of RequestURIParameterSupported.
+func (s *OpenIDProviderMetadataResponse) SetRequestURIParameterSupported(val OptBool) {
+	s.RequestURIParameterSupported = val
+}
+
+// SetRequireRequestURIRegistration sets the value of RequireRequestURIRegistration.
+func (s *OpenIDProviderMetadataResponse) SetRequireRequestURIRegistration(val OptBool) {
+	s.RequireRequestURIRegistration = val
+}
+
+// SetDpopSigningAlgValuesSupported sets the value of DpopSigningAlgValuesSupported.
+func (s *OpenIDProviderMetadataResponse) SetDpopSigningAlgValuesSupported(val []string) {
+	s.DpopSigningAlgValuesSupported = val
+}
+
+// SetEndSessionEndpoint sets the value of EndSessionEndpoint.
+func (s *OpenIDProviderMetadataResponse) SetEndSessionEndpoint(val OptString) {
+	s.EndSessionEndpoint = val
+}
+
+// SetRevocationEndpoint sets the value of RevocationEndpoint.
+func (s *OpenIDProviderMetadataResponse) SetRevocationEndpoint(val OptString) {
+	s.RevocationEndpoint = val
+}
+
+// SetIntrospectionEndpoint sets the value of IntrospectionEndpoint.
+func (s *OpenIDProviderMetadataResponse) SetIntrospectionEndpoint(val OptString) {
+	s.IntrospectionEndpoint = val
+}
+
+// SetDeviceAuthorizationEndpoint sets the value of DeviceAuthorizationEndpoint.
+func (s *OpenIDProviderMetadataResponse) SetDeviceAuthorizationEndpoint(val OptString) {
+	s.DeviceAuthorizationEndpoint = val
+}
+
+// SetRegistrationEndpoint sets the value of RegistrationEndpoint.
+func (s *OpenIDProviderMetadataResponse) SetRegistrationEndpoint(val OptString) {
+	s.RegistrationEndpoint = val
+}
+
+// SetPushedAuthorizationRequestEndpoint sets the value of PushedAuthorizationRequestEndpoint.
+func (s *OpenIDProviderMetadataResponse) SetPushedAuthorizationRequestEndpoint(val OptString) {
+	s.PushedAuthorizationRequestEndpoint = val
+}
+
+// SetRequirePushedAuthorizationRequests sets the value of RequirePushedAuthorizationRequests.
+func (s *OpenIDProviderMetadataResponse) SetRequirePushedAuthorizationRequests(val OptBool) {
+	s.RequirePushedAuthorizationRequests = val
+}
+
+// SetBackchannelLogoutSupported sets the value of BackchannelLogoutSupported.
+func (s *OpenIDProviderMetadataResponse) SetBackchannelLogoutSupported(val OptBool) {
+	s.BackchannelLogoutSupported = val
+}
+
+// SetBackchannelLogoutSessionSupported sets the value of BackchannelLogoutSessionSupported.
+func (s *OpenIDProviderMetadataResponse) SetBackchannelLogoutSessionSupported(val OptBool) {
+	s.BackchannelLogoutSessionSupported = val
+}
+
+// SetFrontchannelLogoutSupported sets the value of FrontchannelLogoutSupported.
+func (s *OpenIDProviderMetadataResponse) SetFrontchannelLogoutSupported(val OptBool) {
+	s.FrontchannelLogoutSupported = val
+}
+
+// SetFrontchannelLogoutSessionSupported sets the value of FrontchannelLogoutSessionSupported.
+func (s *OpenIDProviderMetadataResponse) SetFrontchannelLogoutSessionSupported(val OptBool) {
+	s.FrontchannelLogoutSessionSupported = val
+}
+
+// OpenIDProviderMetadataResponseHeaders wraps OpenIDProviderMetadataResponse with response headers.
+type OpenIDProviderMetadataResponseHeaders struct {
+	AccessControlAllowOrigin OptString
+	Response                 OpenIDProviderMetadataResponse
+}
+
+// GetAccessControlAllowOrigin returns the value of AccessControlAllowOrigin.
+func (s *OpenIDProviderMetadataResponseHeaders) GetAccessControlAllowOrigin() OptString {
+	return s.AccessControlAllowOrigin
+}
+
+// GetResponse returns the value of Response.
+func (s *OpenIDProviderMetadataResponseHeaders) GetResponse() OpenIDProviderMetadataResponse {
+	return s.Response
+}
+
+// SetAccessControlAllowOrigin sets the value of AccessControlAllowOrigin.
+func (s *OpenIDProviderMetadataResponseHeaders) SetAccessControlAllowOrigin(val OptString) {
+	s.AccessControlAllowOrigin = val
+}
+
+// SetResponse sets the value of Response.
+func (s *OpenIDProviderMetadataResponseHeaders) SetResponse(val OpenIDProviderMetadataResponse) {
+	s.Response = val
+}
+
+// NewOptBool returns new OptBool with value set to v.
+func NewOptBool(v bool) OptBool {
+	return OptBool{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptBool is optional bool.
+type OptBool struct {
+	Value bool
+	Set   bool
+}
+
+// IsSet returns true if OptBool was set.
+func (o OptBool) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptBool) Reset() {
+	var v bool
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptBool) SetTo(v bool) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptBool) Get() (v bool, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptBool) Or(d bool) bool {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptConfirmation returns new OptConfirmation with value set to v.
+func NewOptConfirmation(v Confirmation) OptConfirmation {
+	return OptConfirmation{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// SetUserinfoEndpoint sets the value of UserinfoEndpoint.
-func (s *OpenIDProviderMetadataResponse) SetUserinfoEndpoint(val string) {
-	s.UserinfoEndpoint = val
+// OptConfirmation is optional Confirmation.
+type OptConfirmation struct {
+	Value Confirmation
+	Set   bool
 }
 
-// OpenIDProviderMetadataResponseHeaders wraps OpenIDProviderMetadataResponse with response headers.
-type OpenIDProviderMetadataResponseHeaders struct {
-	AccessControlAllowOrigin OptString
-	Response                 OpenIDProviderMetadataResponse
-}
+// IsSet returns true if OptConfirmation was set.
+func (o OptConfirmation) IsSet() bool { return o.Set }
 
-// GetAccessControlAllowOrigin returns the value of AccessControlAllowOrigin.
-func (s *OpenIDProviderMetadataResponseHeaders) GetAccessControlAllowOrigin() OptString {
-	return s.AccessControlAllowOrigin
+// Reset unsets value.
+func (o *OptConfirmation) Reset() {
+	var v Confirmation
+	o.Value = v
+	o.Set = false
 }
 
-// GetResponse returns the value of Response.
-func (s *OpenIDProviderMetadataResponseHeaders) GetResponse() OpenIDProviderMetadataResponse {
-	return s.Response
+// SetTo sets value to v.
+func (o *OptConfirmation) SetTo(v Confirmation) {
+	o.Set = true
+	o.Value = v
 }
 
-// SetAccessControlAllowOrigin sets the value of AccessControlAllowOrigin.
-func (s *OpenIDProviderMetadataResponseHeaders) SetAccessControlAllowOrigin(val OptString) {
-	s.AccessControlAllowOrigin = val
+// Get returns value and boolean that denotes whether value was set.
+func (o OptConfirmation) Get() (v Confirmation, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// SetResponse sets the value of Response.
-func (s *OpenIDProviderMetadataResponseHeaders) SetResponse(val OpenIDProviderMetadataResponse) {
-	s.Response = val
+// Or returns value if set, or given parameter if does not.
+func (o OptConfirmation) Or(d Confirmation) Confirmation {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// NewOptBool returns new OptBool with value set to v.
-func NewOptBool(v bool) OptBool {
-	return OptBool{
+// NewOptInt64 returns new OptInt64 with value set to v.
+func NewOptInt64(v int64) OptInt64 {
+	return OptInt64{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptBool is optional bool.
-type OptBool struct {
-	Value bool
+// OptInt64 is optional int64.
+type OptInt64 struct {
+	Value int64
 	Set   bool
 }
 
-// IsSet returns true if OptBool was set.
-func (o OptBool) IsSet() bool { return o.Set }
+// IsSet returns true if OptInt64 was set.
+func (o OptInt64) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptBool) Reset() {
-	var v bool
+func (o *OptInt64) Reset() {
+	var v int64
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptBool) SetTo(v bool) {
+func (o *OptInt64) SetTo(v int64) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptBool) Get() (v bool, ok bool) {
+func (o OptInt64) Get() (v int64, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -442,7 +1826,7 @@ func (o OptBool) Get() (v bool, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptBool) Or(d bool) bool {
+func (o OptInt64) Or(d int64) int64 {
 	if v, ok := o.Get(); ok {
 		return v
 	}
@@ -495,6 +1879,256 @@ func (o OptString) Or(d string) string {
 	return d
 }
 
+// Ref: #/components/schemas/PushedAuthorizationRequestBody
+type PushedAuthorizationRequestBody struct {
+	ResponseType        OptString `json:"response_type"`
+	ClientID            OptString `json:"client_id"`
+	Scope               OptString `json:"scope"`
+	RedirectURI         OptString `json:"redirect_uri"`
+	State               OptString `json:"state"`
+	ResponseMode        OptString `json:"response_mode"`
+	Nonce               OptString `json:"nonce"`
+	CodeChallenge       OptString `json:"code_challenge"`
+	CodeChallengeMethod OptString `json:"code_challenge_method"`
+	Request             OptString `json:"request"`
+	ClientSecret        OptString `json:"client_secret"`
+	ClientAssertionType OptString `json:"client_assertion_type"`
+	ClientAssertion     OptString `json:"client_assertion"`
+}
+
+// GetResponseType returns the value of ResponseType.
+func (s *PushedAuthorizationRequestBody) GetResponseType() OptString {
+	return s.ResponseType
+}
+
+// GetClientID returns the value of ClientID.
+func (s *PushedAuthorizationRequestBody) GetClientID() OptString {
+	return s.ClientID
+}
+
+// GetScope returns the value of Scope.
+func (s *PushedAuthorizationRequestBody) GetScope() OptString {
+	return s.Scope
+}
+
+// GetRedirectURI returns the value of RedirectURI.
+func (s *PushedAuthorizationRequestBody) GetRedirectURI() OptString {
+	return s.RedirectURI
+}
+
+// GetState returns the value of State.
+func (s *PushedAuthorizationRequestBody) GetState() OptString {
+	return s.State
+}
+
+// GetResponseMode returns the value of ResponseMode.
+func (s *PushedAuthorizationRequestBody) GetResponseMode() OptString {
+	return s.ResponseMode
+}
+
+// GetNonce returns the value of Nonce.
+func (s *PushedAuthorizationRequestBody) GetNonce() OptString {
+	return s.Nonce
+}
+
+// GetCodeChallenge returns the value of CodeChallenge.
+func (s *PushedAuthorizationRequestBody) GetCodeChallenge() OptString {
+	return s.CodeChallenge
+}
+
+// GetCodeChallengeMethod returns the value of CodeChallengeMethod.
+func (s *PushedAuthorizationRequestBody) GetCodeChallengeMethod() OptString {
+	return s.CodeChallengeMethod
+}
+
+// GetRequest returns the value of Request.
+func (s *PushedAuthorizationRequestBody) GetRequest() OptString {
+	return s.Request
+}
+
+// GetClientSecret returns the value of ClientSecret.
+func (s *PushedAuthorizationRequestBody) GetClientSecret() OptString {
+	return s.ClientSecret
+}
+
+// GetClientAssertionType returns the value of ClientAssertionType.
+func (s *PushedAuthorizationRequestBody) GetClientAssertionType() OptString {
+	return s.ClientAssertionType
+}
+
+// GetClientAssertion returns the value of ClientAssertion.
+func (s *PushedAuthorizationRequestBody) GetClientAssertion() OptString {
+	return s.ClientAssertion
+}
+
+// SetResponseType sets the value of ResponseType.
+func (s *PushedAuthorizationRequestBody) SetResponseType(val OptString) {
+	s.ResponseType = val
+}
+
+// SetClientID sets the value of ClientID.
+func (s *PushedAuthorizationRequestBody) SetClientID(val OptString) {
+	s.ClientID = val
+}
+
+// SetScope sets the value of Scope.
+func (s *PushedAuthorizationRequestBody) SetScope(val OptString) {
+	s.Scope = val
+}
+
+// SetRedirectURI sets the value of RedirectURI.
+func (s *PushedAuthorizationRequestBody) SetRedirectURI(val OptString) {
+	s.RedirectURI = val
+}
+
+// SetState sets the value of State.
+func (s *PushedAuthorizationRequestBody) SetState(val OptString) {
+	s.State = val
+}
+
+// SetResponseMode sets the value of ResponseMode.
+func (s *PushedAuthorizationRequestBody) SetResponseMode(val OptString) {
+	s.ResponseMode = val
+}
+
+// SetNonce sets the value of Nonce.
+func (s *PushedAuthorizationRequestBody) SetNonce(val OptString) {
+	s.Nonce = val
+}
+
+// SetCodeChallenge sets the value of CodeChallenge.
+func (s *PushedAuthorizationRequestBody) SetCodeChallenge(val OptString) {
+	s.CodeChallenge = val
+}
+
+// SetCodeChallengeMethod sets the value of CodeChallengeMethod.
+func (s *PushedAuthorizationRequestBody) SetCodeChallengeMethod(val OptString) {
+	s.CodeChallengeMethod = val
+}
+
+// SetRequest sets the value of Request.
+func (s *PushedAuthorizationRequestBody) SetRequest(val OptString) {
+	s.Request = val
+}
+
+// SetClientSecret sets the value of ClientSecret.
+func (s *PushedAuthorizationRequestBody) SetClientSecret(val OptString) {
+	s.ClientSecret = val
+}
+
+// SetClientAssertionType sets the value of ClientAssertionType.
+func (s *PushedAuthorizationRequestBody) SetClientAssertionType(val OptString) {
+	s.ClientAssertionType = val
+}
+
+// SetClientAssertion sets the value of ClientAssertion.
+func (s *PushedAuthorizationRequestBody) SetClientAssertion(val OptString) {
+	s.ClientAssertion = val
+}
+
+// Ref: #/components/schemas/PushedAuthorizationResponse
+type PushedAuthorizationResponse struct {
+	RequestURI string `json:"request_uri"`
+	ExpiresIn  int64  `json:"expires_in"`
+}
+
+// GetRequestURI returns the value of RequestURI.
+func (s *PushedAuthorizationResponse) GetRequestURI() string {
+	return s.RequestURI
+}
+
+// GetExpiresIn returns the value of ExpiresIn.
+func (s *PushedAuthorizationResponse) GetExpiresIn() int64 {
+	return s.ExpiresIn
+}
+
+// SetRequestURI sets the value of RequestURI.
+func (s *PushedAuthorizationResponse) SetRequestURI(val string) {
+	s.RequestURI = val
+}
+
+// SetExpiresIn sets the value of ExpiresIn.
+func (s *PushedAuthorizationResponse) SetExpiresIn(val int64) {
+	s.ExpiresIn = val
+}
+
+func (*PushedAuthorizationResponse) parPostRes() {}
+
+// Ref: #/components/schemas/RevocationRequestBody
+type RevocationRequestBody struct {
+	Token               string    `json:"token"`
+	TokenTypeHint       OptString `json:"token_type_hint"`
+	ClientID            OptString `json:"client_id"`
+	ClientSecret        OptString `json:"client_secret"`
+	ClientAssertionType OptString `json:"client_assertion_type"`
+	ClientAssertion     OptString `json:"client_assertion"`
+}
+
+// GetToken returns the value of Token.
+func (s *RevocationRequestBody) GetToken() string {
+	return s.Token
+}
+
+// GetTokenTypeHint returns the value of TokenTypeHint.
+func (s *RevocationRequestBody) GetTokenTypeHint() OptString {
+	return s.TokenTypeHint
+}
+
+// GetClientID returns the value of ClientID.
+func (s *RevocationRequestBody) GetClientID() OptString {
+	return s.ClientID
+}
+
+// GetClientSecret returns the value of ClientSecret.
+func (s *RevocationRequestBody) GetClientSecret() OptString {
+	return s.ClientSecret
+}
+
+// GetClientAssertionType returns the value of ClientAssertionType.
+func (s *RevocationRequestBody) GetClientAssertionType() OptString {
+	return s.ClientAssertionType
+}
+
+// GetClientAssertion returns the value of ClientAssertion.
+func (s *RevocationRequestBody) GetClientAssertion() OptString {
+	return s.ClientAssertion
+}
+
+// SetToken sets the value of Token.
+func (s *RevocationRequestBody) SetToken(val string) {
+	s.Token = val
+}
+
+// SetTokenTypeHint sets the value of TokenTypeHint.
+func (s *RevocationRequestBody) SetTokenTypeHint(val OptString) {
+	s.TokenTypeHint = val
+}
+
+// SetClientID sets the value of ClientID.
+func (s *RevocationRequestBody) SetClientID(val OptString) {
+	s.ClientID = val
+}
+
+// SetClientSecret sets the value of ClientSecret.
+func (s *RevocationRequestBody) SetClientSecret(val OptString) {
+	s.ClientSecret = val
+}
+
+// SetClientAssertionType sets the value of ClientAssertionType.
+func (s *RevocationRequestBody) SetClientAssertionType(val OptString) {
+	s.ClientAssertionType = val
+}
+
+// SetClientAssertion sets the value of ClientAssertion.
+func (s *RevocationRequestBody) SetClientAssertion(val OptString) {
+	s.ClientAssertion = val
+}
+
+// RevokePostOK is response for RevokePost operation.
+type RevokePostOK struct{}
+
+func (*RevokePostOK) revokePostRes() {}
+
 type TokenPostApplicationJSON TokenRequestBody
 
 func (*TokenPostApplicationJSON) tokenPostReq() {}
@@ -503,18 +2137,33 @@ type TokenPostApplicationXWwwFormUrlencoded TokenRequestBody
 
 func (*TokenPostApplicationXWwwFormUrlencoded) tokenPostReq() {}
 
-type TokenPostBadRequestApplicationJSON string
+type TokenPostBadRequest OAuthErrorHeaders
+
+func (*TokenPostBadRequest) tokenPostRes() {}
 
-func (*TokenPostBadRequestApplicationJSON) tokenPostRes() {}
+type TokenPostUnauthorized OAuthErrorHeaders
+
+func (*TokenPostUnauthorized) tokenPostRes() {}
 
 // Ref: #/components/schemas/TokenRequestBody
 type TokenRequestBody struct {
-	Code         OptString `json:"code"`
-	RefreshToken OptString `json:"refresh_token"`
-	GrantType    OptString `json:"grant_type"`
-	ClientID     OptString `json:"client_id"`
-	RedirectURI  OptString `json:"redirect_uri"`
-	Scope        OptString `json:"scope"`
+	Code                OptString `json:"code"`
+	RefreshToken        OptString `json:"refresh_token"`
+	GrantType           OptString `json:"grant_type"`
+	ClientID            OptString `json:"client_id"`
+	ClientSecret        OptString `json:"client_secret"`
+	RedirectURI         OptString `json:"redirect_uri"`
+	Scope               OptString `json:"scope"`
+	CodeVerifier        OptString `json:"code_verifier"`
+	DeviceCode          OptString `json:"device_code"`
+	ClientAssertionType OptString `json:"client_assertion_type"`
+	ClientAssertion     OptString `json:"client_assertion"`
+	SubjectToken        OptString `json:"subject_token"`
+	SubjectTokenType    OptString `json:"subject_token_type"`
+	ActorToken          OptString `json:"actor_token"`
+	ActorTokenType      OptString `json:"actor_token_type"`
+	RequestedTokenType  OptString `json:"requested_token_type"`
+	Audience            OptString `json:"audience"`
 }
 
 // GetCode returns the value of Code.
@@ -537,6 +2186,11 @@ func (s *TokenRequestBody) GetClientID() OptString {
 	return s.ClientID
 }
 
+// GetClientSecret returns the value of ClientSecret.
+func (s *TokenRequestBody) GetClientSecret() OptString {
+	return s.ClientSecret
+}
+
 // GetRedirectURI returns the value of RedirectURI.
 func (s *TokenRequestBody) GetRedirectURI() OptString {
 	return s.RedirectURI
@@ -547,6 +2201,56 @@ func (s *TokenRequestBody) GetScope() OptString {
 	return s.Scope
 }
 
+// GetCodeVerifier returns the value of CodeVerifier.
+func (s *TokenRequestBody) GetCodeVerifier() OptString {
+	return s.CodeVerifier
+}
+
+// GetDeviceCode returns the value of DeviceCode.
+func (s *TokenRequestBody) GetDeviceCode() OptString {
+	return s.DeviceCode
+}
+
+// GetClientAssertionType returns the value of ClientAssertionType.
+func (s *TokenRequestBody) GetClientAssertionType() OptString {
+	return s.ClientAssertionType
+}
+
+// GetClientAssertion returns the value of ClientAssertion.
+func (s *TokenRequestBody) GetClientAssertion() OptString {
+	return s.ClientAssertion
+}
+
+// GetSubjectToken returns the value of SubjectToken.
+func (s *TokenRequestBody) GetSubjectToken() OptString {
+	return s.SubjectToken
+}
+
+// GetSubjectTokenType returns the value of SubjectTokenType.
+func (s *TokenRequestBody) GetSubjectTokenType() OptString {
+	return s.SubjectTokenType
+}
+
+// GetActorToken returns the value of ActorToken.
+func (s *TokenRequestBody) GetActorToken() OptString {
+	return s.ActorToken
+}
+
+// GetActorTokenType returns the value of ActorTokenType.
+func (s *TokenRequestBody) GetActorTokenType() OptString {
+	return s.ActorTokenType
+}
+
+// GetRequestedTokenType returns the value of RequestedTokenType.
+func (s *TokenRequestBody) GetRequestedTokenType() OptString {
+	return s.RequestedTokenType
+}
+
+// GetAudience returns the value of Audience.
+func (s *TokenRequestBody) GetAudience() OptString {
+	return s.Audience
+}
+
 // SetCode sets the value of Code.
 func (s *TokenRequestBody) SetCode(val OptString) {
 	s.Code = val
@@ -567,6 +2271,11 @@ func (s *TokenRequestBody) SetClientID(val OptString) {
 	s.ClientID = val
 }
 
+// SetClientSecret sets the value of ClientSecret.
+func (s *TokenRequestBody) SetClientSecret(val OptString) {
+	s.ClientSecret = val
+}
+
 // SetRedirectURI sets the value of RedirectURI.
 func (s *TokenRequestBody) SetRedirectURI(val OptString) {
 	s.RedirectURI = val
@@ -577,6 +2286,56 @@ func (s *TokenRequestBody) SetScope(val OptString) {
 	s.Scope = val
 }
 
+// SetCodeVerifier sets the value of CodeVerifier.
+func (s *TokenRequestBody) SetCodeVerifier(val OptString) {
+	s.CodeVerifier = val
+}
+
+// SetDeviceCode sets the value of DeviceCode.
+func (s *TokenRequestBody) SetDeviceCode(val OptString) {
+	s.DeviceCode = val
+}
+
+// SetClientAssertionType sets the value of ClientAssertionType.
+func (s *TokenRequestBody) SetClientAssertionType(val OptString) {
+	s.ClientAssertionType = val
+}
+
+// SetClientAssertion sets the value of ClientAssertion.
+func (s *TokenRequestBody) SetClientAssertion(val OptString) {
+	s.ClientAssertion = val
+}
+
+// SetSubjectToken sets the value of SubjectToken.
+func (s *TokenRequestBody) SetSubjectToken(val OptString) {
+	s.SubjectToken = val
+}
+
+// SetSubjectTokenType sets the value of SubjectTokenType.
+func (s *TokenRequestBody) SetSubjectTokenType(val OptString) {
+	s.SubjectTokenType = val
+}
+
+// SetActorToken sets the value of ActorToken.
+func (s *TokenRequestBody) SetActorToken(val OptString) {
+	s.ActorToken = val
+}
+
+// SetActorTokenType sets the value of ActorTokenType.
+func (s *TokenRequestBody) SetActorTokenType(val OptString) {
+	s.ActorTokenType = val
+}
+
+// SetRequestedTokenType sets the value of RequestedTokenType.
+func (s *TokenRequestBody) SetRequestedTokenType(val OptString) {
+	s.RequestedTokenType = val
+}
+
+// SetAudience sets the value of Audience.
+func (s *TokenRequestBody) SetAudience(val OptString) {
+	s.Audience = val
+}
+
 // Ref: #/components/schemas/UserInfo
 type UserInfo struct {
 	Sub                 string    `json:"sub"`
@@ -585,6 +2344,14 @@ type UserInfo struct {
 	PhoneNumber         OptString `json:"phone_number"`
 	PhoneNumberVerified OptBool   `json:"phone_number_verified"`
 	Username            OptString `json:"username"`
+	PreferredUsername   OptString `json:"preferred_username"`
+	Name                OptString `json:"name"`
+	GivenName           OptString `json:"given_name"`
+	FamilyName          OptString `json:"family_name"`
+	Picture             OptString `json:"picture"`
+	Locale              OptString `json:"locale"`
+	Zoneinfo            OptString `json:"zoneinfo"`
+	UpdatedAt           OptInt64  `json:"updated_at"`
 }
 
 // GetSub returns the value of Sub.
@@ -617,6 +2384,46 @@ func (s *UserInfo) GetUsername() OptString {
 	return s.Username
 }
 
+// GetPreferredUsername returns the value of PreferredUsername.
+func (s *UserInfo) GetPreferredUsername() OptString {
+	return s.PreferredUsername
+}
+
+// GetName returns the value of Name.
+func (s *UserInfo) GetName() OptString {
+	return s.Name
+}
+
+// GetGivenName returns the value of GivenName.
+func (s *UserInfo) GetGivenName() OptString {
+	return s.GivenName
+}
+
+// GetFamilyName returns the value of FamilyName.
+func (s *UserInfo) GetFamilyName() OptString {
+	return s.FamilyName
+}
+
+// GetPicture returns the value of Picture.
+func (s *UserInfo) GetPicture() OptString {
+	return s.Picture
+}
+
+// GetLocale returns the value of Locale.
+func (s *UserInfo) GetLocale() OptString {
+	return s.Locale
+}
+
+// GetZoneinfo returns the value of Zoneinfo.
+func (s *UserInfo) GetZoneinfo() OptString {
+	return s.Zoneinfo
+}
+
+// GetUpdatedAt returns the value of UpdatedAt.
+func (s *UserInfo) GetUpdatedAt() OptInt64 {
+	return s.UpdatedAt
+}
+
 // SetSub sets the value of Sub.
 func (s *UserInfo) SetSub(val string) {
 	s.Sub = val
@@ -646,3 +2453,45 @@ func (s *UserInfo) SetPhoneNumberVerified(val OptBool) {
 func (s *UserInfo) SetUsername(val OptString) {
 	s.Username = val
 }
+
+// SetPreferredUsername sets the value of PreferredUsername.
+func (s *UserInfo) SetPreferredUsername(val OptString) {
+	s.PreferredUsername = val
+}
+
+// SetName sets the value of Name.
+func (s *UserInfo) SetName(val OptString) {
+	s.Name = val
+}
+
+// SetGivenName sets the value of GivenName.
+func (s *UserInfo) SetGivenName(val OptString) {
+	s.GivenName = val
+}
+
+// SetFamilyName sets the value of FamilyName.
+func (s *UserInfo) SetFamilyName(val OptString) {
+	s.FamilyName = val
+}
+
+// SetPicture sets the value of Picture.
+func (s *UserInfo) SetPicture(val OptString) {
+	s.Picture = val
+}
+
+// SetLocale sets the value of Locale.
+func (s *UserInfo) SetLocale(val OptString) {
+	s.Locale = val
+}
+
+// SetZoneinfo sets the value of Zoneinfo.
+func (s *UserInfo) SetZoneinfo(val OptString) {
+	s.Zoneinfo = val
+}
+
+// SetUpdatedAt sets the value of UpdatedAt.
+func (s *UserInfo) SetUpdatedAt(val OptInt64) {
+	s.UpdatedAt = val
+}
+
+func (*UserInfo) userinfoGetRes() {}
@@ -14,8 +14,14 @@ import (
 
 // SecurityHandler is handler for security parameters.
 type SecurityHandler interface {
+	// HandleBasicAuth handles BasicAuth security.
+	HandleBasicAuth(ctx context.Context, operationName OperationName, t BasicAuth) (context.Context, error)
 	// HandleBearerAuth handles BearerAuth security.
 	HandleBearerAuth(ctx context.Context, operationName OperationName, t BearerAuth) (context.Context, error)
+	// HandleDPoPAuth handles DPoPAuth security.
+	HandleDPoPAuth(ctx context.Context, operationName OperationName, t DPoPAuth) (context.Context, error)
+	// HandleDPoPProof handles DPoPProof security.
+	HandleDPoPProof(ctx context.Context, operationName OperationName, t DPoPProof) (context.Context, error)
 	// HandleLoginCookie handles LoginCookie security.
 	HandleLoginCookie(ctx context.Context, operationName OperationName, t LoginCookie) (context.Context, error)
 }
@@ -35,6 +41,25 @@ func findAuthorization(h http.Header, prefix string) (string, bool) {
 	return "", false
 }
 
+func (s *Server) securityBasicAuth(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
+	var t BasicAuth
+	if _, ok := findAuthorization(req.Header, "Basic"); !ok {
+		return ctx, false, nil
+	}
+	username, password, ok := req.BasicAuth()
+	if !ok {
+		return nil, false, errors.New("invalid basic auth")
+	}
+	t.Username = username
+	t.Password = password
+	rctx, err := s.sec.HandleBasicAuth(ctx, operationName, t)
+	if errors.Is(err, ogenerrors.ErrSkipServerSecurity) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return rctx, true, err
+}
 func (s *Server) securityBearerAuth(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
 	var t BearerAuth
 	token, ok := findAuthorization(req.Header, "Bearer")
@@ -50,6 +75,38 @@ func (s *Server) securityBearerAuth(ctx context.Context, operationName Operation
 	}
 	return rctx, true, err
 }
+func (s *Server) securityDPoPAuth(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
+	var t DPoPAuth
+	const parameterName = "Authorization"
+	value := req.Header.Get(parameterName)
+	if value == "" {
+		return ctx, false, nil
+	}
+	t.APIKey = value
+	rctx, err := s.sec.HandleDPoPAuth(ctx, operationName, t)
+	if errors.Is(err, ogenerrors.ErrSkipServerSecurity) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return rctx, true, err
+}
+func (s *Server) securityDPoPProof(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
+	var t DPoPProof
+	const parameterName = "DPoP"
+	value := req.Header.Get(parameterName)
+	if value == "" {
+		return ctx, false, nil
+	}
+	t.APIKey = value
+	rctx, err := s.sec.HandleDPoPProof(ctx, operationName, t)
+	if errors.Is(err, ogenerrors.ErrSkipServerSecurity) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return rctx, true, err
+}
 func (s *Server) securityLoginCookie(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
 	var t LoginCookie
 	const parameterName = "SOIDC_AUTH"
@@ -74,12 +131,26 @@ func (s *Server) securityLoginCookie(ctx context.Context, operationName Operatio
 
 // SecuritySource is provider of security values (tokens, passwords, etc.).
 type SecuritySource interface {
+	// BasicAuth provides BasicAuth security value.
+	BasicAuth(ctx context.Context, operationName OperationName) (BasicAuth, error)
 	// BearerAuth provides BearerAuth security value.
 	BearerAuth(ctx context.Context, operationName OperationName) (BearerAuth, error)
+	// DPoPAuth provides DPoPAuth security value.
+	DPoPAuth(ctx context.Context, operationName OperationName) (DPoPAuth, error)
+	// DPoPProof provides DPoPProof security value.
+	DPoPProof(ctx context.Context, operationName OperationName) (DPoPProof, error)
 	// LoginCookie provides LoginCookie security value.
 	LoginCookie(ctx context.Context, operationName OperationName) (LoginCookie, error)
 }
 
+func (s *Client) securityBasicAuth(ctx context.Context, operationName OperationName, req *http.Request) error {
+	t, err := s.sec.BasicAuth(ctx, operationName)
+	if err != nil {
+		return errors.Wrap(err, "security source \"BasicAuth\"")
+	}
+	req.SetBasicAuth(t.Username, t.Password)
+	return nil
+}
 func (s *Client) securityBearerAuth(ctx context.Context, operationName OperationName, req *http.Request) error {
 	t, err := s.sec.BearerAuth(ctx, operationName)
 	if err != nil {
@@ -88,6 +159,22 @@ func (s *Client) securityBearerAuth(ctx context.Context, operationName Operation
 	req.Header.Set("Authorization", "Bearer "+t.Token)
 	return nil
 }
+func (s *Client) securityDPoPAuth(ctx context.Context, operationName OperationName, req *http.Request) error {
+	t, err := s.sec.DPoPAuth(ctx, operationName)
+	if err != nil {
+		return errors.Wrap(err, "security source \"DPoPAuth\"")
+	}
+	req.Header.Set("Authorization", t.APIKey)
+	return nil
+}
+func (s *Client) securityDPoPProof(ctx context.Context, operationName OperationName, req *http.Request) error {
+	t, err := s.sec.DPoPProof(ctx, operationName)
+	if err != nil {
+		return errors.Wrap(err, "security source \"DPoPProof\"")
+	}
+	req.Header.Set("DPoP", t.APIKey)
+	return nil
+}
 func (s *Client) securityLoginCookie(ctx context.Context, operationName OperationName, req *http.Request) error {
 	t, err := s.sec.LoginCookie(ctx, operationName)
 	if err != nil {
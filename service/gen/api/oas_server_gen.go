@@ -9,12 +9,17 @@ import (
 // Handler handles operations described by OpenAPI v3 specification.
 type Handler interface {
 	AuthorizationHandler
+	DeviceAuthorizationHandler
+	IntrospectionHandler
+	PushedAuthorizationHandler
+	RegistrationHandler
+	RevocationHandler
 	UserInfoHandler
 	WellKnownHandler
-	// NewError creates *ErrRespStatusCode from error returned by handler.
+	// NewError creates *ErrorResponseStatusCode from error returned by handler.
 	//
 	// Used for common default response.
-	NewError(ctx context.Context, err error) *ErrRespStatusCode
+	NewError(ctx context.Context, err error) *ErrorResponseStatusCode
 }
 
 // AuthorizationHandler handles operations described by OpenAPI v3 specification.
@@ -35,6 +40,84 @@ type AuthorizationHandler interface {
 	TokenPost(ctx context.Context, req TokenPostReq) (TokenPostRes, error)
 }
 
+// DeviceAuthorizationHandler handles operations described by OpenAPI v3 specification.
+//
+// x-ogen-operation-group: DeviceAuthorization
+type DeviceAuthorizationHandler interface {
+	// DeviceAuthorizationPost implements POST /device_authorization operation.
+	//
+	// Device Authorization Endpoint (RFC 8628).
+	//
+	// POST /device_authorization
+	DeviceAuthorizationPost(ctx context.Context, req *DeviceAuthorizationRequestBody) (DeviceAuthorizationPostRes, error)
+}
+
+// IntrospectionHandler handles operations described by OpenAPI v3 specification.
+//
+// x-ogen-operation-group: Introspection
+type IntrospectionHandler interface {
+	// IntrospectPost implements POST /introspect operation.
+	//
+	// Token Introspection Endpoint (RFC 7662).
+	//
+	// POST /introspect
+	IntrospectPost(ctx context.Context, req *IntrospectionRequestBody) (IntrospectPostRes, error)
+}
+
+// PushedAuthorizationHandler handles operations described by OpenAPI v3 specification.
+//
+// x-ogen-operation-group: PushedAuthorization
+type PushedAuthorizationHandler interface {
+	// ParPost implements POST /par operation.
+	//
+	// Pushed Authorization Request Endpoint (RFC 9126).
+	//
+	// POST /par
+	ParPost(ctx context.Context, req *PushedAuthorizationRequestBody) (ParPostRes, error)
+}
+
+// RegistrationHandler handles operations described by OpenAPI v3 specification.
+//
+// x-ogen-operation-group: Registration
+type RegistrationHandler interface {
+	// DeleteClientRegistration implements deleteClientRegistration operation.
+	//
+	// Deregister a client (RFC 7592).
+	//
+	// DELETE /connect/register/{client_id}
+	DeleteClientRegistration(ctx context.Context, params DeleteClientRegistrationParams) (DeleteClientRegistrationRes, error)
+	// GetClientRegistration implements getClientRegistration operation.
+	//
+	// Read a registered client (RFC 7592).
+	//
+	// GET /connect/register/{client_id}
+	GetClientRegistration(ctx context.Context, params GetClientRegistrationParams) (GetClientRegistrationRes, error)
+	// RegisterClient implements registerClient operation.
+	//
+	// Dynamic Client Registration Endpoint (RFC 7591).
+	//
+	// POST /connect/register
+	RegisterClient(ctx context.Context, req *ClientMetadata) (RegisterClientRes, error)
+	// UpdateClientRegistration implements updateClientRegistration operation.
+	//
+	// Update a registered client (RFC 7592).
+	//
+	// PUT /connect/register/{client_id}
+	UpdateClientRegistration(ctx context.Context, req *ClientMetadata, params UpdateClientRegistrationParams) (UpdateClientRegistrationRes, error)
+}
+
+// RevocationHandler handles operations described by OpenAPI v3 specification.
+//
+// x-ogen-operation-group: Revocation
+type RevocationHandler interface {
+	// RevokePost implements POST /revoke operation.
+	//
+	// Token Revocation Endpoint (RFC 7009).
+	//
+	// POST /revoke
+	RevokePost(ctx context.Context, req *RevocationRequestBody) (RevokePostRes, error)
+}
+
 // UserInfoHandler handles operations described by OpenAPI v3 specification.
 //
 // x-ogen-operation-group: UserInfo
@@ -44,7 +127,7 @@ type UserInfoHandler interface {
 	// Get user information.
 	//
 	// GET /userinfo
-	UserinfoGet(ctx context.Context) (*UserInfo, error)
+	UserinfoGet(ctx context.Context) (UserinfoGetRes, error)
 }
 
 // WellKnownHandler handles operations described by OpenAPI v3 specification.
@@ -22,6 +22,42 @@ func (UnimplementedHandler) AuthorizeGet(ctx context.Context, params AuthorizeGe
 	return r, ht.ErrNotImplemented
 }
 
+// DeleteClientRegistration implements deleteClientRegistration operation.
+//
+// Deregister a client (RFC 7592).
+//
+// DELETE /connect/register/{client_id}
+func (UnimplementedHandler) DeleteClientRegistration(ctx context.Context, params DeleteClientRegistrationParams) (r DeleteClientRegistrationRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// DeviceAuthorizationPost implements POST /device_authorization operation.
+//
+// Device Authorization Endpoint (RFC 8628).
+//
+// POST /device_authorization
+func (UnimplementedHandler) DeviceAuthorizationPost(ctx context.Context, req *DeviceAuthorizationRequestBody) (r DeviceAuthorizationPostRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetClientRegistration implements getClientRegistration operation.
+//
+// Read a registered client (RFC 7592).
+//
+// GET /connect/register/{client_id}
+func (UnimplementedHandler) GetClientRegistration(ctx context.Context, params GetClientRegistrationParams) (r GetClientRegistrationRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// IntrospectPost implements POST /introspect operation.
+//
+// Token Introspection Endpoint (RFC 7662).
+//
+// POST /introspect
+func (UnimplementedHandler) IntrospectPost(ctx context.Context, req *IntrospectionRequestBody) (r IntrospectPostRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // Jwks implements jwks operation.
 //
 // Json Web Keyset.
@@ -40,6 +76,33 @@ func (UnimplementedHandler) OpenIdConfiguration(ctx context.Context) (r *OpenIDP
 	return r, ht.ErrNotImplemented
 }
 
+// ParPost implements POST /par operation.
+//
+// Pushed Authorization Request Endpoint (RFC 9126).
+//
+// POST /par
+func (UnimplementedHandler) ParPost(ctx context.Context, req *PushedAuthorizationRequestBody) (r ParPostRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// RegisterClient implements registerClient operation.
+//
+// Dynamic Client Registration Endpoint (RFC 7591).
+//
+// POST /connect/register
+func (UnimplementedHandler) RegisterClient(ctx context.Context, req *ClientMetadata) (r RegisterClientRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// RevokePost implements POST /revoke operation.
+//
+// Token Revocation Endpoint (RFC 7009).
+//
+// POST /revoke
+func (UnimplementedHandler) RevokePost(ctx context.Context, req *RevocationRequestBody) (r RevokePostRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // TokenPost implements POST /token operation.
 //
 // Token Exchange Endpoint.
@@ -49,19 +112,28 @@ func (UnimplementedHandler) TokenPost(ctx context.Context, req TokenPostReq) (r
 	return r, ht.ErrNotImplemented
 }
 
+// UpdateClientRegistration implements updateClientRegistration operation.
+//
+// Update a registered client (RFC 7592).
+//
+// PUT /connect/register/{client_id}
+func (UnimplementedHandler) UpdateClientRegistration(ctx context.Context, req *ClientMetadata, params UpdateClientRegistrationParams) (r UpdateClientRegistrationRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // UserinfoGet implements GET /userinfo operation.
 //
 // Get user information.
 //
 // GET /userinfo
-func (UnimplementedHandler) UserinfoGet(ctx context.Context) (r *UserInfo, _ error) {
+func (UnimplementedHandler) UserinfoGet(ctx context.Context) (r UserinfoGetRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
-// NewError creates *ErrRespStatusCode from error returned by handler.
+// NewError creates *ErrorResponseStatusCode from error returned by handler.
 //
 // Used for common default response.
-func (UnimplementedHandler) NewError(ctx context.Context, err error) (r *ErrRespStatusCode) {
-	r = new(ErrRespStatusCode)
+func (UnimplementedHandler) NewError(ctx context.Context, err error) (r *ErrorResponseStatusCode) {
+	r = new(ErrorResponseStatusCode)
 	return r
 }
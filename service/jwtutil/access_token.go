@@ -0,0 +1,99 @@
+package jwtutil
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	cjwt "github.com/cristalhq/jwt/v5"
+	"github.com/kncept-oauth/simple-oidc/service/keys"
+)
+
+// JWT Profile for OAuth 2.0 Access Tokens
+// see https://datatracker.ietf.org/doc/html/rfc9068
+const AccessTokenType = "at+jwt"
+
+// JtiStore namespace for revoked access tokens, recorded until the token expires
+const RevokedAccessTokenNamespace = "revoked-access-token"
+
+type AccessToken struct {
+	Iss      string        `json:"iss"`
+	Sub      string        `json:"sub"`
+	Aud      cjwt.Audience `json:"aud"` // the resource servers (APIs) the token is intended for
+	Exp      int64         `json:"exp"`
+	Iat      int64         `json:"iat"`
+	Jti      string        `json:"jti"`
+	ClientId string        `json:"client_id"`
+	Scope    string        `json:"scope,omitempty"`
+	AuthTime int64         `json:"auth_time,omitempty"`
+	Sid      string        `json:"sid,omitempty"`
+	Cnf      *Confirmation `json:"cnf,omitempty"` // only for sender constrained (DPoP) tokens
+	Act      *ActorClaim   `json:"act,omitempty"` // only for delegated (token exchange) tokens
+}
+
+// the key the token is bound to
+// see https://datatracker.ietf.org/doc/html/rfc9449#section-6.1
+type Confirmation struct {
+	Jkt string `json:"jkt"` // JWK SHA-256 thumbprint
+}
+
+// nil unless the token is bound to a key
+func NewConfirmation(jkt string) *Confirmation {
+	if jkt == "" {
+		return nil
+	}
+	return &Confirmation{
+		Jkt: jkt,
+	}
+}
+
+// the thumbprint of the key the token is bound to, if any
+func (jwt AccessToken) BoundJkt() string {
+	if jwt.Cnf == nil {
+		return ""
+	}
+	return jwt.Cnf.Jkt
+}
+
+func (jwt AccessToken) Verify(issuer string) error {
+	if jwt.Iss != issuer {
+		return fmt.Errorf("Issuer")
+	}
+	return jwt.VerifyDateClaimsAsOf(time.Now())
+}
+
+func (jwt AccessToken) VerifyDateClaimsAsOf(now time.Time) error {
+	nowUnix := now.Unix()
+	if jwt.Iat > nowUnix {
+		return fmt.Errorf("Issued At")
+	}
+	if jwt.Exp < nowUnix {
+		return fmt.Errorf("Expired")
+	}
+	return nil
+}
+
+func (jwt AccessToken) HasAudience(audience string) bool {
+	return slices.Contains(jwt.Aud, audience)
+}
+
+// the typ header MUST be checked, so that an id token can not be used as an access token
+// see https://datatracker.ietf.org/doc/html/rfc9068#section-4
+func ParseAccessToken(ctx context.Context, jwt string, keySource keys.Keystore, issuer string, audience string) (*AccessToken, error) {
+	if jwt == "" {
+		return nil, nil
+	}
+	if !JwtHasType(jwt, AccessTokenType) {
+		return nil, fmt.Errorf("not an access token")
+	}
+	claims := &AccessToken{}
+	err := ParseJwt(ctx, jwt, keySource, issuer, claims)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.HasAudience(audience) {
+		return nil, fmt.Errorf("Audience")
+	}
+	return claims, nil
+}
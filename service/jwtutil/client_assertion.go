@@ -0,0 +1,126 @@
+package jwtutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"fmt"
+	"slices"
+	"time"
+
+	cjwt "github.com/cristalhq/jwt/v5"
+	"github.com/kncept-oauth/simple-oidc/service/keys"
+)
+
+// see https://datatracker.ietf.org/doc/html/rfc7523#section-2.2
+const ClientAssertionTypeJwtBearer = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// asymmetric algorithms only. 'none' and HMAC (HS*) are always rejected
+var SupportedClientAssertionSigningAlgs = []string{
+	cjwt.RS256.String(), cjwt.RS384.String(), cjwt.RS512.String(),
+	cjwt.PS256.String(), cjwt.PS384.String(), cjwt.PS512.String(),
+	cjwt.ES256.String(), cjwt.ES384.String(), cjwt.ES512.String(),
+}
+
+// HMAC algorithms, keyed with the client's shared secret (client_secret_jwt only)
+var SupportedClientSecretJwtSigningAlgs = []string{
+	cjwt.HS256.String(), cjwt.HS384.String(), cjwt.HS512.String(),
+}
+
+// selects a verifier for the (untrusted) alg header, matched against the public key type
+func VerifierForJwk(alg cjwt.Algorithm, jwk *keys.JwkDetails) (cjwt.Verifier, error) {
+	if !slices.Contains(SupportedClientAssertionSigningAlgs, alg.String()) {
+		return nil, fmt.Errorf("unsupported signing alg: %v", alg)
+	}
+	publicKey, err := jwk.ToPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		switch alg {
+		case cjwt.RS256, cjwt.RS384, cjwt.RS512:
+			return cjwt.NewVerifierRS(alg, key)
+		case cjwt.PS256, cjwt.PS384, cjwt.PS512:
+			return cjwt.NewVerifierPS(alg, key)
+		}
+	case *ecdsa.PublicKey:
+		switch alg {
+		case cjwt.ES256, cjwt.ES384, cjwt.ES512:
+			return cjwt.NewVerifierES(alg, key)
+		}
+	}
+	return nil, fmt.Errorf("signing alg %v does not match key type %v", alg, jwk.Kty)
+}
+
+// verifies the signature against a key from the (externally supplied) key set
+func JwtToClaimsWithJwks(jwt string, jwks *keys.JwkSet, dst any) error {
+	token, err := cjwt.ParseNoVerify([]byte(jwt))
+	if err != nil {
+		return err
+	}
+	jwk := jwks.FindKey(token.Header().KeyID)
+	if jwk == nil {
+		return fmt.Errorf("no matching key for kid %v", token.Header().KeyID)
+	}
+	verifier, err := VerifierForJwk(token.Header().Algorithm, jwk)
+	if err != nil {
+		return err
+	}
+	return cjwt.ParseClaims([]byte(jwt), verifier, dst)
+}
+
+// verifies an HMAC signature, keyed with the octets of the shared secret
+// see https://openid.net/specs/openid-connect-core-1_0.html#ClientAuthentication
+func JwtToClaimsWithHmacKey(jwt string, key string, dst any) error {
+	token, err := cjwt.ParseNoVerify([]byte(jwt))
+	if err != nil {
+		return err
+	}
+	alg := token.Header().Algorithm
+	if !slices.Contains(SupportedClientSecretJwtSigningAlgs, alg.String()) {
+		return fmt.Errorf("unsupported signing alg: %v", alg)
+	}
+	verifier, err := cjwt.NewVerifierHS(alg, []byte(key))
+	if err != nil {
+		return err
+	}
+	return cjwt.ParseClaims([]byte(jwt), verifier, dst)
+}
+
+// see https://datatracker.ietf.org/doc/html/rfc7523#section-3
+type ClientAssertionClaims struct {
+	Iss string        `json:"iss"`
+	Sub string        `json:"sub"`
+	Aud cjwt.Audience `json:"aud"`
+	Exp int64         `json:"exp"`
+	Iat int64         `json:"iat,omitempty"`
+	Nbf int64         `json:"nbf,omitempty"`
+	Jti string        `json:"jti"`
+}
+
+// the audience must be one of the acceptable values (eg: the issuer, or the token endpoint)
+func (jwt ClientAssertionClaims) Verify(clientId string, acceptableAudiences ...string) error {
+	return jwt.VerifyAsOf(time.Now(), clientId, acceptableAudiences...)
+}
+
+func (jwt ClientAssertionClaims) VerifyAsOf(now time.Time, clientId string, acceptableAudiences ...string) error {
+	if clientId == "" || jwt.Iss != clientId || jwt.Sub != clientId {
+		return fmt.Errorf("Issuer")
+	}
+	if !slices.ContainsFunc(jwt.Aud, func(aud string) bool {
+		return slices.Contains(acceptableAudiences, aud)
+	}) {
+		return fmt.Errorf("Audience")
+	}
+	if jwt.Jti == "" {
+		return fmt.Errorf("Jti")
+	}
+	nowUnix := now.Unix()
+	if jwt.Exp == 0 || jwt.Exp < nowUnix {
+		return fmt.Errorf("Expired")
+	}
+	if jwt.Nbf > nowUnix {
+		return fmt.Errorf("Not Before")
+	}
+	return nil
+}
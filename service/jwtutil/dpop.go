@@ -0,0 +1,172 @@
+package jwtutil
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	cjwt "github.com/cristalhq/jwt/v5"
+	"github.com/kncept-oauth/simple-oidc/service/keys"
+)
+
+// OAuth 2.0 Demonstrating Proof of Possession
+// see https://datatracker.ietf.org/doc/html/rfc9449
+const DPoPProofType = "dpop+jwt"
+
+// the token_type of a DPoP bound access token
+const DPoPTokenType = "DPoP"
+
+// how far the proof iat may be from the server time (in either direction)
+const DPoPProofWindow = 60 * time.Second
+
+// prefix for the HMAC of server provided nonces, which aren't stored
+const DPoPNonceNamespace = "dpop-nonce"
+
+// JtiStore namespace for used proofs, per key. eg: dpop-proof:<jkt>
+const DPoPProofNamespace = "dpop-proof"
+
+// see https://datatracker.ietf.org/doc/html/rfc9449#section-4.2
+type DPoPProofClaims struct {
+	Jti   string `json:"jti"`
+	Htm   string `json:"htm"` // http method
+	Htu   string `json:"htu"` // http uri, without query or fragment
+	Iat   int64  `json:"iat"`
+	Ath   string `json:"ath,omitempty"` // access token hash, when presenting an access token
+	Nonce string `json:"nonce,omitempty"`
+}
+
+// a proof with a valid signature from the public key in its own header
+type DPoPProof struct {
+	Claims DPoPProofClaims
+	Jwk    *keys.JwkDetails
+	Jkt    string // thumbprint of Jwk
+}
+
+type dpopProofHeader struct {
+	Typ string                     `json:"typ"`
+	Alg string                     `json:"alg"`
+	Jwk map[string]json.RawMessage `json:"jwk"`
+}
+
+// verifies the signature against the embedded public key.
+// the claims are NOT checked, see DPoPProof.Verify
+func ParseDPoPProof(proof string) (*DPoPProof, error) {
+	encodedHeader, _, found := strings.Cut(proof, ".")
+	if !found {
+		return nil, fmt.Errorf("malformed proof")
+	}
+	headerBytes, err := base64.RawURLEncoding.DecodeString(encodedHeader)
+	if err != nil {
+		return nil, err
+	}
+	header := dpopProofHeader{}
+	err = json.Unmarshal(headerBytes, &header)
+	if err != nil {
+		return nil, err
+	}
+	if header.Typ != DPoPProofType {
+		return nil, fmt.Errorf("unexpected proof type: %v", header.Typ)
+	}
+	if header.Jwk == nil {
+		return nil, fmt.Errorf("missing jwk")
+	}
+	if _, ok := header.Jwk["d"]; ok {
+		return nil, fmt.Errorf("jwk must not contain a private key")
+	}
+	jwkBytes, err := json.Marshal(header.Jwk)
+	if err != nil {
+		return nil, err
+	}
+	jwk := &keys.JwkDetails{}
+	err = json.Unmarshal(jwkBytes, jwk)
+	if err != nil {
+		return nil, err
+	}
+	// VerifierForJwk rejects 'none' and symmetric algorithms
+	verifier, err := VerifierForJwk(cjwt.Algorithm(header.Alg), jwk)
+	if err != nil {
+		return nil, err
+	}
+	parsed := &DPoPProof{
+		Jwk: jwk,
+	}
+	err = cjwt.ParseClaims([]byte(proof), verifier, &parsed.Claims)
+	if err != nil {
+		return nil, err
+	}
+	parsed.Jkt, err = jwk.Thumbprint()
+	if err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// the proof must be fresh, and for this request.
+// accessToken is only set when the proof is presented with an access token
+// see https://datatracker.ietf.org/doc/html/rfc9449#section-4.3
+func (obj *DPoPProof) Verify(htm string, htu string, accessToken string) error {
+	return obj.VerifyAsOf(time.Now(), htm, htu, accessToken)
+}
+
+func (obj *DPoPProof) VerifyAsOf(now time.Time, htm string, htu string, accessToken string) error {
+	if obj.Claims.Jti == "" {
+		return fmt.Errorf("Jti")
+	}
+	if err := obj.VerifyIssuedAtAsOf(now); err != nil {
+		return err
+	}
+	if obj.Claims.Htm != htm {
+		return fmt.Errorf("Htm")
+	}
+	if !sameHttpUri(obj.Claims.Htu, htu) {
+		return fmt.Errorf("Htu")
+	}
+	return obj.VerifyAccessToken(accessToken)
+}
+
+func (obj *DPoPProof) VerifyIssuedAtAsOf(now time.Time) error {
+	iat := time.Unix(obj.Claims.Iat, 0)
+	if obj.Claims.Iat == 0 || iat.Before(now.Add(-DPoPProofWindow)) || iat.After(now.Add(DPoPProofWindow)) {
+		return fmt.Errorf("Issued At")
+	}
+	return nil
+}
+
+func (obj *DPoPProof) VerifyAccessToken(accessToken string) error {
+	if accessToken == "" {
+		if obj.Claims.Ath != "" {
+			return fmt.Errorf("Access Token Hash")
+		}
+		return nil
+	}
+	if obj.Claims.Ath != DPoPAccessTokenHash(accessToken) {
+		return fmt.Errorf("Access Token Hash")
+	}
+	return nil
+}
+
+// the full base64url encoded SHA-256 hash of the access token
+func DPoPAccessTokenHash(accessToken string) string {
+	digest := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+// query and fragment are ignored, as is the case of the scheme and host
+// see https://datatracker.ietf.org/doc/html/rfc9449#section-4.3
+func sameHttpUri(actual string, expected string) bool {
+	actualUri, err := url.Parse(actual)
+	if err != nil {
+		return false
+	}
+	expectedUri, err := url.Parse(expected)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(actualUri.Scheme, expectedUri.Scheme) &&
+		strings.EqualFold(actualUri.Host, expectedUri.Host) &&
+		actualUri.EscapedPath() == expectedUri.EscapedPath()
+}
@@ -0,0 +1,61 @@
+package jwtutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// a previously seen JWT ID, kept until the JWT itself has expired
+type UsedJti struct {
+	Jti    string    `dynamodbav:"jti"`
+	Expiry time.Time `dynamodbav:"expiry"`
+	Ttl    int64     `dynamodbav:"ttl"` // dynamodb time to live, the expiry in epoch seconds
+}
+
+type JtiStore interface {
+	GetJti(ctx context.Context, jti string) (*UsedJti, error)
+	SaveJti(ctx context.Context, usedJti *UsedJti) error
+	// atomically saves the jti, unless there is already an unexpired record of it.
+	// returns false if the jti was already recorded
+	SaveJtiIfAbsent(ctx context.Context, usedJti *UsedJti) (bool, error)
+}
+
+func NewUsedJti(key string, expiry time.Time) *UsedJti {
+	return &UsedJti{
+		Jti:    key,
+		Expiry: expiry,
+		Ttl:    expiry.Unix(),
+	}
+}
+
+func (obj *UsedJti) IsExpired() bool {
+	return !time.Now().Before(obj.Expiry)
+}
+
+// the namespace prevents collisions between different token types and issuers
+// eg: client-assertion:<client_id>
+func RecordJti(ctx context.Context, store JtiStore, namespace string, jti string, expiry time.Time) error {
+	saved, err := store.SaveJtiIfAbsent(ctx, NewUsedJti(fmt.Sprintf("%v:%v", namespace, jti), expiry))
+	if err != nil {
+		return err
+	}
+	if !saved {
+		return fmt.Errorf("jti replay detected")
+	}
+	return nil
+}
+
+// records the jti without a replay check, eg: for a revoked token
+func SaveJti(ctx context.Context, store JtiStore, namespace string, jti string, expiry time.Time) error {
+	return store.SaveJti(ctx, NewUsedJti(fmt.Sprintf("%v:%v", namespace, jti), expiry))
+}
+
+// if the jti has been recorded, and the record has not yet expired
+func IsJtiRecorded(ctx context.Context, store JtiStore, namespace string, jti string) (bool, error) {
+	existing, err := store.GetJti(ctx, fmt.Sprintf("%v:%v", namespace, jti))
+	if err != nil {
+		return false, err
+	}
+	return existing != nil && !existing.IsExpired(), nil
+}
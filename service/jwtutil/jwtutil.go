@@ -14,10 +14,13 @@ type VerifiableClaims interface {
 	Verify(issuer string) error
 }
 
+// all simple-oidc issued tokens are signed with this algorithm
+const SigningAlgorithm = cjwt.RS512
+
 // see https://github.com/lestrrat-go/jwx
 // see https://github.com/cristalhq/jwt
 func ClaimsToJwt(claims any, keyId string, key *rsa.PrivateKey) (string, error) {
-	signer, err := cjwt.NewSignerRS(cjwt.RS512, key)
+	signer, err := cjwt.NewSignerRS(SigningAlgorithm, key)
 	if err != nil {
 		return "", err
 	}
@@ -45,7 +48,7 @@ func JwtAlgorithm(jwt string) string {
 	return token.Header().Algorithm.String()
 }
 func JwtToClaims(jwt string, key *rsa.PublicKey, dst any) error {
-	verifier, err := cjwt.NewVerifierRS(cjwt.RS512, key)
+	verifier, err := cjwt.NewVerifierRS(SigningAlgorithm, key)
 	if err != nil {
 		return err
 	}
@@ -61,10 +64,9 @@ func ParseIdToken(ctx context.Context, jwt string, keySource keys.Keystore, issu
 	return claims, nil
 }
 
-func ParseJwt(ctx context.Context, jwt string, keySource keys.Keystore, issuer string, claims VerifiableClaims) error {
-	if jwt == "" {
-		return nil
-	}
+// verifies the signature ONLY (not the issuer, or time based claims).
+// eg: a revocation request may present a token that is not yet (or no longer) valid
+func ParseSignedJwt(ctx context.Context, jwt string, keySource keys.Keystore, claims any) error {
 	token, err := cjwt.ParseNoVerify([]byte(jwt))
 	if err != nil {
 		return err
@@ -73,11 +75,21 @@ func ParseJwt(ctx context.Context, jwt string, keySource keys.Keystore, issuer s
 	if err != nil {
 		return err
 	}
+	if keyPair == nil {
+		return fmt.Errorf("unknown key id: %v", token.Header().KeyID)
+	}
 	rsaPrivateKey, err := keyPair.DecodeRsaKey()
 	if err != nil {
 		return err
 	}
-	err = JwtToClaims(jwt, &rsaPrivateKey.PublicKey, claims)
+	return JwtToClaims(jwt, &rsaPrivateKey.PublicKey, claims)
+}
+
+func ParseJwt(ctx context.Context, jwt string, keySource keys.Keystore, issuer string, claims VerifiableClaims) error {
+	if jwt == "" {
+		return nil
+	}
+	err := ParseSignedJwt(ctx, jwt, keySource, claims)
 	if err != nil {
 		return err
 	}
@@ -108,6 +120,7 @@ type AdditionalStandardClaimsIdToken struct {
 	AuthTime int64    `json:"auth_time,omitempty"` // only useful with 'max age' header. Ignore, but here cos it's in the spec
 	Nonce    string   `json:"nonce,omitempty"`
 	AtHash   string   `json:"at_hash,omitempty"`
+	CHash    string   `json:"c_hash,omitempty"`
 	ACR      string   `json:"acr,omitempty"` //  Authentication Context Class Reference
 	AMR      []string `json:"amr,omitempty"` //   Authentication Methods References
 	AZP      string   `json:"azp,omitempty"` //  Authorized third party
@@ -119,10 +132,18 @@ type AdditionalCustomClaimsIdToken struct {
 	// scopes ?
 }
 
+// the claims that may be present in an issued id token
+var SupportedIdTokenClaims = []string{
+	"iss", "sub", "aud", "exp", "iat",
+	"auth_time", "nonce", "at_hash", "c_hash", "azp",
+	"sid",
+}
+
 type IdToken struct {
 	MinimalIdToken
 	AdditionalStandardClaimsIdToken
 	AdditionalCustomClaimsIdToken
+	*StandardClaims // user profile claims, if released
 }
 
 func (jwt IdToken) Verify(issuer string) error {
@@ -150,10 +171,15 @@ func (jwt IdToken) VerifyDateClaimsAsOf(now time.Time) error {
 	return nil
 }
 
+// refresh tokens are explicitly typed, so that no other simple-oidc token (eg: an id token)
+// can be presented as one
+const RefreshTokenType = "rt+jwt"
+
 type AdditionalRefreshClaims struct {
-	Nbf  int64  `json:"nbf"`  // not before
-	Ses  string `json:"sid"`  // session ID
-	Code string `json:"code"` // SINGLE use lookup code
+	Nbf  int64         `json:"nbf"`           // not before
+	Ses  string        `json:"sid"`           // session ID
+	Code string        `json:"code"`          // SINGLE use lookup code
+	Cnf  *Confirmation `json:"cnf,omitempty"` // only for sender constrained (DPoP) sessions
 }
 
 type RefreshClaimsJwt struct {
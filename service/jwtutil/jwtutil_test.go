@@ -1,6 +1,7 @@
 package jwtutil
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"errors"
@@ -10,6 +11,7 @@ import (
 
 	cjwt "github.com/cristalhq/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/keys"
 )
 
 var _ VerifiableClaims = (*IdToken)(nil)
@@ -133,3 +135,95 @@ func TestJwtVerify(t *testing.T) {
 	jwt.Exp = now.Unix()
 
 }
+
+func TestTokenHash(t *testing.T) {
+	accessToken := "jHkWEdUXMU1BwAsC4vtUsZwnNvdSjxoTYOYfWOV06UE"
+	expected := map[cjwt.Algorithm]string{
+		cjwt.RS256: "N7_fCVhujjhtl_HiTOknxg",
+		cjwt.RS512: "tgOichfdf4PKkZr53zYFYpMwhoubx48cN7vNvBo-Y5E",
+	}
+	for alg, expectedHash := range expected {
+		hash, err := TokenHash(alg, accessToken)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		if hash != expectedHash {
+			t.Errorf("%v: expected %v but got %v", alg, expectedHash, hash)
+		}
+	}
+	if _, err := TokenHash(cjwt.Algorithm("none"), accessToken); err == nil {
+		t.Fatalf("unknown alg must be rejected")
+	}
+}
+
+type singleKeystore struct {
+	keypair *keys.JwkKeypair
+}
+
+func (obj *singleKeystore) ListKeys(ctx context.Context) ([]*keys.JwkKeypair, error) {
+	return []*keys.JwkKeypair{obj.keypair}, nil
+}
+func (obj *singleKeystore) GetKey(ctx context.Context, kid string) (*keys.JwkKeypair, error) {
+	if kid != obj.keypair.Kid {
+		return nil, nil
+	}
+	return obj.keypair, nil
+}
+func (obj *singleKeystore) SaveKey(ctx context.Context, keypair *keys.JwkKeypair) error {
+	return errors.ErrUnsupported
+}
+
+func TestParseAccessToken(t *testing.T) {
+	ctx := t.Context()
+	keypair, err := keys.GenerateJwkKeypair()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	keystore := &singleKeystore{keypair: keypair}
+	privateKey, err := keypair.DecodeRsaKey()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	now := time.Now()
+	accessToken := &AccessToken{
+		Iss:      "issuer",
+		Sub:      uuid.NewString(),
+		Aud:      []string{"issuer", "https://api"},
+		Exp:      now.Add(time.Minute).Unix(),
+		Iat:      now.Unix(),
+		Jti:      uuid.NewString(),
+		ClientId: "client",
+		Scope:    "openid",
+	}
+	accessTokenJwt, err := ClaimsToTypedJwt(accessToken, AccessTokenType, keypair.Kid, privateKey)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	parsed, err := ParseAccessToken(ctx, accessTokenJwt, keystore, "issuer", "https://api")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if parsed.Jti != accessToken.Jti || parsed.ClientId != "client" || parsed.Scope != "openid" {
+		t.Fatalf("unexpected claims: %+v", parsed)
+	}
+	if _, err = ParseAccessToken(ctx, accessTokenJwt, keystore, "issuer", "https://other-api"); err == nil {
+		t.Fatalf("access token for another audience must be rejected")
+	}
+
+	idTokenJwt, err := ClaimsToJwt(&IdToken{
+		MinimalIdToken: MinimalIdToken{
+			Iss: "issuer",
+			Sub: accessToken.Sub,
+			Aud: []string{"issuer"},
+			Exp: accessToken.Exp,
+			Iat: accessToken.Iat,
+		},
+	}, keypair.Kid, privateKey)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err = ParseAccessToken(ctx, idTokenJwt, keystore, "issuer", "issuer"); err == nil {
+		t.Fatalf("id token must not be accepted as an access token")
+	}
+}
@@ -0,0 +1,21 @@
+package jwtutil
+
+import cjwt "github.com/cristalhq/jwt/v5"
+
+// OpenID Connect Back-Channel Logout 1.0
+// see https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+const LogoutTokenType = "logout+jwt"
+
+const BackChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+// a logout token MUST NOT contain a nonce, so that it can't be mistaken for an id token
+type LogoutToken struct {
+	Iss    string                    `json:"iss"`
+	Sub    string                    `json:"sub,omitempty"`
+	Aud    cjwt.Audience             `json:"aud"`
+	Iat    int64                     `json:"iat"`
+	Exp    int64                     `json:"exp"`
+	Jti    string                    `json:"jti"`
+	Sid    string                    `json:"sid,omitempty"`
+	Events map[string]map[string]any `json:"events"`
+}
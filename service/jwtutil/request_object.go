@@ -0,0 +1,62 @@
+package jwtutil
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	cjwt "github.com/cristalhq/jwt/v5"
+)
+
+// the authorization request parameters, as a signed JWT
+// see https://datatracker.ietf.org/doc/html/rfc9101#section-4
+type RequestObjectClaims struct {
+	Iss string        `json:"iss"`
+	Aud cjwt.Audience `json:"aud"`
+	Exp int64         `json:"exp,omitempty"`
+	Iat int64         `json:"iat,omitempty"`
+	Nbf int64         `json:"nbf,omitempty"`
+	Jti string        `json:"jti,omitempty"`
+
+	ResponseType        string `json:"response_type,omitempty"`
+	ClientId            string `json:"client_id"`
+	Scope               string `json:"scope,omitempty"`
+	RedirectUri         string `json:"redirect_uri,omitempty"`
+	State               string `json:"state,omitempty"`
+	ResponseMode        string `json:"response_mode,omitempty"`
+	Nonce               string `json:"nonce,omitempty"`
+	CodeChallenge       string `json:"code_challenge,omitempty"`
+	CodeChallengeMethod string `json:"code_challenge_method,omitempty"`
+
+	// request objects must not be nested
+	Request    string `json:"request,omitempty"`
+	RequestUri string `json:"request_uri,omitempty"`
+}
+
+// the audience must be the issuer, and an expiry is required
+func (jwt RequestObjectClaims) Verify(clientId string, issuer string) error {
+	return jwt.VerifyAsOf(time.Now(), clientId, issuer)
+}
+
+func (jwt RequestObjectClaims) VerifyAsOf(now time.Time, clientId string, issuer string) error {
+	if clientId == "" || jwt.ClientId != clientId {
+		return fmt.Errorf("ClientId")
+	}
+	if jwt.Iss != clientId {
+		return fmt.Errorf("Issuer")
+	}
+	if !slices.Contains(jwt.Aud, issuer) {
+		return fmt.Errorf("Audience")
+	}
+	if jwt.Request != "" || jwt.RequestUri != "" {
+		return fmt.Errorf("Nested")
+	}
+	nowUnix := now.Unix()
+	if jwt.Exp == 0 || jwt.Exp < nowUnix {
+		return fmt.Errorf("Expired")
+	}
+	if jwt.Nbf > nowUnix {
+		return fmt.Errorf("Not Before")
+	}
+	return nil
+}
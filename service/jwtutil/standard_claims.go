@@ -0,0 +1,66 @@
+package jwtutil
+
+// the user profile claims, released according to the granted scopes
+// see https://openid.net/specs/openid-connect-core-1_0.html#StandardClaims
+type StandardClaims struct {
+	Name              string `json:"name,omitempty"`
+	GivenName         string `json:"given_name,omitempty"`
+	FamilyName        string `json:"family_name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Picture           string `json:"picture,omitempty"`
+	Zoneinfo          string `json:"zoneinfo,omitempty"`
+	Locale            string `json:"locale,omitempty"`
+
+	Email         string `json:"email,omitempty"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+
+	PhoneNumber         string `json:"phone_number,omitempty"`
+	PhoneNumberVerified *bool  `json:"phone_number_verified,omitempty"`
+
+	UpdatedAt int64 `json:"updated_at,omitempty"`
+}
+
+// only the named claims, or nil if none are released
+func (obj *StandardClaims) Released(claimNames []string) *StandardClaims {
+	released := &StandardClaims{}
+	empty := *released
+	for _, claimName := range claimNames {
+		switch claimName {
+		case "name":
+			released.Name = obj.Name
+		case "given_name":
+			released.GivenName = obj.GivenName
+		case "family_name":
+			released.FamilyName = obj.FamilyName
+		case "preferred_username":
+			released.PreferredUsername = obj.PreferredUsername
+		case "picture":
+			released.Picture = obj.Picture
+		case "zoneinfo":
+			released.Zoneinfo = obj.Zoneinfo
+		case "locale":
+			released.Locale = obj.Locale
+		case "email":
+			released.Email = obj.Email
+		case "email_verified":
+			released.EmailVerified = obj.EmailVerified
+		case "phone_number":
+			released.PhoneNumber = obj.PhoneNumber
+		case "phone_number_verified":
+			released.PhoneNumberVerified = obj.PhoneNumberVerified
+		case "updated_at":
+			released.UpdatedAt = obj.UpdatedAt
+		}
+	}
+	if *released == empty {
+		return nil
+	}
+	return released
+}
+
+// the standard claims that may be released
+var SupportedStandardClaims = []string{
+	"name", "given_name", "family_name", "preferred_username", "picture", "zoneinfo", "locale", "updated_at",
+	"email", "email_verified",
+	"phone_number", "phone_number_verified",
+}
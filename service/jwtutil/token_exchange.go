@@ -0,0 +1,64 @@
+package jwtutil
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	cjwt "github.com/cristalhq/jwt/v5"
+)
+
+// token type identifiers for subject_token_type, actor_token_type and issued_token_type
+// see https://datatracker.ietf.org/doc/html/rfc8693#section-3
+const (
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	TokenTypeJwt         = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// the party acting on behalf of the subject. prior actors in a delegation chain are nested
+// see https://datatracker.ietf.org/doc/html/rfc8693#section-4.1
+type ActorClaim struct {
+	Sub      string      `json:"sub"`
+	ClientId string      `json:"client_id,omitempty"`
+	Act      *ActorClaim `json:"act,omitempty"`
+}
+
+// a subject asserted by a client, for impersonation. signed with the client's own keys
+// see https://datatracker.ietf.org/doc/html/rfc8693#section-2.1
+type SubjectAssertionClaims struct {
+	Iss string        `json:"iss"`
+	Sub string        `json:"sub"`
+	Aud cjwt.Audience `json:"aud"`
+	Exp int64         `json:"exp"`
+	Iat int64         `json:"iat,omitempty"`
+	Nbf int64         `json:"nbf,omitempty"`
+	Jti string        `json:"jti"`
+}
+
+// the client must be the issuer, and simple-oidc the audience
+func (jwt SubjectAssertionClaims) Verify(clientId string, issuer string) error {
+	return jwt.VerifyAsOf(time.Now(), clientId, issuer)
+}
+
+func (jwt SubjectAssertionClaims) VerifyAsOf(now time.Time, clientId string, issuer string) error {
+	if clientId == "" || jwt.Iss != clientId {
+		return fmt.Errorf("Issuer")
+	}
+	if jwt.Sub == "" {
+		return fmt.Errorf("Subject")
+	}
+	if !slices.Contains(jwt.Aud, issuer) {
+		return fmt.Errorf("Audience")
+	}
+	if jwt.Jti == "" {
+		return fmt.Errorf("Jti")
+	}
+	nowUnix := now.Unix()
+	if jwt.Exp == 0 || jwt.Exp < nowUnix {
+		return fmt.Errorf("Expired")
+	}
+	if jwt.Nbf > nowUnix {
+		return fmt.Errorf("Not Before")
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package jwtutil
+
+import (
+	"crypto"
+	"encoding/base64"
+	"fmt"
+
+	cjwt "github.com/cristalhq/jwt/v5"
+)
+
+// the left-most half of the hash of the ascii value, base64url encoded.
+// the hash algorithm is the one used by the JWS alg header of the id token.
+// used for at_hash and c_hash
+// see https://openid.net/specs/openid-connect-core-1_0.html#CodeIDToken
+func TokenHash(alg cjwt.Algorithm, value string) (string, error) {
+	var hash crypto.Hash
+	switch alg {
+	case cjwt.RS256, cjwt.PS256, cjwt.ES256, cjwt.HS256:
+		hash = crypto.SHA256
+	case cjwt.RS384, cjwt.PS384, cjwt.ES384, cjwt.HS384:
+		hash = crypto.SHA384
+	case cjwt.RS512, cjwt.PS512, cjwt.ES512, cjwt.HS512:
+		hash = crypto.SHA512
+	default:
+		return "", fmt.Errorf("unsupported alg: %v", alg)
+	}
+	hasher := hash.New()
+	hasher.Write([]byte(value))
+	digest := hasher.Sum(nil)
+	return base64.RawURLEncoding.EncodeToString(digest[:len(digest)/2]), nil
+}
+
+// at_hash for the access token issued alongside this id token
+func (jwt *IdToken) SetAccessTokenHash(accessToken string) error {
+	atHash, err := TokenHash(SigningAlgorithm, accessToken)
+	if err != nil {
+		return err
+	}
+	jwt.AtHash = atHash
+	return nil
+}
+
+// c_hash for the authorization code issued alongside this id token
+func (jwt *IdToken) SetCodeHash(code string) error {
+	cHash, err := TokenHash(SigningAlgorithm, code)
+	if err != nil {
+		return err
+	}
+	jwt.CHash = cHash
+	return nil
+}
@@ -0,0 +1,49 @@
+package jwtutil
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	cjwt "github.com/cristalhq/jwt/v5"
+)
+
+// cristalhq/jwt will only build tokens with a 'typ' header of JWT,
+// so explicitly typed tokens (eg: at+jwt) are assembled here
+// see https://datatracker.ietf.org/doc/html/rfc8725#section-3.11
+func ClaimsToTypedJwt(claims any, typ string, keyId string, key *rsa.PrivateKey) (string, error) {
+	signer, err := cjwt.NewSignerRS(SigningAlgorithm, key)
+	if err != nil {
+		return "", err
+	}
+	header, err := cjwt.Header{
+		Algorithm: signer.Algorithm(),
+		Type:      typ,
+		KeyID:     keyId,
+	}.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	signature, err := signer.Sign([]byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// the typ header is compared case insensitively, and the "application/" prefix may be omitted
+// see https://datatracker.ietf.org/doc/html/rfc7515#section-4.1.9
+func JwtHasType(jwt string, typ string) bool {
+	token, err := cjwt.ParseNoVerify([]byte(jwt))
+	if err != nil {
+		return false
+	}
+	tokenType := strings.TrimPrefix(strings.ToLower(token.Header().Type), "application/")
+	return tokenType == strings.ToLower(typ)
+}
@@ -0,0 +1,94 @@
+package keys
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/kncept-oauth/simple-oidc/service/netutil"
+)
+
+// PUBLIC json web key set, as served from a jwks_uri
+type JwkSet struct {
+	Keys []*JwkDetails `json:"keys"`
+}
+
+func (obj *JwkSet) FindKey(kid string) *JwkDetails {
+	for _, key := range obj.Keys {
+		if key.Kid == kid {
+			return key
+		}
+	}
+	// a key id is optional if there is only a single key
+	if kid == "" && len(obj.Keys) == 1 {
+		return obj.Keys[0]
+	}
+	return nil
+}
+
+// no caching - keys are fetched every time
+func FetchJwks(ctx context.Context, jwksUri string) (*JwkSet, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksUri, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept", "application/json")
+	res, err := netutil.FetchClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch jwks from %v: %v", jwksUri, res.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1024*1024))
+	if err != nil {
+		return nil, err
+	}
+	jwks := &JwkSet{}
+	err = json.Unmarshal(body, jwks)
+	if err != nil {
+		return nil, err
+	}
+	return jwks, nil
+}
+
+// the base64url encoded SHA-256 hash of the required public key members, in lexicographic order
+// see https://datatracker.ietf.org/doc/html/rfc7638#section-3
+func (obj JwkDetails) Thumbprint() (string, error) {
+	var members []string
+	switch obj.Kty {
+	case "RSA":
+		members = []string{"e", obj.E, "kty", obj.Kty, "n", obj.N}
+	case "EC":
+		members = []string{"crv", obj.Crv, "kty", obj.Kty, "x", obj.X, "y", obj.Y}
+	default:
+		return "", fmt.Errorf("unable to thumbprint key type: %v", obj.Kty)
+	}
+	buf := bytes.Buffer{}
+	buf.WriteString("{")
+	for i := 0; i < len(members); i += 2 {
+		if members[i+1] == "" {
+			return "", fmt.Errorf("missing jwk member: %v", members[i])
+		}
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		name, _ := json.Marshal(members[i])
+		value, _ := json.Marshal(members[i+1])
+		buf.Write(name)
+		buf.WriteString(":")
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	digest := sha256.Sum256(buf.Bytes())
+	return base64.RawURLEncoding.EncodeToString(digest[:]), nil
+}
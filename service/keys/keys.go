@@ -64,6 +64,10 @@ type JwkKeypair struct {
 
 	Exp *time.Time `dynamodbav:"exp"` // expiry
 	Nbf *time.Time `dynamodbav:"nbf"` // not before time
+
+	// a random secret for server side HMACs (eg: DPoP nonces), rotated along with the key.
+	// so that the private key is only ever used to sign
+	NonceKey string `dynamodbav:"nonceKey"` // base64url
 }
 
 func (jwk *JwkKeypair) InDate(when time.Time) bool {
@@ -76,6 +80,34 @@ func (jwk *JwkKeypair) InDate(when time.Time) bool {
 	return true
 }
 
+// the nonce key of the current key. keys saved before there were nonce keys are given one
+func GetCurrentNonceKey(ctx context.Context, store Keystore) ([]byte, error) {
+	keyPair, err := GetCurrentKey(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	if keyPair.NonceKey == "" {
+		keyPair.NonceKey, err = newNonceKey()
+		if err != nil {
+			return nil, err
+		}
+		err = store.SaveKey(ctx, keyPair)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return base64.RawURLEncoding.DecodeString(keyPair.NonceKey)
+}
+
+func newNonceKey() (string, error) {
+	nonceKey := make([]byte, 32)
+	_, err := rand.Read(nonceKey)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(nonceKey), nil
+}
+
 func NewKeyId(prefix string) string {
 	return fmt.Sprintf("%v-%v", prefix, uuid.NewString())
 }
@@ -104,6 +136,10 @@ func GenerateJwkKeypair(asof ...time.Time) (*JwkKeypair, error) {
 	}
 	now := asof[0].UTC().Truncate(time.Second)
 	exp := now.Add(30 * 24 * time.Hour)
+	nonceKey, err := newNonceKey()
+	if err != nil {
+		return nil, err
+	}
 	keyPair := &JwkKeypair{
 		Kid: k.String(),
 		Kty: "RSA",
@@ -111,6 +147,8 @@ func GenerateJwkKeypair(asof ...time.Time) (*JwkKeypair, error) {
 
 		Nbf: &now,
 		Exp: &exp,
+
+		NonceKey: nonceKey,
 	}
 	err = keyPair.encodeKey(rsaKey)
 	if err != nil {
@@ -244,9 +282,46 @@ func (obj JwkDetails) ToPublicKey() (any, error) {
 	if obj.Kty == "RSA" {
 		return obj.ToRsaPublicKey()
 	}
+	if obj.Kty == "EC" {
+		return obj.ToEcdsaPublicKey()
+	}
 	return nil, fmt.Errorf("Unable to decode key type: %v", obj.Kty)
 }
 
+func (obj JwkDetails) ToEcdsaPublicKey() (*ecdsa.PublicKey, error) {
+	if obj.Kty != "EC" {
+		return nil, fmt.Errorf("only ec key type supported")
+	}
+	var curve elliptic.Curve
+	switch obj.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve: %v", obj.Crv)
+	}
+	xb, err := base64.RawURLEncoding.DecodeString(obj.X)
+	if err != nil {
+		return nil, err
+	}
+	yb, err := base64.RawURLEncoding.DecodeString(obj.Y)
+	if err != nil {
+		return nil, err
+	}
+	key := &ecdsa.PublicKey{
+		Curve: curve,
+		X:     big.NewInt(0).SetBytes(xb),
+		Y:     big.NewInt(0).SetBytes(yb),
+	}
+	if !curve.IsOnCurve(key.X, key.Y) {
+		return nil, fmt.Errorf("invalid ec public key")
+	}
+	return key, nil
+}
+
 func (obj JwkDetails) ToRsaPublicKey() (*rsa.PublicKey, error) {
 	if obj.Kty != "RSA" {
 		return nil, fmt.Errorf("only rsa key type supported")
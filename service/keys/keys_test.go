@@ -1,6 +1,8 @@
 package keys
 
 import (
+	"bytes"
+	"context"
 	"strings"
 	"testing"
 )
@@ -42,3 +44,83 @@ func TestCanConvertToJwksAndBack(t *testing.T) {
 		t.Fatalf("different n")
 	}
 }
+
+func TestCanConvertEcdsaToJwksAndBack(t *testing.T) {
+	key, err := GenerateEcdsaKey()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	jwk := JwkFromEcDSA(NewKeyId("test"), &key.PublicKey)
+	publicKey, err := jwk.ToEcdsaPublicKey()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !key.PublicKey.Equal(publicKey) {
+		t.Fatalf("different public key")
+	}
+}
+
+// the example from https://datatracker.ietf.org/doc/html/rfc7638#section-3.1
+func TestJwkThumbprint(t *testing.T) {
+	jwk := JwkDetails{
+		Kty: "RSA",
+		Kid: "2011-04-29",
+		Alg: "RS256",
+		N:   "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		E:   "AQAB",
+	}
+	thumbprint, err := jwk.Thumbprint()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if thumbprint != "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs" {
+		t.Fatalf("unexpected thumbprint: %v", thumbprint)
+	}
+}
+
+type singleKeystore struct {
+	key *JwkKeypair
+}
+
+func (obj *singleKeystore) ListKeys(ctx context.Context) ([]*JwkKeypair, error) {
+	return []*JwkKeypair{obj.key}, nil
+}
+
+func (obj *singleKeystore) GetKey(ctx context.Context, kid string) (*JwkKeypair, error) {
+	return obj.key, nil
+}
+
+func (obj *singleKeystore) SaveKey(ctx context.Context, keypair *JwkKeypair) error {
+	saved := *keypair
+	obj.key = &saved
+	return nil
+}
+
+func TestNonceKeyIsSeparateFromTheSigningKey(t *testing.T) {
+	ctx := t.Context()
+	key, err := GenerateJwkKeypair()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if key.NonceKey == "" {
+		t.Fatalf("expected a generated nonce key")
+	}
+
+	// saved before there were nonce keys
+	key.NonceKey = ""
+	store := &singleKeystore{key: key}
+	nonceKey, err := GetCurrentNonceKey(ctx, store)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(nonceKey) != 32 || store.key.NonceKey == "" {
+		t.Fatalf("expected a new nonce key to be saved")
+	}
+	again, err := GetCurrentNonceKey(ctx, store)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !bytes.Equal(nonceKey, again) {
+		t.Fatalf("expected the saved nonce key to be reused")
+	}
+}
@@ -0,0 +1,190 @@
+package logout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kncept-oauth/simple-oidc/service/audit"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/netutil"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+	"github.com/kncept-oauth/simple-oidc/service/tokens"
+)
+
+// how long a client has to accept the logout token
+const DeliveryTimeout = 5 * time.Second
+
+// the delay before each retry of a failed delivery. once they are exhausted, the failure is recorded
+var DefaultRetryDelays = []time.Duration{time.Minute, 5 * time.Minute, 30 * time.Minute, 2 * time.Hour}
+
+// notifies clients (relying parties) that a user session with them has ended
+// see https://openid.net/specs/openid-connect-backchannel-1_0.html
+type BackChannelNotifier struct {
+	DaoSource   dao.DaoSource
+	Issuer      string
+	RetryDelays []time.Duration // defaults to DefaultRetryDelays
+}
+
+// a pending logout is saved for every client that needs to be notified, so that a slow (or
+// unavailable) client never holds up the response. they are delivered by DeliverPending
+func (obj *BackChannelNotifier) SessionsEnded(ctx context.Context, sessions []*session.Session) {
+	for _, ses := range sessions {
+		err := obj.savePendingLogout(ctx, ses)
+		if err != nil {
+			log.Printf("unable to save backchannel logout for session %v: %v\n", ses.SessionId, err)
+		}
+	}
+}
+
+// clients without a backchannel logout uri are not notified
+func (obj *BackChannelNotifier) savePendingLogout(ctx context.Context, ses *session.Session) error {
+	oidcClient, err := obj.DaoSource.GetClientStore(ctx).GetClient(ctx, ses.ClientId)
+	if err != nil {
+		return err
+	}
+	if oidcClient == nil || oidcClient.BackchannelLogoutUri == "" {
+		return nil
+	}
+	pending, err := client.NewPendingLogout(ses.ClientId, ses.UserId, ses.SessionId)
+	if err != nil {
+		return err
+	}
+	return obj.DaoSource.GetPendingLogoutStore(ctx).SavePendingLogout(ctx, pending)
+}
+
+// delivers pending logouts every interval, until the context is done.
+// this suits a long running server, a lambda is invoked on a schedule instead (see main.go)
+func (obj *BackChannelNotifier) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := obj.DeliverPending(ctx)
+			if err != nil {
+				log.Printf("backchannel logout delivery failed: %v\n", err)
+			}
+		}
+	}
+}
+
+// every due logout is delivered in parallel. a delivered logout is removed, and a failed one is
+// retried after the next retry delay, or recorded as an audit event (for administrators to review)
+// once the retries are exhausted
+func (obj *BackChannelNotifier) DeliverPending(ctx context.Context) error {
+	store := obj.DaoSource.GetPendingLogoutStore(ctx)
+	pendingLogouts, err := store.ListPendingLogouts(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	errs := make([]error, len(pendingLogouts))
+	var wg sync.WaitGroup
+	for idx, pending := range pendingLogouts {
+		if !pending.IsDue(now) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[idx] = obj.deliverPending(ctx, store, pending)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (obj *BackChannelNotifier) deliverPending(ctx context.Context, store client.PendingLogoutStore, pending *client.PendingLogout) error {
+	err := obj.Notify(ctx, &session.Session{
+		SessionId: pending.SessionId,
+		UserId:    pending.UserId,
+		ClientId:  pending.ClientId,
+	})
+	if err == nil {
+		return store.DeletePendingLogout(ctx, pending.Id)
+	}
+
+	retryDelays := obj.RetryDelays
+	if retryDelays == nil {
+		retryDelays = DefaultRetryDelays
+	}
+	pending.Attempts++
+	pending.LastError = err.Error()
+	if pending.Attempts <= len(retryDelays) {
+		pending.NextAttempt = time.Now().UTC().Add(retryDelays[pending.Attempts-1])
+		return store.SavePendingLogout(ctx, pending)
+	}
+
+	event, err := audit.NewEvent(audit.EventTypeBackChannelLogoutFailed)
+	if err != nil {
+		return err
+	}
+	event.ClientId = pending.ClientId
+	event.UserId = pending.UserId
+	event.SessionId = pending.SessionId
+	event.Details = fmt.Sprintf("%v attempts: %v", pending.Attempts, pending.LastError)
+	err = audit.RecordEvent(ctx, obj.DaoSource.GetEventStore(ctx), event)
+	if err != nil {
+		return err
+	}
+	return store.DeletePendingLogout(ctx, pending.Id)
+}
+
+// delivers a logout token for the session, in a single attempt.
+// clients without a backchannel logout uri are not notified
+func (obj *BackChannelNotifier) Notify(ctx context.Context, ses *session.Session) error {
+	oidcClient, err := obj.DaoSource.GetClientStore(ctx).GetClient(ctx, ses.ClientId)
+	if err != nil {
+		return err
+	}
+	if oidcClient == nil || oidcClient.BackchannelLogoutUri == "" {
+		return nil
+	}
+	logoutToken, err := (&tokens.TokenService{
+		DaoSource: obj.DaoSource,
+		Issuer:    obj.Issuer,
+	}).IssueLogoutToken(ctx, ses)
+	if err != nil {
+		return err
+	}
+	err = deliverLogoutToken(ctx, oidcClient.BackchannelLogoutUri, logoutToken)
+	if err != nil {
+		return fmt.Errorf("%v: %w", oidcClient.BackchannelLogoutUri, err)
+	}
+	return nil
+}
+
+// see https://openid.net/specs/openid-connect-backchannel-1_0.html#BCRequest
+func deliverLogoutToken(ctx context.Context, backchannelLogoutUri string, logoutToken string) error {
+	ctx, cancel := context.WithTimeout(ctx, DeliveryTimeout)
+	defer cancel()
+	body := url.Values{}
+	body.Add("logout_token", logoutToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, backchannelLogoutUri, strings.NewReader(body.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	res, err := netutil.FetchClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+	// 200 OK (or 204 No Content) on success
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %v", res.StatusCode)
+	}
+	return nil
+}
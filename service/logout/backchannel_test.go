@@ -0,0 +1,140 @@
+package logout
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/audit"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/keys"
+	"github.com/kncept-oauth/simple-oidc/service/netutil"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+)
+
+func TestBackChannelNotify(t *testing.T) {
+	ctx := t.Context()
+	issuer := "https://issuer.example.com"
+	daoSource := dao.NewMemoryDao()
+	keypair, err := keys.GenerateJwkKeypair()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = daoSource.GetKeyStore(ctx).SaveKey(ctx, keypair)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	logoutTokens := make([]string, 0)
+	rp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logoutTokens = append(logoutTokens, r.FormValue("logout_token"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer rp.Close()
+	// the test server is on loopback, which is otherwise refused
+	fetchClient := netutil.FetchClient
+	netutil.FetchClient = netutil.NewFetchClient(func(addr netip.Addr) bool { return true })
+	t.Cleanup(func() { netutil.FetchClient = fetchClient })
+
+	oidcClient := &client.Client{
+		ClientId:             uuid.NewString(),
+		BackchannelLogoutUri: rp.URL,
+	}
+	daoSource.GetClientStore(ctx).SaveClient(ctx, oidcClient)
+	ses, err := session.NewSession("username", oidcClient.ClientId)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	notifier := &BackChannelNotifier{
+		DaoSource: daoSource,
+		Issuer:    issuer,
+	}
+	err = notifier.Notify(ctx, ses)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(logoutTokens) != 1 {
+		t.Fatalf("expected a single delivered logout token, but got %v", len(logoutTokens))
+	}
+	if !jwtutil.JwtHasType(logoutTokens[0], jwtutil.LogoutTokenType) {
+		t.Fatalf("expected a %v typed token", jwtutil.LogoutTokenType)
+	}
+	claims := &jwtutil.LogoutToken{}
+	err = jwtutil.ParseSignedJwt(ctx, logoutTokens[0], daoSource.GetKeyStore(ctx), claims)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if claims.Iss != issuer || claims.Sub != ses.UserId || claims.Sid != ses.SessionId || !slices.Contains(claims.Aud, oidcClient.ClientId) {
+		t.Fatalf("unexpected logout token claims: %+v", claims)
+	}
+	if _, ok := claims.Events[jwtutil.BackChannelLogoutEvent]; !ok {
+		t.Fatalf("missing the backchannel logout event: %+v", claims.Events)
+	}
+
+	// deliveries are made later, and a failed delivery is retried
+	pendingLogouts := func() []*client.PendingLogout {
+		pending, err := daoSource.GetPendingLogoutStore(ctx).ListPendingLogouts(ctx)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return pending
+	}
+	attempts := 0
+	rp.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		logoutTokens = append(logoutTokens, r.FormValue("logout_token"))
+		w.WriteHeader(http.StatusOK)
+	})
+	notifier.RetryDelays = []time.Duration{0}
+	notifier.SessionsEnded(ctx, []*session.Session{ses})
+	if attempts != 0 || len(pendingLogouts()) != 1 {
+		t.Fatalf("expected a pending delivery, but got %v attempts", attempts)
+	}
+	err = notifier.DeliverPending(ctx)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if pending := pendingLogouts(); attempts != 1 || len(pending) != 1 || pending[0].Attempts != 1 {
+		t.Fatalf("expected the failed delivery to be kept for a retry: %+v", pending)
+	}
+	err = notifier.DeliverPending(ctx)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if attempts != 2 || len(logoutTokens) != 2 || len(pendingLogouts()) != 0 {
+		t.Fatalf("expected the retry to be delivered, but got %v attempts", attempts)
+	}
+
+	// once every retry has failed, the failure is recorded
+	rp.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	notifier.SessionsEnded(ctx, []*session.Session{ses})
+	for range 2 {
+		err = notifier.DeliverPending(ctx)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+	if len(pendingLogouts()) != 0 {
+		t.Fatalf("expected the failed delivery to be given up on")
+	}
+	events, err := daoSource.GetEventStore(ctx).ListEvents(ctx)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(events) != 1 || events[0].EventType != audit.EventTypeBackChannelLogoutFailed || events[0].SessionId != ses.SessionId {
+		t.Fatalf("expected the failed delivery to be recorded: %+v", events)
+	}
+}
@@ -2,19 +2,27 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/awslabs/aws-lambda-go-api-proxy/httpadapter"
+	"github.com/kncept-oauth/simple-oidc/service/client"
 	"github.com/kncept-oauth/simple-oidc/service/dao"
 	"github.com/kncept-oauth/simple-oidc/service/development"
 	"github.com/kncept-oauth/simple-oidc/service/dispatcher"
+	"github.com/kncept-oauth/simple-oidc/service/logout"
 )
 
+// how often a long running server retries back-channel logouts
+const pendingLogoutInterval = 15 * time.Second
+
 func main() {
 	ctx := context.Background()
 	runmode := os.Getenv("RUN_MODE")
@@ -35,6 +43,12 @@ func main() {
 
 	// fmt.Printf("Runmode: %s\nHostUrl: %s\n", runmode, hostUrl)
 
+	// dynamic client registration is closed unless enabled for the deployment
+	registration := &client.RegistrationSettings{
+		Policy:             os.Getenv("CLIENT_REGISTRATION"),
+		InitialAccessToken: os.Getenv("CLIENT_REGISTRATION_TOKEN"),
+	}
+
 	switch runmode {
 	case "aws-lambda":
 		cfg, err := config.LoadDefaultConfig(ctx)
@@ -46,9 +60,24 @@ func main() {
 			tablePrefix = fmt.Sprintf("%s_", tablePrefix)
 		}
 		daoSource := dao.NewDynamoDbDao(cfg, tablePrefix)
-		err = wrappedRunner(daoSource, hostUrl, func(handler http.Handler) error {
+		notifier := &logout.BackChannelNotifier{
+			DaoSource: daoSource,
+			Issuer:    hostUrl,
+		}
+		err = wrappedRunner(daoSource, hostUrl, registration, func(handler http.Handler) error {
 			handlerAdapter := httpadapter.New(handler)
-			lambda.Start(handlerAdapter.ProxyWithContext)
+			lambda.Start(func(ctx context.Context, payload json.RawMessage) (any, error) {
+				// the scheduled event (see deploy) delivers pending back-channel logouts
+				scheduled := &events.CloudWatchEvent{}
+				if json.Unmarshal(payload, scheduled) == nil && scheduled.Source == "aws.events" {
+					return nil, notifier.DeliverPending(ctx)
+				}
+				req := events.APIGatewayProxyRequest{}
+				if err := json.Unmarshal(payload, &req); err != nil {
+					return nil, err
+				}
+				return handlerAdapter.ProxyWithContext(ctx, req)
+			})
 			return nil
 		})
 		if err != nil {
@@ -60,7 +89,11 @@ func main() {
 			panic(err)
 		}
 		daoSource := dao.NewDynamoDbDao(cfg, "")
-		err = wrappedRunner(daoSource, hostUrl, func(handler http.Handler) error {
+		go (&logout.BackChannelNotifier{
+			DaoSource: daoSource,
+			Issuer:    hostUrl,
+		}).Run(ctx, pendingLogoutInterval)
+		err = wrappedRunner(daoSource, hostUrl, registration, func(handler http.Handler) error {
 			_, err := development.RunLocally(daoSource, handler, "../service")
 			return err
 		})
@@ -69,7 +102,11 @@ func main() {
 		}
 	case "dev":
 		daoSource := dao.NewDefaultFilesystemDao()
-		err := wrappedRunner(daoSource, hostUrl, func(handler http.Handler) error {
+		go (&logout.BackChannelNotifier{
+			DaoSource: daoSource,
+			Issuer:    hostUrl,
+		}).Run(ctx, pendingLogoutInterval)
+		err := wrappedRunner(daoSource, hostUrl, registration, func(handler http.Handler) error {
 			done := make(chan struct{})
 			_, err := development.RunLocally(daoSource, handler, "../service")
 			<-done // will block forever
@@ -83,10 +120,11 @@ func main() {
 	}
 }
 
-func wrappedRunner(daoSource dao.DaoSource, hostUrl string, callback func(handler http.Handler) error) error {
+func wrappedRunner(daoSource dao.DaoSource, hostUrl string, registration *client.RegistrationSettings, callback func(handler http.Handler) error) error {
 	srv, err := dispatcher.NewApplication(
 		daoSource,
 		hostUrl,
+		registration,
 		nil,
 	)
 	if err != nil {
@@ -0,0 +1,54 @@
+package netutil
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// shared address space (carrier grade nat), also used for some cloud metadata services
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// the http client for uris that a (dynamically registered) client supplies, eg: jwks_uri,
+// request_uri and backchannel_logout_uri. the uri is validated on registration, but a hostname
+// can resolve to anything, so the resolved address is checked on every connection
+var FetchClient = NewFetchClient(IsPublicAddress)
+
+// loopback, private, link local, multicast and unspecified addresses are not public
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// connections are only made to addresses that are allowed, and redirects are never followed,
+// as they could point anywhere
+func NewFetchClient(allowed func(addr netip.Addr) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network string, address string, c syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !allowed(addrPort.Addr()) {
+				return fmt.Errorf("address is not allowed: %v", address)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would be dialed instead of the resolved address
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return errors.New("redirects are not followed")
+		},
+	}
+}
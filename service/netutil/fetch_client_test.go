@@ -0,0 +1,73 @@
+package netutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+)
+
+func TestIsPublicAddress(t *testing.T) {
+	for addr, expected := range map[string]bool{
+		"93.184.215.14":    true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"::1":              false,
+		"10.0.0.1":         false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"100.100.100.200":  false,
+		"fd00:ec2::254":    false,
+		"0.0.0.0":          false,
+		"::ffff:127.0.0.1": false,
+	} {
+		if IsPublicAddress(netip.MustParseAddr(addr)) != expected {
+			t.Errorf("expected %v public: %v", addr, expected)
+		}
+	}
+}
+
+func TestFetchClientChecksTheResolvedAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	serverUrl, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// a hostname, rather than an ip literal, that resolves to loopback
+	res, err := FetchClient.Get("http://localhost:" + serverUrl.Port())
+	if err == nil {
+		res.Body.Close()
+		t.Fatalf("expected the loopback address to be refused")
+	}
+	res, err = FetchClient.Get(server.URL)
+	if err == nil {
+		res.Body.Close()
+		t.Fatalf("expected the loopback address to be refused")
+	}
+
+	allowAll := NewFetchClient(func(addr netip.Addr) bool { return true })
+	res, err = allowAll.Get("http://localhost:" + serverUrl.Port())
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	res.Body.Close()
+}
+
+func TestFetchClientDoesNotFollowRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	}))
+	defer server.Close()
+
+	allowAll := NewFetchClient(func(addr netip.Addr) bool { return true })
+	res, err := allowAll.Get(server.URL)
+	if err == nil {
+		res.Body.Close()
+		t.Fatalf("expected the redirect to be refused")
+	}
+}
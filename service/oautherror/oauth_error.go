@@ -0,0 +1,125 @@
+package oautherror
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// see https://datatracker.ietf.org/doc/html/rfc6749#section-5.2
+// and https://datatracker.ietf.org/doc/html/rfc6749#section-4.1.2.1
+const (
+	InvalidRequest          = "invalid_request"
+	InvalidClient           = "invalid_client"
+	InvalidGrant            = "invalid_grant"
+	UnauthorizedClient      = "unauthorized_client"
+	UnsupportedGrantType    = "unsupported_grant_type"
+	InvalidScope            = "invalid_scope"
+	UnsupportedResponseType = "unsupported_response_type"
+	AccessDenied            = "access_denied"
+	ServerError             = "server_error"
+)
+
+// device authorization grant polling responses
+// see https://datatracker.ietf.org/doc/html/rfc8628#section-3.5
+const (
+	AuthorizationPending = "authorization_pending"
+	SlowDown             = "slow_down"
+	ExpiredToken         = "expired_token"
+)
+
+// request object errors
+// see https://datatracker.ietf.org/doc/html/rfc9101#section-6.2
+const (
+	InvalidRequestUri    = "invalid_request_uri"
+	InvalidRequestObject = "invalid_request_object"
+)
+
+// DPoP proof errors. a 400 from the token endpoint, and a 401 from a protected resource
+// see https://datatracker.ietf.org/doc/html/rfc9449#section-12.2
+const (
+	InvalidDPoPProof = "invalid_dpop_proof"
+	UseDPoPNonce     = "use_dpop_nonce"
+)
+
+// token exchange errors
+// see https://datatracker.ietf.org/doc/html/rfc8693#section-2.2.2
+const (
+	InvalidTarget = "invalid_target"
+)
+
+// dynamic client registration errors
+// see https://datatracker.ietf.org/doc/html/rfc7591#section-3.2.2
+const (
+	InvalidRedirectUri    = "invalid_redirect_uri"
+	InvalidClientMetadata = "invalid_client_metadata"
+	// see https://datatracker.ietf.org/doc/html/rfc6750#section-3.1
+	InvalidToken      = "invalid_token"
+	InsufficientScope = "insufficient_scope"
+)
+
+type OAuthError struct {
+	Code        string // the 'error' value
+	Description string // the 'error_description' value, human readable
+}
+
+func (obj *OAuthError) Error() string {
+	if obj.Description == "" {
+		return obj.Code
+	}
+	return fmt.Sprintf("%v: %v", obj.Code, obj.Description)
+}
+
+// invalid_client and invalid_token are a 401, insufficient_scope a 403, every other error is a 400
+func (obj *OAuthError) StatusCode() int {
+	if obj.Code == InvalidClient || obj.Code == InvalidToken {
+		return 401
+	}
+	if obj.Code == InsufficientScope {
+		return 403
+	}
+	return 400
+}
+
+// parameters for an error response redirected back to the client
+func (obj *OAuthError) ResponseParams(state string) url.Values {
+	responseParams := url.Values{}
+	responseParams.Add("error", obj.Code)
+	if obj.Description != "" {
+		responseParams.Add("error_description", obj.Description)
+	}
+	if state != "" {
+		responseParams.Add("state", state)
+	}
+	return responseParams
+}
+
+func New(code string, format string, args ...any) *OAuthError {
+	return &OAuthError{
+		Code:        code,
+		Description: fmt.Sprintf(format, args...),
+	}
+}
+
+// wraps errors that aren't already an OAuthError with the given code
+// eg: a failure to validate a grant becomes invalid_grant
+func Wrap(code string, err error) *OAuthError {
+	if err == nil {
+		return nil
+	}
+	if oauthErr, ok := As(err); ok {
+		return oauthErr
+	}
+	return &OAuthError{
+		Code:        code,
+		Description: err.Error(),
+	}
+}
+
+func As(err error) (*OAuthError, bool) {
+	var oauthErr *OAuthError
+	if errors.As(err, &oauthErr) {
+		return oauthErr, true
+	}
+	return nil, false
+}
@@ -0,0 +1,35 @@
+package oautherror
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestWrapKeepsOAuthErrorCode(t *testing.T) {
+	invalidClient := New(InvalidClient, "unknown client %v", "abc")
+	wrapped := Wrap(InvalidGrant, fmt.Errorf("authenticating: %w", invalidClient))
+	if wrapped.Code != InvalidClient {
+		t.Fatalf("expected %v but got %v", InvalidClient, wrapped.Code)
+	}
+	if wrapped.StatusCode() != 401 {
+		t.Fatalf("invalid_client must be a 401")
+	}
+
+	wrapped = Wrap(InvalidGrant, fmt.Errorf("code expired"))
+	if wrapped.Code != InvalidGrant || wrapped.Description != "code expired" {
+		t.Fatalf("unexpected wrapped error: %v", wrapped)
+	}
+	if wrapped.StatusCode() != 400 {
+		t.Fatalf("invalid_grant must be a 400")
+	}
+	if Wrap(InvalidGrant, nil) != nil {
+		t.Fatalf("nil errors must stay nil")
+	}
+}
+
+func TestResponseParams(t *testing.T) {
+	responseParams := New(InvalidScope, "scope %v not allowed", "admin").ResponseParams("xyz")
+	if responseParams.Encode() != "error=invalid_scope&error_description=scope+admin+not+allowed&state=xyz" {
+		t.Fatalf("unexpected response params: %v", responseParams.Encode())
+	}
+}
@@ -15,8 +15,24 @@ type OidcAuthCodeFlowParams struct {
 	Scope        string `json:"scope"`
 	RedirectUri  string `json:"redirect_uri"`
 
-	State string `json:"state,omitempty"`
-	Nonce string `json:"nonce,omitempty"`
+	State        string `json:"state,omitempty"`
+	ResponseMode string `json:"response_mode,omitempty"`
+	Nonce        string `json:"nonce,omitempty"`
+
+	// PKCE (RFC 7636)
+	CodeChallenge       string `json:"code_challenge,omitempty"`
+	CodeChallengeMethod string `json:"code_challenge_method,omitempty"`
+
+	// set when approving a device authorization request (RFC 8628) instead
+	UserCode string `json:"user_code,omitempty"`
+
+	// set for a pushed authorization request (RFC 9126), the other params are kept server side
+	RequestUri string `json:"request_uri,omitempty"`
+}
+
+// device authorization requests are approved in the browser, but the tokens go to the device
+func (obj *OidcAuthCodeFlowParams) IsDeviceAuthorization() bool {
+	return obj.UserCode != ""
 }
 
 func jsonTag(f reflect.StructField) []string {
@@ -44,6 +60,10 @@ func (obj *OidcAuthCodeFlowParams) ToQueryParams() string {
 }
 
 func (obj *OidcAuthCodeFlowParams) IsValid() bool {
+	if obj.IsDeviceAuthorization() {
+		// there is no redirect, the device polls the token endpoint
+		return obj.ClientId != "" && obj.Scope != ""
+	}
 	if obj.ResponseType == "" {
 		return false
 	}
@@ -56,6 +76,9 @@ func (obj *OidcAuthCodeFlowParams) IsValid() bool {
 	if obj.RedirectUri == "" {
 		return false
 	}
+	if obj.CodeChallengeMethod != "" && obj.CodeChallenge == "" {
+		return false
+	}
 
 	return true
 }
@@ -66,7 +89,18 @@ func (obj *OidcAuthCodeFlowParams) Merge(other *OidcAuthCodeFlowParams) {
 	obj.Scope = fallbackString(obj.Scope, other.Scope)
 	obj.RedirectUri = fallbackString(obj.RedirectUri, other.RedirectUri)
 	obj.State = fallbackString(obj.State, other.State)
+	obj.ResponseMode = fallbackString(obj.ResponseMode, other.ResponseMode)
 	obj.Nonce = fallbackString(obj.Nonce, other.Nonce)
+	obj.CodeChallenge = fallbackString(obj.CodeChallenge, other.CodeChallenge)
+	obj.CodeChallengeMethod = fallbackString(obj.CodeChallengeMethod, other.CodeChallengeMethod)
+	// a new authorization request replaces any device authorization (or pushed request) in progress
+	if other.ResponseType != "" || other.RequestUri != "" {
+		obj.UserCode = other.UserCode
+		obj.RequestUri = other.RequestUri
+	} else {
+		obj.UserCode = fallbackString(obj.UserCode, other.UserCode)
+		obj.RequestUri = fallbackString(obj.RequestUri, other.RequestUri)
+	}
 }
 
 func fallbackString(v1, v2 string) string {
@@ -36,3 +36,52 @@ func TestEmptyOidcParamsFromQuery(t *testing.T) {
 		t.Fatalf("Unexpected query params: %v", v.ToQueryParams())
 	}
 }
+
+func TestAuthorizationRequestReplacesDeviceAuthorization(t *testing.T) {
+	v := &OidcAuthCodeFlowParams{
+		ClientId: "device",
+		Scope:    "openid",
+		UserCode: "BCDFGHJK",
+	}
+	if !v.IsValid() {
+		t.Fatalf("a device authorization has no redirect uri or response type")
+	}
+	v.Merge(&OidcAuthCodeFlowParams{
+		Scope: "openid profile",
+	})
+	if !v.IsDeviceAuthorization() {
+		t.Fatalf("expected the device authorization to continue")
+	}
+	v.Merge(&OidcAuthCodeFlowParams{
+		ResponseType: "code",
+		ClientId:     "web",
+		RedirectUri:  "https://example.com/callback",
+	})
+	if v.IsDeviceAuthorization() {
+		t.Fatalf("expected the authorization request to replace the device authorization")
+	}
+}
+
+func TestPushedAuthorizationRequestMerge(t *testing.T) {
+	v := &OidcAuthCodeFlowParams{
+		ResponseType: "code",
+		ClientId:     "web",
+		Scope:        "openid",
+		RedirectUri:  "https://example.com/callback",
+	}
+	v.Merge(&OidcAuthCodeFlowParams{
+		ClientId:   "web",
+		RequestUri: "urn:ietf:params:oauth:request_uri:abc",
+	})
+	if v.RequestUri != "urn:ietf:params:oauth:request_uri:abc" {
+		t.Fatalf("expected the pushed request to replace the inline request")
+	}
+	v.Merge(&OidcAuthCodeFlowParams{
+		ResponseType: "code",
+		ClientId:     "web",
+		RedirectUri:  "https://example.com/callback",
+	})
+	if v.RequestUri != "" {
+		t.Fatalf("expected the inline request to replace the pushed request")
+	}
+}
@@ -0,0 +1,59 @@
+package scopes
+
+import (
+	"slices"
+	"strings"
+)
+
+// see https://openid.net/specs/openid-connect-core-1_0.html#ScopeClaims
+const (
+	OpenId  = "openid"
+	Profile = "profile"
+	Email   = "email"
+	Phone   = "phone"
+	Address = "address"
+)
+
+var Supported = []string{
+	OpenId,
+	Profile,
+	Email,
+	Phone,
+	Address,
+}
+
+// the standard claims released for each scope
+var Claims = map[string][]string{
+	Profile: {
+		"name", "family_name", "given_name", "middle_name", "nickname",
+		"preferred_username", "profile", "picture", "website", "gender",
+		"birthdate", "zoneinfo", "locale", "updated_at",
+	},
+	Email:   {"email", "email_verified"},
+	Phone:   {"phone_number", "phone_number_verified"},
+	Address: {"address"},
+}
+
+// scope values are a space separated set
+func Parse(scope string) []string {
+	parsed := []string{}
+	for _, s := range strings.Fields(scope) {
+		if !slices.Contains(parsed, s) {
+			parsed = append(parsed, s)
+		}
+	}
+	return parsed
+}
+
+func Includes(scope string, value string) bool {
+	return slices.Contains(strings.Fields(scope), value)
+}
+
+// the claims released for a (space separated) scope
+func ClaimsFor(scope string) []string {
+	claims := []string{}
+	for _, s := range Parse(scope) {
+		claims = append(claims, Claims[s]...)
+	}
+	return claims
+}
@@ -0,0 +1,19 @@
+package scopes
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestClaimsFor(t *testing.T) {
+	claims := ClaimsFor("openid email custom")
+	if !slices.Equal(claims, []string{"email", "email_verified"}) {
+		t.Fatalf("unexpected claims: %v", claims)
+	}
+	if len(ClaimsFor(OpenId)) != 0 {
+		t.Fatalf("openid scope alone should not release any profile claims")
+	}
+	if !slices.Contains(ClaimsFor("profile"), "preferred_username") {
+		t.Fatalf("profile scope should release preferred_username")
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"github.com/segmentio/ksuid"
 )
 
+// access tokens are short lived, and must be refreshed
+const AccessTokenLifetime = time.Hour
+
 type Session struct {
 	SessionId string `dynamodbav:"id"`
 
@@ -21,7 +24,76 @@ type Session struct {
 	Refreshed  time.Time `dynamodbav:"refreshed"`
 	IssueCount int64     `dynamodbav:"issueCount"`
 
+	AuthTime time.Time `dynamodbav:"authTime"` // when the user actually authenticated
+	Nonce    string    `dynamodbav:"nonce"`    // from the authorization request
+	Scope    string    `dynamodbav:"scope"`    // space separated
+
 	RefreshCode string `dynamodbav:"refreshCode"` // refresh code needs to match when extracted from the RefreshToken JWT
+
+	// the JWK thumbprint of the DPoP key all tokens for this session are bound to
+	DPoPJkt string `dynamodbav:"dpopJkt"`
+
+	Revoked *time.Time `dynamodbav:"revoked"` // no further tokens are issued for a revoked session
+}
+
+func (obj *Session) IsActive() bool {
+	return obj.Revoked == nil
+}
+
+func (obj *Session) Revoke() {
+	if obj.Revoked == nil {
+		now := time.Now().UTC()
+		obj.Revoked = &now
+	}
+}
+
+// revoking a session that does not exist is not an error
+func RevokeSession(ctx context.Context, store SessionStore, sessionId string, userId string) error {
+	ses, err := store.LoadSession(ctx, sessionId, userId)
+	if err != nil {
+		return err
+	}
+	if ses == nil || !ses.IsActive() {
+		return nil
+	}
+	ses.Revoke()
+	return store.SaveSession(ctx, ses)
+}
+
+// ends every active session for the user (eg: logging out of simple-oidc).
+// returns the sessions that were revoked by this call
+func RevokeUserSessions(ctx context.Context, store SessionStore, userId string) ([]*Session, error) {
+	return revokeUserSessions(ctx, store, userId, func(ses *Session) bool {
+		return true
+	})
+}
+
+// ends the users active sessions with a single client (eg: the client is deauthorized).
+// returns the sessions that were revoked by this call
+func RevokeUserClientSessions(ctx context.Context, store SessionStore, userId string, clientId string) ([]*Session, error) {
+	return revokeUserSessions(ctx, store, userId, func(ses *Session) bool {
+		return ses.ClientId == clientId
+	})
+}
+
+func revokeUserSessions(ctx context.Context, store SessionStore, userId string, filter func(ses *Session) bool) ([]*Session, error) {
+	sessions, err := store.ListUserSessions(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	revoked := make([]*Session, 0, len(sessions))
+	for _, ses := range sessions {
+		if !ses.IsActive() || !filter(ses) {
+			continue
+		}
+		ses.Revoke()
+		err = store.SaveSession(ctx, ses)
+		if err != nil {
+			return nil, err
+		}
+		revoked = append(revoked, ses)
+	}
+	return revoked, nil
 }
 
 // TODO: these two should _really_ be a single function
@@ -44,10 +116,17 @@ func (obj *Session) IssueTokens(issuer string, audience ...string) (*jwtutil.IdT
 			Exp: expiry.Unix(),
 			Iat: obj.Refreshed.Unix(),
 		},
+		AdditionalStandardClaimsIdToken: jwtutil.AdditionalStandardClaimsIdToken{
+			AuthTime: obj.AuthTime.Unix(),
+			AZP:      obj.ClientId,
+		},
 		AdditionalCustomClaimsIdToken: jwtutil.AdditionalCustomClaimsIdToken{
 			Sid: obj.SessionId,
 		},
 	}
+	// the nonce binds the authentication request to the id tokens issued in response to it
+	// (with the hybrid flow, both the front channel AND the token endpoint id tokens)
+	idToken.Nonce = obj.Nonce
 	refreshToken := &jwtutil.RefreshClaimsJwt{
 		MinimalIdToken: jwtutil.MinimalIdToken{
 			Iss: issuer,
@@ -57,20 +136,48 @@ func (obj *Session) IssueTokens(issuer string, audience ...string) (*jwtutil.IdT
 			Iat: obj.Refreshed.Unix(),
 		},
 		AdditionalRefreshClaims: jwtutil.AdditionalRefreshClaims{
-			Nbf:  expiry.Add(-time.Hour).Unix(),
+			// usable straight away, as the access token expires long before the id token
+			Nbf:  obj.Refreshed.Unix(),
 			Ses:  obj.SessionId,
 			Code: obj.RefreshCode,
+			Cnf:  jwtutil.NewConfirmation(obj.DPoPJkt),
 		},
 	}
 	return idToken, refreshToken
 }
 
+// must be called AFTER IssueTokens, as the access token shares the issued at time.
+// the audience is the resource servers (APIs) that the token may be presented to
+func (obj *Session) IssueAccessToken(issuer string, audience ...string) *jwtutil.AccessToken {
+	if len(audience) == 0 {
+		panic("Must supply at least one audience")
+	}
+	return &jwtutil.AccessToken{
+		Iss:      issuer,
+		Sub:      obj.UserId,
+		Aud:      audience,
+		Exp:      obj.Refreshed.Add(AccessTokenLifetime).Unix(),
+		Iat:      obj.Refreshed.Unix(),
+		Jti:      uuid.NewString(),
+		ClientId: obj.ClientId,
+		Scope:    obj.Scope,
+		AuthTime: obj.AuthTime.Unix(),
+		Sid:      obj.SessionId,
+		Cnf:      jwtutil.NewConfirmation(obj.DPoPJkt),
+	}
+}
+
 type SessionStore interface {
 	SaveSession(ctx context.Context, session *Session) error
 	LoadSession(ctx context.Context, sessionId string, userId string) (*Session, error)
 	ListUserSessions(ctx context.Context, userId string) ([]*Session, error)
 }
 
+// tokens issued on refresh are not a response to the authentication request, so don't carry the nonce
+func (obj *Session) ForgetNonce() {
+	obj.Nonce = ""
+}
+
 func NewSession(userId string, clientId string) (*Session, error) {
 	now := time.Now()
 	k, err := ksuid.NewRandomWithTime(now)
@@ -83,6 +190,7 @@ func NewSession(userId string, clientId string) (*Session, error) {
 		ClientId:    clientId,
 		Created:     now,
 		Refreshed:   now,
+		AuthTime:    now,
 		IssueCount:  0,
 		RefreshCode: "",
 	}, nil
@@ -0,0 +1,55 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestIssueTokensStandardClaims(t *testing.T) {
+	ses, err := NewSession(uuid.NewString(), "client")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	authTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	ses.AuthTime = authTime
+	ses.Nonce = "n-0S6_WzA2Mj"
+
+	idToken, _ := ses.IssueTokens("issuer", "client")
+	if idToken.Nonce != ses.Nonce {
+		t.Fatalf("expected nonce %v but got %v", ses.Nonce, idToken.Nonce)
+	}
+	if idToken.AuthTime != authTime.Unix() {
+		t.Fatalf("expected auth_time %v but got %v", authTime.Unix(), idToken.AuthTime)
+	}
+	if idToken.AZP != "client" {
+		t.Fatalf("expected azp of client but got %v", idToken.AZP)
+	}
+
+	hybridIdToken, _ := ses.IssueTokens("issuer", "client")
+	if hybridIdToken.Nonce != idToken.Nonce {
+		t.Fatalf("nonce must be included in every id token issued for the authentication request")
+	}
+
+	ses.ForgetNonce()
+	refreshedIdToken, _ := ses.IssueTokens("issuer", "client")
+	if refreshedIdToken.Nonce != "" {
+		t.Fatalf("nonce must not be included in refreshed id tokens")
+	}
+	if refreshedIdToken.AuthTime != authTime.Unix() {
+		t.Fatalf("auth_time must be the original authentication time")
+	}
+}
+
+func TestRefreshTokenIsUsableBeforeTheAccessTokenExpires(t *testing.T) {
+	ses, err := NewSession(uuid.NewString(), "client")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, refreshToken := ses.IssueTokens("issuer", "client")
+	accessToken := ses.IssueAccessToken("issuer", "issuer")
+	if refreshToken.Nbf > accessToken.Iat || refreshToken.Exp <= accessToken.Exp {
+		t.Fatalf("the refresh token must be valid while the access token is: %+v %+v", refreshToken, accessToken)
+	}
+}
@@ -0,0 +1,41 @@
+package tokens
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+)
+
+// a machine to machine access token. The client is the subject, and there is no user session
+// (so no id token or refresh token either). dpopJkt is only set for a DPoP bound token
+// see https://datatracker.ietf.org/doc/html/rfc6749#section-4.4
+func (obj *TokenService) IssueClientCredentialsToken(ctx context.Context, oidcClient *client.Client, scope string, dpopJkt string) (*IssuedTokens, error) {
+	keyPair, rsaKey, err := obj.signingKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	accessToken := &jwtutil.AccessToken{
+		Iss:      obj.Issuer,
+		Sub:      oidcClient.ClientId,
+		Aud:      oidcClient.MachineAudiences,
+		Exp:      now.Add(session.AccessTokenLifetime).Unix(),
+		Iat:      now.Unix(),
+		Jti:      uuid.NewString(),
+		ClientId: oidcClient.ClientId,
+		Scope:    scope,
+		Cnf:      jwtutil.NewConfirmation(dpopJkt),
+	}
+	issued := &IssuedTokens{
+		AccessTokenClaims: accessToken,
+	}
+	issued.AccessToken, err = jwtutil.ClaimsToTypedJwt(accessToken, jwtutil.AccessTokenType, keyPair.Kid, rsaKey)
+	if err != nil {
+		return nil, err
+	}
+	return issued, nil
+}
@@ -0,0 +1,119 @@
+package tokens
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/keys"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+)
+
+// server provided nonces change every DPoPNonceLifetime, and the previous nonce is still accepted.
+// so a nonce is accepted for at least this long
+const DPoPNonceLifetime = 5 * time.Minute
+
+// a fresh server provided nonce, for the DPoP-Nonce response header
+// see https://datatracker.ietf.org/doc/html/rfc9449#section-8
+func (obj *TokenService) NewDPoPNonce(ctx context.Context) (string, error) {
+	return obj.dpopNonce(ctx, dpopNonceWindow(time.Now()))
+}
+
+func dpopNonceWindow(now time.Time) int64 {
+	return now.Unix() / int64(DPoPNonceLifetime.Seconds())
+}
+
+// nonces are an HMAC of the time window, so nothing is stored for them (and unauthenticated
+// requests can't fill up the jti store). the HMAC key is the current key's nonce key
+func (obj *TokenService) dpopNonce(ctx context.Context, window int64) (string, error) {
+	nonceKey, err := keys.GetCurrentNonceKey(ctx, obj.DaoSource.GetKeyStore(ctx))
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, nonceKey)
+	fmt.Fprintf(mac, "%v:%v:%v", jwtutil.DPoPNonceNamespace, obj.Issuer, window)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// the nonce for the current or previous time window
+func (obj *TokenService) isValidDPoPNonce(ctx context.Context, nonce string) (bool, error) {
+	window := dpopNonceWindow(time.Now())
+	for _, nonceWindow := range []int64{window, window - 1} {
+		expected, err := obj.dpopNonce(ctx, nonceWindow)
+		if err != nil {
+			return false, err
+		}
+		if hmac.Equal([]byte(nonce), []byte(expected)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// verifies a proof sent directly to simple-oidc for the given endpoint path (eg: /token).
+// every proof must carry a current server provided nonce, and may only be used once.
+// accessToken is only set when the proof is presented with an access token
+func (obj *TokenService) VerifyDPoPProof(ctx context.Context, proof string, htm string, path string, accessToken string) (*jwtutil.DPoPProof, error) {
+	parsed, err := jwtutil.ParseDPoPProof(proof)
+	if err != nil {
+		return nil, oautherror.New(oautherror.InvalidDPoPProof, "%v", err)
+	}
+	err = parsed.Verify(htm, fmt.Sprintf("%v%v", obj.Issuer, path), accessToken)
+	if err != nil {
+		return nil, oautherror.New(oautherror.InvalidDPoPProof, "%v", err)
+	}
+	if parsed.Claims.Nonce == "" {
+		return nil, oautherror.New(oautherror.UseDPoPNonce, "nonce required")
+	}
+	validNonce, err := obj.isValidDPoPNonce(ctx, parsed.Claims.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	if !validNonce {
+		return nil, oautherror.New(oautherror.UseDPoPNonce, "unknown or expired nonce")
+	}
+	err = obj.recordDPoPProof(ctx, parsed)
+	if err != nil {
+		return nil, oautherror.New(oautherror.InvalidDPoPProof, "%v", err)
+	}
+	return parsed, nil
+}
+
+// a proof is only accepted inside the iat window, so only needs to be remembered until then
+func (obj *TokenService) recordDPoPProof(ctx context.Context, parsed *jwtutil.DPoPProof) error {
+	return jwtutil.RecordJti(
+		ctx,
+		obj.DaoSource.GetJtiStore(ctx),
+		fmt.Sprintf("%v:%v", jwtutil.DPoPProofNamespace, parsed.Jkt),
+		parsed.Claims.Jti,
+		time.Unix(parsed.Claims.Iat, 0).Add(jwtutil.DPoPProofWindow),
+	)
+}
+
+// a DPoP proof that a resource server was sent with an access token, and the method and uri
+// of the request that it was sent with
+type ForwardedDPoPProof struct {
+	Proof string
+	Htm   string
+	Htu   string
+}
+
+// a forwarded proof must be from the bound key, for the resource server's request, and is
+// only accepted once (the same as a proof sent directly to simple-oidc)
+func (obj *TokenService) isForwardedDPoPProofValid(ctx context.Context, forwarded *ForwardedDPoPProof, accessToken string, claims *jwtutil.AccessToken) bool {
+	if forwarded == nil || forwarded.Proof == "" || forwarded.Htm == "" || forwarded.Htu == "" {
+		return false
+	}
+	parsed, err := jwtutil.ParseDPoPProof(forwarded.Proof)
+	if err != nil {
+		return false
+	}
+	if parsed.Jkt != claims.BoundJkt() || parsed.Verify(forwarded.Htm, forwarded.Htu, accessToken) != nil {
+		return false
+	}
+	return obj.recordDPoPProof(ctx, parsed) == nil
+}
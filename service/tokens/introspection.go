@@ -0,0 +1,105 @@
+package tokens
+
+import (
+	"context"
+
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+)
+
+// the state of a token, as seen by the authorization server.
+// inactive tokens carry no further details
+// see https://datatracker.ietf.org/doc/html/rfc7662#section-2.2
+type TokenIntrospection struct {
+	Active    bool
+	TokenType string // only set for access tokens
+	Iss       string
+	Sub       string
+	ClientId  string
+	Scope     string
+	Sid       string
+	Exp       int64
+	Iat       int64
+	Nbf       int64
+	Jkt       string // the DPoP key a bound access token is confirmed by
+}
+
+var inactiveToken = &TokenIntrospection{}
+
+// introspects an access or refresh token.
+// the token must be valid now, AND the session it belongs to must still be active.
+// refresh tokens are only reported to the client they were issued to.
+// DPoP bound access tokens are only reported as active with a proof from the bound key,
+// forwarded by the resource server the token was presented to.
+func (obj *TokenService) IntrospectToken(ctx context.Context, token string, oidcClient *client.Client, dpopProof *ForwardedDPoPProof) (*TokenIntrospection, error) {
+	if jwtutil.JwtHasType(token, jwtutil.AccessTokenType) {
+		claims := &jwtutil.AccessToken{}
+		if jwtutil.ParseJwt(ctx, token, obj.DaoSource.GetKeyStore(ctx), obj.Issuer, claims) != nil {
+			return inactiveToken, nil
+		}
+		revoked, err := obj.IsAccessTokenRevoked(ctx, claims)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return inactiveToken, nil
+		}
+		tokenType := "Bearer"
+		if claims.BoundJkt() != "" {
+			if !obj.isForwardedDPoPProofValid(ctx, dpopProof, token, claims) {
+				return inactiveToken, nil
+			}
+			tokenType = jwtutil.DPoPTokenType
+		}
+		if claims.Sid != "" {
+			ses, err := obj.DaoSource.GetSessionStore(ctx).LoadSession(ctx, claims.Sid, claims.Sub)
+			if err != nil {
+				return nil, err
+			}
+			if !isActiveSession(ses) {
+				return inactiveToken, nil
+			}
+		}
+		return &TokenIntrospection{
+			Active:    true,
+			TokenType: tokenType,
+			Iss:       claims.Iss,
+			Sub:       claims.Sub,
+			ClientId:  claims.ClientId,
+			Scope:     claims.Scope,
+			Sid:       claims.Sid,
+			Exp:       claims.Exp,
+			Iat:       claims.Iat,
+			Jkt:       claims.BoundJkt(),
+		}, nil
+	}
+
+	claims := &jwtutil.RefreshClaimsJwt{}
+	if !jwtutil.JwtHasType(token, jwtutil.RefreshTokenType) || jwtutil.ParseJwt(ctx, token, obj.DaoSource.GetKeyStore(ctx), obj.Issuer, claims) != nil || claims.Ses == "" || claims.Code == "" {
+		return inactiveToken, nil
+	}
+	ses, err := obj.DaoSource.GetSessionStore(ctx).LoadSession(ctx, claims.Ses, claims.Sub)
+	if err != nil {
+		return nil, err
+	}
+	// a rotated refresh token can no longer be used
+	if !isActiveSession(ses) || ses.ClientId != oidcClient.ClientId || ses.RefreshCode != claims.Code {
+		return inactiveToken, nil
+	}
+	return &TokenIntrospection{
+		Active:   true,
+		Iss:      claims.Iss,
+		Sub:      claims.Sub,
+		ClientId: ses.ClientId,
+		Scope:    ses.Scope,
+		Sid:      ses.SessionId,
+		Exp:      claims.Exp,
+		Iat:      claims.Iat,
+		Nbf:      claims.Nbf,
+	}, nil
+}
+
+func isActiveSession(ses *session.Session) bool {
+	return ses != nil && ses.IsActive()
+}
@@ -0,0 +1,36 @@
+package tokens
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+)
+
+// logout tokens are delivered immediately, so only need to be valid for long enough to be processed
+const LogoutTokenLifetime = 2 * time.Minute
+
+// the signed logout token for a session that has ended
+// see https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+func (obj *TokenService) IssueLogoutToken(ctx context.Context, ses *session.Session) (string, error) {
+	keyPair, rsaKey, err := obj.signingKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	logoutToken := &jwtutil.LogoutToken{
+		Iss: obj.Issuer,
+		Sub: ses.UserId,
+		Aud: []string{ses.ClientId},
+		Iat: now.Unix(),
+		Exp: now.Add(LogoutTokenLifetime).Unix(),
+		Jti: uuid.NewString(),
+		Sid: ses.SessionId,
+		Events: map[string]map[string]any{
+			jwtutil.BackChannelLogoutEvent: {},
+		},
+	}
+	return jwtutil.ClaimsToTypedJwt(logoutToken, jwtutil.LogoutTokenType, keyPair.Kid, rsaKey)
+}
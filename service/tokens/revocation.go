@@ -0,0 +1,55 @@
+package tokens
+
+import (
+	"context"
+	"time"
+
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+)
+
+// revokes an access or refresh token, and the session it belongs to.
+// the token type is determined from the token itself, so no type hint is needed.
+// invalid (or unknown) tokens are ignored
+// see https://datatracker.ietf.org/doc/html/rfc7009#section-2.1
+func (obj *TokenService) RevokeToken(ctx context.Context, token string, oidcClient *client.Client) error {
+	if jwtutil.JwtHasType(token, jwtutil.AccessTokenType) {
+		claims := &jwtutil.AccessToken{}
+		if jwtutil.ParseSignedJwt(ctx, token, obj.DaoSource.GetKeyStore(ctx), claims) != nil || claims.Iss != obj.Issuer {
+			return nil
+		}
+		if claims.ClientId != oidcClient.ClientId {
+			return oautherror.New(oautherror.UnauthorizedClient, "token was not issued to client %v", oidcClient.ClientId)
+		}
+		err := jwtutil.SaveJti(ctx, obj.DaoSource.GetJtiStore(ctx), jwtutil.RevokedAccessTokenNamespace, claims.Jti, time.Unix(claims.Exp, 0))
+		if err != nil {
+			return err
+		}
+		if claims.Sid == "" {
+			return nil
+		}
+		return session.RevokeSession(ctx, obj.DaoSource.GetSessionStore(ctx), claims.Sid, claims.Sub)
+	}
+
+	claims := &jwtutil.RefreshClaimsJwt{}
+	if !jwtutil.JwtHasType(token, jwtutil.RefreshTokenType) || jwtutil.ParseSignedJwt(ctx, token, obj.DaoSource.GetKeyStore(ctx), claims) != nil || claims.Iss != obj.Issuer || claims.Ses == "" || claims.Code == "" {
+		return nil
+	}
+	ses, err := obj.DaoSource.GetSessionStore(ctx).LoadSession(ctx, claims.Ses, claims.Sub)
+	if err != nil {
+		return err
+	}
+	if ses == nil {
+		return nil
+	}
+	if ses.ClientId != oidcClient.ClientId {
+		return oautherror.New(oautherror.UnauthorizedClient, "token was not issued to client %v", oidcClient.ClientId)
+	}
+	return session.RevokeSession(ctx, obj.DaoSource.GetSessionStore(ctx), ses.SessionId, ses.UserId)
+}
+
+func (obj *TokenService) IsAccessTokenRevoked(ctx context.Context, claims *jwtutil.AccessToken) (bool, error) {
+	return jwtutil.IsJtiRecorded(ctx, obj.DaoSource.GetJtiStore(ctx), jwtutil.RevokedAccessTokenNamespace, claims.Jti)
+}
@@ -0,0 +1,230 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/oautherror"
+	"github.com/kncept-oauth/simple-oidc/service/scopes"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+)
+
+// see https://datatracker.ietf.org/doc/html/rfc8693#section-2.1
+type TokenExchangeRequest struct {
+	SubjectToken       string
+	SubjectTokenType   string
+	ActorToken         string // optional, for delegation
+	ActorTokenType     string
+	RequestedTokenType string // only access tokens are issued
+	Audience           string // optional if the policy only allows a single audience
+	Scope              string
+
+	// the issued token is bound to the DPoP key from the token request, if any
+	DPoPJkt string
+}
+
+// exchanges a subject token (and optional actor token) for an access token for another audience.
+// with an actor token the issued token carries an act claim, so the resource server can see
+// who is acting on behalf of the subject.
+// a subject asserted by the client itself (impersonation) always requires an actor token.
+// access tokens are only exchangeable by the client they were issued to (or intended for),
+// and the actor token must have been issued to the client
+// see https://datatracker.ietf.org/doc/html/rfc8693
+func (obj *TokenService) ExchangeToken(ctx context.Context, oidcClient *client.Client, req *TokenExchangeRequest) (*IssuedTokens, error) {
+	if !oidcClient.AllowsTokenExchange() {
+		return nil, oautherror.New(oautherror.UnauthorizedClient, "token exchange not allowed for client")
+	}
+	policy := oidcClient.TokenExchangePolicy
+	if req.RequestedTokenType != "" && req.RequestedTokenType != jwtutil.TokenTypeAccessToken {
+		return nil, oautherror.New(oautherror.InvalidRequest, "unsupported requested_token_type: %v", req.RequestedTokenType)
+	}
+	audience := req.Audience
+	if audience == "" && len(policy.Audiences) == 1 {
+		audience = policy.Audiences[0]
+	}
+	if !policy.AllowsAudience(audience) {
+		return nil, oautherror.New(oautherror.InvalidTarget, "audience not allowed for client: %v", audience)
+	}
+	if req.SubjectToken == "" {
+		return nil, oautherror.New(oautherror.InvalidRequest, "parameter \"subject_token\" not set")
+	}
+
+	var subject *jwtutil.AccessToken
+	var scope string
+	var err error
+	switch req.SubjectTokenType {
+	case jwtutil.TokenTypeAccessToken:
+		subject, err = obj.exchangeableAccessToken(ctx, req.SubjectToken, req.DPoPJkt)
+		if err != nil {
+			return nil, err
+		}
+		if subject.ClientId != oidcClient.ClientId && !subject.HasAudience(oidcClient.ClientId) {
+			return nil, oautherror.New(oautherror.InvalidGrant, "subject token was not issued to client")
+		}
+		scope, err = exchangedScope(req.Scope, subject.Scope)
+		if err != nil {
+			return nil, err
+		}
+	case jwtutil.TokenTypeJwt:
+		if req.ActorToken == "" {
+			return nil, oautherror.New(oautherror.InvalidRequest, "actor_token required for an asserted subject")
+		}
+		subject, err = obj.assertedSubject(ctx, oidcClient, req.SubjectToken)
+		if err != nil {
+			return nil, err
+		}
+		scope, err = oidcClient.GrantScopes(req.Scope)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, oautherror.New(oautherror.InvalidRequest, "unsupported subject_token_type: %v", req.SubjectTokenType)
+	}
+	if !policy.AllowsSubject(subject.Sub) {
+		return nil, oautherror.New(oautherror.UnauthorizedClient, "subject not allowed for client: %v", subject.Sub)
+	}
+
+	// the current actor is the outermost act claim, any prior actors are kept
+	act := subject.Act
+	if req.ActorToken != "" {
+		if req.ActorTokenType != jwtutil.TokenTypeAccessToken {
+			return nil, oautherror.New(oautherror.InvalidRequest, "unsupported actor_token_type: %v", req.ActorTokenType)
+		}
+		actor, err := obj.exchangeableAccessToken(ctx, req.ActorToken, req.DPoPJkt)
+		if err != nil {
+			return nil, err
+		}
+		if actor.ClientId != oidcClient.ClientId {
+			return nil, oautherror.New(oautherror.InvalidGrant, "actor token was not issued to client")
+		}
+		if !policy.AllowsActor(actor.Sub) {
+			return nil, oautherror.New(oautherror.UnauthorizedClient, "actor not allowed for client: %v", actor.Sub)
+		}
+		act = &jwtutil.ActorClaim{
+			Sub:      actor.Sub,
+			ClientId: actor.ClientId,
+			Act:      subject.Act,
+		}
+	}
+
+	keyPair, rsaKey, err := obj.signingKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	exp := now.Add(session.AccessTokenLifetime).Unix()
+	// an exchanged token never outlives the token it was exchanged for
+	if subject.Exp != 0 && subject.Exp < exp {
+		exp = subject.Exp
+	}
+	accessToken := &jwtutil.AccessToken{
+		Iss:      obj.Issuer,
+		Sub:      subject.Sub,
+		Aud:      []string{audience},
+		Exp:      exp,
+		Iat:      now.Unix(),
+		Jti:      uuid.NewString(),
+		ClientId: oidcClient.ClientId,
+		Scope:    scope,
+		AuthTime: subject.AuthTime,
+		Sid:      subject.Sid,
+		Cnf:      jwtutil.NewConfirmation(req.DPoPJkt),
+		Act:      act,
+	}
+	issued := &IssuedTokens{
+		AccessTokenClaims: accessToken,
+	}
+	issued.AccessToken, err = jwtutil.ClaimsToTypedJwt(accessToken, jwtutil.AccessTokenType, keyPair.Kid, rsaKey)
+	if err != nil {
+		return nil, err
+	}
+	return issued, nil
+}
+
+// a current access token issued by simple-oidc. the token may be for any audience,
+// so the caller checks that it belongs to the exchanging client
+func (obj *TokenService) exchangeableAccessToken(ctx context.Context, token string, dpopJkt string) (*jwtutil.AccessToken, error) {
+	claims := &jwtutil.AccessToken{}
+	if !jwtutil.JwtHasType(token, jwtutil.AccessTokenType) || jwtutil.ParseJwt(ctx, token, obj.DaoSource.GetKeyStore(ctx), obj.Issuer, claims) != nil {
+		return nil, oautherror.New(oautherror.InvalidGrant, "invalid access token")
+	}
+	revoked, err := obj.IsAccessTokenRevoked(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, oautherror.New(oautherror.InvalidGrant, "access token revoked")
+	}
+	if claims.Sid != "" {
+		ses, err := obj.DaoSource.GetSessionStore(ctx).LoadSession(ctx, claims.Sid, claims.Sub)
+		if err != nil {
+			return nil, err
+		}
+		if !isActiveSession(ses) {
+			return nil, oautherror.New(oautherror.InvalidGrant, "session revoked")
+		}
+	}
+	// otherwise a stolen DPoP bound token could be exchanged for an unbound one
+	if claims.BoundJkt() != "" && claims.BoundJkt() != dpopJkt {
+		return nil, oautherror.New(oautherror.InvalidGrant, "DPoP bound access token requires a proof from the same key")
+	}
+	return claims, nil
+}
+
+// a user asserted by the client, signed with one of the client's own keys
+func (obj *TokenService) assertedSubject(ctx context.Context, oidcClient *client.Client, assertion string) (*jwtutil.AccessToken, error) {
+	jwks, err := oidcClient.PublicKeys(ctx)
+	if err != nil {
+		return nil, oautherror.Wrap(oautherror.InvalidGrant, err)
+	}
+	claims := &jwtutil.SubjectAssertionClaims{}
+	err = jwtutil.JwtToClaimsWithJwks(assertion, jwks, claims)
+	if err != nil {
+		return nil, oautherror.Wrap(oautherror.InvalidGrant, err)
+	}
+	err = claims.Verify(oidcClient.ClientId, obj.Issuer)
+	if err != nil {
+		return nil, oautherror.Wrap(oautherror.InvalidGrant, err)
+	}
+	err = jwtutil.RecordJti(
+		ctx,
+		obj.DaoSource.GetJtiStore(ctx),
+		fmt.Sprintf("subject-assertion:%v", oidcClient.ClientId),
+		claims.Jti,
+		time.Unix(claims.Exp, 0),
+	)
+	if err != nil {
+		return nil, oautherror.Wrap(oautherror.InvalidGrant, err)
+	}
+	user, err := obj.DaoSource.GetUserStore(ctx).GetUser(ctx, claims.Sub)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, oautherror.New(oautherror.InvalidGrant, "no such user: %v", claims.Sub)
+	}
+	return &jwtutil.AccessToken{
+		Sub: user.Id,
+	}, nil
+}
+
+// the exchanged token may only narrow the scope of the subject token
+func exchangedScope(requested string, subjectScope string) (string, error) {
+	requestedScopes := scopes.Parse(requested)
+	if len(requestedScopes) == 0 {
+		return subjectScope, nil
+	}
+	grantedScopes := scopes.Parse(subjectScope)
+	for _, scope := range requestedScopes {
+		if !slices.Contains(grantedScopes, scope) {
+			return "", oautherror.New(oautherror.InvalidScope, "scope not granted to subject token: %v", scope)
+		}
+	}
+	return strings.Join(requestedScopes, " "), nil
+}
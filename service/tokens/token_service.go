@@ -0,0 +1,114 @@
+package tokens
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	"github.com/kncept-oauth/simple-oidc/service/client"
+	"github.com/kncept-oauth/simple-oidc/service/dao"
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/keys"
+	"github.com/kncept-oauth/simple-oidc/service/session"
+)
+
+// issues (and signs) the tokens for a client session
+// shared by the token endpoint, and the front channel (implicit and hybrid) flows
+type TokenService struct {
+	DaoSource dao.DaoSource
+	Issuer    string
+}
+
+type IssueOptions struct {
+	// the access token is returned alongside the id token, so the at_hash claim is included
+	AccessToken bool
+	// authorization code returned alongside the id token (hybrid flow), for the c_hash claim
+	Code string
+	// refresh tokens are only ever returned from the token endpoint
+	RefreshToken bool
+}
+
+type IssuedTokens struct {
+	IdToken      string // not for client credentials
+	AccessToken  string
+	RefreshToken string // only if requested
+
+	AccessTokenClaims *jwtutil.AccessToken
+}
+
+func (obj *IssuedTokens) ExpiresIn() int64 {
+	return obj.AccessTokenClaims.Exp - obj.AccessTokenClaims.Iat
+}
+
+// the session is updated (refresh code rotated) and saved
+func (obj *TokenService) IssueTokens(ctx context.Context, ses *session.Session, oidcClient *client.Client, options IssueOptions) (*IssuedTokens, error) {
+	keyPair, rsaKey, err := obj.signingKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := obj.DaoSource.GetUserStore(ctx).GetUser(ctx, ses.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("no such user: %v", ses.UserId)
+	}
+
+	idToken, refreshToken := ses.IssueTokens(obj.Issuer, ses.ClientId)
+	// profile claims are released according to the granted scopes
+	idToken.StandardClaims = user.StandardClaims(ses.Scope)
+	accessToken := ses.IssueAccessToken(obj.Issuer, oidcClient.AccessTokenAudience(obj.Issuer)...)
+	err = obj.DaoSource.GetSessionStore(ctx).SaveSession(ctx, ses)
+	if err != nil {
+		return nil, err
+	}
+
+	issued := &IssuedTokens{
+		AccessTokenClaims: accessToken,
+	}
+	issued.AccessToken, err = jwtutil.ClaimsToTypedJwt(accessToken, jwtutil.AccessTokenType, keyPair.Kid, rsaKey)
+	if err != nil {
+		return nil, err
+	}
+	if options.AccessToken {
+		err = idToken.SetAccessTokenHash(issued.AccessToken)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if options.Code != "" {
+		err = idToken.SetCodeHash(options.Code)
+		if err != nil {
+			return nil, err
+		}
+	}
+	issued.IdToken, err = jwtutil.ClaimsToJwt(idToken, keyPair.Kid, rsaKey)
+	if err != nil {
+		return nil, err
+	}
+	if options.RefreshToken {
+		issued.RefreshToken, err = jwtutil.ClaimsToTypedJwt(refreshToken, jwtutil.RefreshTokenType, keyPair.Kid, rsaKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return issued, nil
+}
+
+func (obj *TokenService) signingKey(ctx context.Context) (*keys.JwkKeypair, *rsa.PrivateKey, error) {
+	keyPair, err := keys.GetCurrentKey(ctx, obj.DaoSource.GetKeyStore(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+	decodedKey, err := keyPair.DecodePrivateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	rsaKey, isRsaKey := decodedKey.(*rsa.PrivateKey)
+	if !isRsaKey {
+		return nil, nil, errors.New("not an rsa key")
+	}
+	return keyPair, rsaKey, nil
+}
@@ -0,0 +1,33 @@
+package users
+
+import (
+	"github.com/kncept-oauth/simple-oidc/service/jwtutil"
+	"github.com/kncept-oauth/simple-oidc/service/scopes"
+)
+
+// the profile claims released for the granted (space separated) scope
+func (obj *OidcUser) StandardClaims(scope string) *jwtutil.StandardClaims {
+	profile := obj.Profile
+	all := &jwtutil.StandardClaims{
+		Name:              profile.Name,
+		GivenName:         profile.GivenName,
+		FamilyName:        profile.FamilyName,
+		PreferredUsername: obj.Id,
+		Picture:           profile.Picture,
+		Zoneinfo:          profile.Zoneinfo,
+		Locale:            profile.Locale,
+		Email:             profile.Email,
+		PhoneNumber:       profile.PhoneNumber,
+	}
+	// verified flags are meaningless without the value they refer to
+	if profile.Email != "" {
+		all.EmailVerified = &profile.EmailVerified
+	}
+	if profile.PhoneNumber != "" {
+		all.PhoneNumberVerified = &profile.PhoneNumberVerified
+	}
+	if !profile.UpdatedAt.IsZero() {
+		all.UpdatedAt = profile.UpdatedAt.Unix()
+	}
+	return all.Released(scopes.ClaimsFor(scope))
+}
@@ -0,0 +1,38 @@
+package users
+
+import "testing"
+
+func TestStandardClaimsReleasedByScope(t *testing.T) {
+	user := &OidcUser{
+		Id: "username",
+	}
+	if claims := user.StandardClaims("openid"); claims != nil {
+		t.Fatalf("no profile claims should be released without the profile scope: %+v", claims)
+	}
+	claims := user.StandardClaims("openid profile")
+	if claims == nil || claims.PreferredUsername != "username" {
+		t.Fatalf("profile scope should release preferred_username: %+v", claims)
+	}
+}
+
+func TestProfileClaimsReleasedByScope(t *testing.T) {
+	user := &OidcUser{
+		Id: "username",
+		Profile: UserProfile{
+			Name:        "User Name",
+			Email:       "user@example.com",
+			PhoneNumber: "+61400000000",
+		},
+	}
+	claims := user.StandardClaims("openid email")
+	if claims.Email != "user@example.com" || claims.EmailVerified == nil || *claims.EmailVerified {
+		t.Fatalf("email scope should release email and email_verified: %+v", claims)
+	}
+	if claims.Name != "" || claims.PhoneNumber != "" {
+		t.Fatalf("only the email claims should be released: %+v", claims)
+	}
+	claims = user.StandardClaims("openid profile phone")
+	if claims.Name != "User Name" || claims.PhoneNumber != "+61400000000" || claims.Email != "" {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+}
@@ -0,0 +1,84 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// the user editable profile, released as standard claims according to the granted scopes
+// see https://openid.net/specs/openid-connect-core-1_0.html#StandardClaims
+type UserProfile struct {
+	Name       string `dynamodbav:"name"`
+	GivenName  string `dynamodbav:"givenName"`
+	FamilyName string `dynamodbav:"familyName"`
+	Picture    string `dynamodbav:"picture"`  // url
+	Locale     string `dynamodbav:"locale"`   // BCP47, eg: en-AU
+	Zoneinfo   string `dynamodbav:"zoneinfo"` // IANA time zone, eg: Australia/Sydney
+
+	Email         string `dynamodbav:"email"`
+	EmailVerified bool   `dynamodbav:"emailVerified"`
+
+	PhoneNumber         string `dynamodbav:"phoneNumber"` // E.164, eg: +61400000000
+	PhoneNumberVerified bool   `dynamodbav:"phoneNumberVerified"`
+
+	UpdatedAt time.Time `dynamodbav:"updatedAt"`
+}
+
+var localeRegexp = regexp.MustCompile(`^[a-zA-Z]{2,3}([-_][a-zA-Z0-9]{2,8})*$`)
+var phoneNumberRegexp = regexp.MustCompile(`^\+?[0-9 ()-]{4,20}$`)
+
+func (obj *UserProfile) Validate() error {
+	if obj.Email != "" {
+		address, err := mail.ParseAddress(obj.Email)
+		if err != nil || address.Address != obj.Email {
+			return fmt.Errorf("invalid email: %v", obj.Email)
+		}
+	}
+	if obj.PhoneNumber != "" && !phoneNumberRegexp.MatchString(obj.PhoneNumber) {
+		return fmt.Errorf("invalid phone number: %v", obj.PhoneNumber)
+	}
+	if obj.Picture != "" {
+		u, err := url.Parse(obj.Picture)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("invalid picture url: %v", obj.Picture)
+		}
+	}
+	if obj.Locale != "" && !localeRegexp.MatchString(obj.Locale) {
+		return fmt.Errorf("invalid locale: %v", obj.Locale)
+	}
+	if obj.Zoneinfo != "" {
+		if _, err := time.LoadLocation(obj.Zoneinfo); err != nil {
+			return fmt.Errorf("invalid zoneinfo: %v", obj.Zoneinfo)
+		}
+	}
+	return nil
+}
+
+// users may edit their own profile, but can not verify their own email or phone number.
+// changing either one clears its verified flag
+func (obj UserService) UpdateProfile(ctx context.Context, userId string, profile UserProfile) (*OidcUser, error) {
+	err := profile.Validate()
+	if err != nil {
+		return nil, err
+	}
+	user, err := obj.UserStore.GetUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("no such user: %v", userId)
+	}
+	profile.EmailVerified = user.Profile.EmailVerified && profile.Email == user.Profile.Email
+	profile.PhoneNumberVerified = user.Profile.PhoneNumberVerified && profile.PhoneNumber == user.Profile.PhoneNumber
+	profile.UpdatedAt = time.Now().UTC()
+	user.Profile = profile
+	err = obj.UserStore.SaveUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,81 @@
+package users
+
+import (
+	"context"
+	"testing"
+)
+
+type mapUserStore map[string]*OidcUser
+
+func (obj mapUserStore) GetUser(ctx context.Context, id string) (*OidcUser, error) {
+	return obj[id], nil
+}
+func (obj mapUserStore) SaveUser(ctx context.Context, user *OidcUser) error {
+	obj[user.Id] = user
+	return nil
+}
+func (obj mapUserStore) EnumerateUsers(ctx context.Context, callback func(user *OidcUser) bool) error {
+	return nil
+}
+
+func TestValidateProfile(t *testing.T) {
+	valid := UserProfile{
+		Email:       "user@example.com",
+		PhoneNumber: "+61 400 000 000",
+		Picture:     "https://example.com/me.png",
+		Locale:      "en-AU",
+		Zoneinfo:    "Australia/Sydney",
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("%v", err)
+	}
+	for _, invalid := range []UserProfile{
+		{Email: "User <user@example.com>"},
+		{PhoneNumber: "call me"},
+		{Picture: "javascript:alert(1)"},
+		{Locale: "english please"},
+		{Zoneinfo: "Nowhere/Special"},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("expected invalid profile: %+v", invalid)
+		}
+	}
+}
+
+func TestUpdateProfileClearsVerification(t *testing.T) {
+	ctx := t.Context()
+	store := mapUserStore{}
+	store.SaveUser(ctx, &OidcUser{
+		Id: "user",
+		Profile: UserProfile{
+			Email:         "user@example.com",
+			EmailVerified: true,
+		},
+	})
+	userService := UserService{
+		UserStore: store,
+	}
+
+	user, err := userService.UpdateProfile(ctx, "user", UserProfile{
+		Name:          "User Name",
+		Email:         "user@example.com",
+		EmailVerified: false, // not user editable
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !user.Profile.EmailVerified || user.Profile.Name != "User Name" || user.Profile.UpdatedAt.IsZero() {
+		t.Fatalf("unexpected profile: %+v", user.Profile)
+	}
+
+	user, err = userService.UpdateProfile(ctx, "user", UserProfile{
+		Email:         "other@example.com",
+		EmailVerified: true, // not user editable
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if user.Profile.EmailVerified {
+		t.Fatalf("changing the email must clear the verified flag")
+	}
+}
@@ -25,6 +25,8 @@ type OidcUser struct {
 	Id              string `dynamodbav:"id"`
 	Salt            string `dynamodbav:"salt"`
 	EncodedPassword string `dynamodbav:"pass"`
+
+	Profile UserProfile `dynamodbav:"profile"`
 }
 
 type EncodingType string
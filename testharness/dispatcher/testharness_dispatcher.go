@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kncept-oauth/simple-oidc/service/client"
@@ -23,6 +24,9 @@ import (
 
 const staticClientId = "static-client-id"
 
+// testharness only: a real client secret must never be hardcoded
+const staticClientSecret = "static-client-secret"
+
 type testApp struct {
 	message         string
 	fiberOidcConfig *fiberoidc.Config
@@ -56,10 +60,18 @@ func NewApplication(daoSource dao.DaoSource) *fiber.App {
 			AllowedRedirectUris: []string{
 				"https://localhost:3000/oauth2/callback",
 			},
+			PostLogoutRedirectUris: []string{
+				"https://localhost:3000/",
+			},
+			ClientType: client.ClientTypeConfidential,
 			// Audiences: []string{
 			// 	"https://localhost:3000/",
 			// },
 		}
+		err = staticClient.SetClientSecret(staticClientSecret)
+		if err != nil {
+			panic(err)
+		}
 		daoSource.GetClientStore(ctx).SaveClient(ctx, staticClient)
 	}
 
@@ -67,7 +79,7 @@ func NewApplication(daoSource dao.DaoSource) *fiber.App {
 		OidcProviderConfig: provider.OidcProviderConfig{
 			Issuer:       "https://localhost:8443",
 			ClientId:     staticClientId,
-			ClientSecret: fmt.Sprintf("client-secret-%v", uuid.NewString()),
+			ClientSecret: staticClientSecret,
 			RedirectUri:  "https://localhost:3000/oauth2/callback",
 		},
 		WebAppConfig: fiberoidc.WebAppConfig{
@@ -163,6 +175,24 @@ func (obj *testApp) PostIndex(c *fiber.Ctx) error {
 		} else {
 			obj.message = fmt.Sprintf("Error occurred:\n%v", err)
 		}
+	case "rotate-secret":
+		c, err := obj.daoSource.GetClientStore(ctx).GetClient(ctx, payload.Id)
+		if err != nil {
+			return err
+		}
+		if c == nil {
+			return errors.New("no client with id " + payload.Id)
+		}
+		c.ClientType = client.ClientTypeConfidential
+		secret, err := c.RotateClientSecret(time.Hour)
+		if err == nil {
+			err = obj.daoSource.GetClientStore(ctx).UpdateClient(ctx, c)
+		}
+		if err == nil {
+			obj.message = fmt.Sprintf("New client secret for %v (previous secret valid for 1 hour):\n%v", c.ClientId, secret)
+		} else {
+			obj.message = fmt.Sprintf("Error occurred:\n%v", err)
+		}
 	case "delete":
 		c, err := obj.daoSource.GetClientStore(ctx).GetClient(ctx, payload.Id)
 		if err != nil {
@@ -11,6 +11,7 @@ import (
 	"syscall"
 
 	"github.com/gofiber/fiber/v2"
+	serviceclient "github.com/kncept-oauth/simple-oidc/service/client"
 	"github.com/kncept-oauth/simple-oidc/service/crypto"
 	"github.com/kncept-oauth/simple-oidc/service/dao"
 	servicedao "github.com/kncept-oauth/simple-oidc/service/dao"
@@ -31,6 +32,9 @@ func main() {
 	serviceApp, err := servicedispatcher.NewApplication(
 		daoSource,
 		"https://localhost:8443",
+		&serviceclient.RegistrationSettings{
+			Policy: serviceclient.RegistrationPolicyOpen,
+		},
 		&devModeLiveFilesystemBase,
 	)
 	if err != nil {